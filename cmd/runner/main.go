@@ -0,0 +1,204 @@
+// Command runner is the worker loop executed by remote runner agents and by
+// Kubernetes worker pods. It leases tasks from the platform's runner API,
+// downloads the audio via presigned URLs, calls the vendor adapter locally
+// and pushes the raw outcome back; scoring stays server-side.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// leasedTask mirrors the runner API's lease response item.
+type leasedTask struct {
+	Task     *models.RunnerTask `json:"task"`
+	TestCase struct {
+		ID          int64  `json:"id"`
+		Language    string `json:"language"`
+		AudioFormat string `json:"audio_format"`
+	} `json:"test_case"`
+	AudioURL     string               `json:"audio_url"`
+	VendorConfig *models.VendorConfig `json:"vendor_config"`
+}
+
+// taskResult mirrors the runner API's result-push request body.
+type taskResult struct {
+	RecognizedText    string          `json:"recognized_text,omitempty"`
+	Confidence        float64         `json:"confidence,omitempty"`
+	LatencyMS         int64           `json:"latency_ms"`
+	VendorLatencyMS   int64           `json:"vendor_latency_ms,omitempty"`
+	RawVendorResponse json.RawMessage `json:"raw_vendor_response,omitempty"`
+	ErrorCode         string          `json:"error_code,omitempty"`
+	ErrorMessage      string          `json:"error_message,omitempty"`
+}
+
+type runner struct {
+	serverURL string
+	token     string
+	client    *http.Client
+}
+
+func main() {
+	serverURL := os.Getenv("AITP_RUNNER_SERVER_URL")
+	token := os.Getenv("AITP_RUNNER_TOKEN")
+	if serverURL == "" || token == "" {
+		log.Fatal("AITP_RUNNER_SERVER_URL and AITP_RUNNER_TOKEN are required")
+	}
+	maxBatch := envInt("AITP_RUNNER_MAX_BATCH", 4)
+	exitWhenDrained := os.Getenv("AITP_RUNNER_EXIT_WHEN_DRAINED") == "true"
+
+	r := &runner{
+		serverURL: serverURL,
+		token:     token,
+		client:    &http.Client{Timeout: 5 * time.Minute},
+	}
+
+	ctx := context.Background()
+	idleRounds := 0
+	for {
+		tasks, err := r.lease(ctx, maxBatch)
+		if err != nil {
+			log.Printf("lease: %v", err)
+			time.Sleep(10 * time.Second)
+			continue
+		}
+		if len(tasks) == 0 {
+			idleRounds++
+			if exitWhenDrained && idleRounds >= 3 {
+				log.Print("pool drained, exiting")
+				return
+			}
+			time.Sleep(5 * time.Second)
+			continue
+		}
+		idleRounds = 0
+		for _, task := range tasks {
+			result := r.execute(ctx, task)
+			if err := r.push(ctx, task.Task.ID, result); err != nil {
+				log.Printf("task %d: push result: %v", task.Task.ID, err)
+			}
+		}
+	}
+}
+
+// execute downloads the task's audio and runs the vendor adapter. Failures
+// become errored results so every leased task still produces a result row.
+func (r *runner) execute(ctx context.Context, task leasedTask) *taskResult {
+	audio, err := r.download(ctx, task.AudioURL)
+	if err != nil {
+		return &taskResult{ErrorCode: "AUDIO_UNAVAILABLE", ErrorMessage: err.Error()}
+	}
+
+	adapter := vendoradapter.GetASRAdapter(task.VendorConfig)
+	req := &vendoradapter.ASRRequest{
+		Audio:    audio,
+		Format:   task.TestCase.AudioFormat,
+		Language: task.TestCase.Language,
+	}
+	start := time.Now()
+	resp, err := adapter.Recognize(ctx, req)
+	latency := time.Since(start).Milliseconds()
+
+	if err != nil {
+		result := &taskResult{LatencyMS: latency, ErrorMessage: err.Error()}
+		var adapterErr *vendoradapter.AdapterError
+		if errors.As(err, &adapterErr) {
+			result.ErrorCode = adapterErr.Code
+			result.ErrorMessage = adapterErr.Message
+		} else {
+			result.ErrorCode = vendoradapter.ErrCodeVendorError
+		}
+		return result
+	}
+	return &taskResult{
+		RecognizedText:    resp.Transcript,
+		Confidence:        resp.Confidence,
+		LatencyMS:         latency,
+		VendorLatencyMS:   resp.VendorProcessingMS,
+		RawVendorResponse: resp.RawResponse,
+	}
+}
+
+func (r *runner) lease(ctx context.Context, max int) ([]leasedTask, error) {
+	var out struct {
+		Tasks []leasedTask `json:"tasks"`
+	}
+	err := r.call(ctx, "/runner/lease", map[string]any{"max": max}, &out)
+	return out.Tasks, err
+}
+
+func (r *runner) push(ctx context.Context, taskID int64, result *taskResult) error {
+	path := fmt.Sprintf("/runner/tasks/%d/result", taskID)
+	return r.call(ctx, path, result, nil)
+}
+
+// call POSTs a JSON body to the runner API and decodes the response into out
+// when non-nil.
+func (r *runner) call(ctx context.Context, path string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.serverURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+r.token)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d: %.200s", path, resp.StatusCode, data)
+	}
+	if out != nil {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+func (r *runner) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("audio download returned %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,102 @@
+// Command server runs the AITestPlatform backend: the admin HTTP API, the
+// in-process job dispatcher and the evaluation engine.
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/apiserver"
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/config"
+	"github.com/Jcateye/AITestPlatform/internal/coreengine"
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/jobmanagement"
+	"github.com/Jcateye/AITestPlatform/internal/kubedispatch"
+	"github.com/Jcateye/AITestPlatform/internal/objectstore"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+
+	err = vendoradapter.ConfigureTransport(vendoradapter.TransportOptions{
+		MaxIdleConns:        cfg.HTTPMaxIdleConns,
+		MaxIdleConnsPerHost: cfg.HTTPMaxIdleConnsPerHost,
+		Timeout:             time.Duration(cfg.HTTPTimeoutSeconds) * time.Second,
+		RecordFixturesDir:   cfg.RecordFixturesDir,
+		BlockPrivateAddrs:   cfg.BlockPrivateEgress,
+		ProxyURL:            cfg.HTTPProxyURL,
+		CACertFile:          cfg.HTTPCACertFile,
+	})
+	if err != nil {
+		log.Fatalf("transport: %v", err)
+	}
+	if cfg.RecordFixturesDir != "" {
+		log.Printf("recording vendor HTTP fixtures to %s", cfg.RecordFixturesDir)
+	}
+	vendoradapter.SetAllowUnknownAdapters(cfg.AllowUnknownAdapters)
+	if cfg.AllowUnknownAdapters {
+		log.Printf("unknown adapter keys fall back to the mock adapter")
+	}
+	if cfg.VaultAddr != "" {
+		vendoradapter.RegisterSecretProvider("vault", &vendoradapter.VaultSecretProvider{
+			Addr:  cfg.VaultAddr,
+			Token: cfg.VaultToken,
+		})
+		log.Printf("resolving vault:// secret references against %s", cfg.VaultAddr)
+	}
+
+	ctx := context.Background()
+
+	store, err := datastore.Open(ctx, cfg.DatabaseURL, cfg.DatabaseReplicaURLs...)
+	if err != nil {
+		log.Fatalf("datastore: %v", err)
+	}
+	defer store.Close()
+	if err := store.EnsureSchema(ctx); err != nil {
+		log.Fatalf("schema: %v", err)
+	}
+	store.SetRawResponseCompression(cfg.CompressRawResponses)
+
+	objects, err := objectstore.New(ctx, objectstore.Options{
+		Endpoint:  cfg.MinioEndpoint,
+		AccessKey: cfg.MinioAccessKey,
+		SecretKey: cfg.MinioSecretKey,
+		Bucket:    cfg.MinioBucket,
+		UseSSL:    cfg.MinioUseSSL,
+	})
+	if err != nil {
+		log.Fatalf("objectstore: %v", err)
+	}
+
+	content := objectstore.NewContentStore(objects, store)
+	queryCache := cache.New()
+
+	engine := coreengine.New(store, objects, queryCache)
+	if cfg.KubeWorkerImage != "" {
+		kube, err := kubedispatch.New(kubedispatch.Options{
+			APIURL:      cfg.KubeAPIURL,
+			Namespace:   cfg.KubeNamespace,
+			WorkerImage: cfg.KubeWorkerImage,
+			CallbackURL: cfg.PublicURL,
+		})
+		if err != nil {
+			log.Fatalf("kubedispatch: %v", err)
+		}
+		engine.SetKubeDispatcher(kube)
+	}
+	jobs := jobmanagement.NewService(cfg, store, engine)
+	jobs.SetContentStore(content)
+	jobs.Start(ctx)
+
+	server := apiserver.New(cfg, store, objects, content, jobs, queryCache)
+	log.Printf("listening on %s", cfg.ListenAddr)
+	if err := server.Router().Run(cfg.ListenAddr); err != nil {
+		log.Fatalf("http server: %v", err)
+	}
+}
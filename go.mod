@@ -0,0 +1,10 @@
+module github.com/Jcateye/AITestPlatform
+
+go 1.22
+
+require (
+	github.com/gin-gonic/gin v1.10.0
+	github.com/lib/pq v1.10.9
+	github.com/minio/minio-go/v7 v7.0.70
+	github.com/parquet-go/parquet-go v0.23.0
+)
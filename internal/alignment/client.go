@@ -0,0 +1,70 @@
+// Package alignment talks to an external forced-alignment service that maps
+// ground-truth words to audio timestamps. The platform does not bundle an
+// aligner; any service speaking the simple JSON contract below (such as a
+// wrapped MFA or CTC-segmentation model) can be pointed at via
+// AITP_ALIGNER_URL.
+package alignment
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// Client calls the configured aligner service.
+type Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewClient builds a Client for the aligner at baseURL. Alignment of long
+// recordings is slow, so the timeout is generous.
+func NewClient(baseURL string) *Client {
+	return &Client{baseURL: baseURL, client: &http.Client{Timeout: 5 * time.Minute}}
+}
+
+// Align sends the audio and its transcript to the aligner and returns one
+// span per ground-truth word, in order.
+func (c *Client) Align(ctx context.Context, audio []byte, format, language, text string) ([]models.WordAlignment, error) {
+	body, err := json.Marshal(map[string]string{
+		"audio":    base64.StdEncoding.EncodeToString(audio),
+		"format":   format,
+		"language": language,
+		"text":     text,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal align request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/align", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call aligner: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read aligner response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("aligner returned %d: %.200s", resp.StatusCode, raw)
+	}
+	var parsed struct {
+		Words []models.WordAlignment `json:"words"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, fmt.Errorf("unparseable aligner response: %w", err)
+	}
+	return parsed.Words, nil
+}
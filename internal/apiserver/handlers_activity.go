@@ -0,0 +1,133 @@
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// activityEvent is one entry of a project's activity feed, normalized from
+// the underlying domain tables.
+type activityEvent struct {
+	Type       string    `json:"type"`
+	RefID      int64     `json:"ref_id"`
+	Message    string    `json:"message"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// ProjectActivityHandler returns a merged, newest-first feed of recent
+// project events: jobs submitted and finished, test cases added, vendor
+// configs created or edited, and platform notifications such as budget
+// warnings and regressions. Paginate with ?limit= (default 50) and ?offset=.
+func (s *Server) ProjectActivityHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	limit := int(queryInt64(c, "limit", 50))
+	offset := int(queryInt64(c, "offset", 0))
+	if limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	ctx := c.Request.Context()
+
+	var events []activityEvent
+
+	jobs, err := s.store.ListEvaluationJobs(ctx, id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	for _, job := range jobs {
+		events = append(events, activityEvent{
+			Type:       "job_created",
+			RefID:      job.ID,
+			Message:    fmt.Sprintf("job %q submitted", job.Name),
+			OccurredAt: job.CreatedAt,
+		})
+		if job.CompletedAt != nil {
+			eventType := "job_completed"
+			message := fmt.Sprintf("job %q completed", job.Name)
+			if job.Status != "COMPLETED" {
+				eventType = "job_finished"
+				message = fmt.Sprintf("job %q finished as %s", job.Name, job.Status)
+			}
+			events = append(events, activityEvent{
+				Type:       eventType,
+				RefID:      job.ID,
+				Message:    message,
+				OccurredAt: *job.CompletedAt,
+			})
+		}
+	}
+
+	testCases, err := s.store.ListASRTestCases(ctx, id, "")
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	for _, tc := range testCases {
+		events = append(events, activityEvent{
+			Type:       "test_case_created",
+			RefID:      tc.ID,
+			Message:    fmt.Sprintf("test case %q added", tc.Name),
+			OccurredAt: tc.CreatedAt,
+		})
+	}
+
+	vendorConfigs, err := s.store.ListVendorConfigs(ctx, id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	for _, vc := range vendorConfigs {
+		events = append(events, activityEvent{
+			Type:       "vendor_config_created",
+			RefID:      vc.ID,
+			Message:    fmt.Sprintf("vendor config %q created", vc.Name),
+			OccurredAt: vc.CreatedAt,
+		})
+		if vc.UpdatedAt.After(vc.CreatedAt.Add(time.Second)) {
+			events = append(events, activityEvent{
+				Type:       "vendor_config_updated",
+				RefID:      vc.ID,
+				Message:    fmt.Sprintf("vendor config %q edited", vc.Name),
+				OccurredAt: vc.UpdatedAt,
+			})
+		}
+	}
+
+	notifications, err := s.store.ListNotifications(ctx, id, false)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	for _, n := range notifications {
+		events = append(events, activityEvent{
+			Type:       "notification_" + n.Kind,
+			RefID:      n.ID,
+			Message:    n.Message,
+			OccurredAt: n.CreatedAt,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].OccurredAt.After(events[j].OccurredAt)
+	})
+	total := len(events)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"project_id": id,
+		"total":      total,
+		"events":     events[offset:end],
+	})
+}
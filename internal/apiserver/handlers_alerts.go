@@ -0,0 +1,101 @@
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateAlertRuleHandler registers an alert rule for a project.
+func (s *Server) CreateAlertRuleHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		Name           string  `json:"name" binding:"required"`
+		Metric         string  `json:"metric" binding:"required"`
+		VendorConfigID int64   `json:"vendor_config_id"`
+		Tag            string  `json:"tag"`
+		Comparison     string  `json:"comparison" binding:"required"`
+		Threshold      float64 `json:"threshold"`
+		WindowJobs     int     `json:"window_jobs"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !models.ValidAlertMetric(req.Metric) {
+		errorJSON(c, http.StatusBadRequest, fmt.Sprintf("metric must be one of %s", strings.Join(models.AlertMetrics, ", ")))
+		return
+	}
+	if req.Comparison != ">" && req.Comparison != "<" {
+		errorJSON(c, http.StatusBadRequest, `comparison must be ">" or "<"`)
+		return
+	}
+	if req.WindowJobs < 0 {
+		errorJSON(c, http.StatusBadRequest, "window_jobs must be positive")
+		return
+	}
+	if req.WindowJobs == 0 {
+		req.WindowJobs = 1
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), projectID); err != nil {
+		storeError(c, err)
+		return
+	}
+	rule := &models.AlertRule{
+		ProjectID:  projectID,
+		Name:       req.Name,
+		Metric:     req.Metric,
+		Tag:        req.Tag,
+		Comparison: req.Comparison,
+		Threshold:  req.Threshold,
+		WindowJobs: req.WindowJobs,
+		Enabled:    true,
+	}
+	if req.VendorConfigID > 0 {
+		if _, err := s.store.GetVendorConfig(c.Request.Context(), req.VendorConfigID); err != nil {
+			storeError(c, err)
+			return
+		}
+		rule.VendorConfigID = &req.VendorConfigID
+	}
+	if err := s.store.CreateAlertRule(c.Request.Context(), rule); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, rule)
+}
+
+// ListAlertRulesHandler lists a project's alert rules, including their
+// current firing state.
+func (s *Server) ListAlertRulesHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	rules, err := s.store.ListAlertRules(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"rules": rules})
+}
+
+// DeleteAlertRuleHandler removes an alert rule.
+func (s *Server) DeleteAlertRuleHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteAlertRule(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": id})
+}
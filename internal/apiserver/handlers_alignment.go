@@ -0,0 +1,79 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// AlignTestCaseHandler runs the configured forced aligner over a test case,
+// persisting the word-level timestamps. With ?segment_ms=N the alignment is
+// additionally bucketed into ground-truth segments of roughly N milliseconds,
+// which switches on per-segment scoring for subsequent jobs. The word spans
+// power error heatmaps over time and playback at error positions.
+func (s *Server) AlignTestCaseHandler(c *gin.Context) {
+	if s.aligner == nil {
+		errorJSON(c, http.StatusServiceUnavailable, "no aligner configured (AITP_ALIGNER_URL)")
+		return
+	}
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	tc, err := s.store.GetASRTestCase(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	audio, err := s.objects.Get(c.Request.Context(), tc.AudioObjectKey)
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, "fetch audio: "+err.Error())
+		return
+	}
+
+	alignments, err := s.aligner.Align(c.Request.Context(), audio, tc.AudioFormat, tc.Language, tc.GroundTruth)
+	if err != nil {
+		errorJSON(c, http.StatusBadGateway, err.Error())
+		return
+	}
+	if err := s.store.SetASRTestCaseAlignment(c.Request.Context(), id, alignments); err != nil {
+		storeError(c, err)
+		return
+	}
+
+	var segments []models.Segment
+	if segmentMS := queryInt64(c, "segment_ms", 0); segmentMS > 0 {
+		segments = segmentsFromAlignment(alignments, segmentMS)
+		if err := s.store.SetASRTestCaseSegments(c.Request.Context(), id, segments); err != nil {
+			storeError(c, err)
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"asr_test_case_id": id,
+		"word_alignments":  alignments,
+		"segments":         segments,
+	})
+}
+
+// segmentsFromAlignment buckets aligned words into segments of roughly
+// targetMS, always breaking at word boundaries.
+func segmentsFromAlignment(alignments []models.WordAlignment, targetMS int64) []models.Segment {
+	var segments []models.Segment
+	var current *models.Segment
+	for _, w := range alignments {
+		if current == nil {
+			segments = append(segments, models.Segment{StartMS: w.StartMS, EndMS: w.EndMS, Text: w.Word})
+			current = &segments[len(segments)-1]
+		} else {
+			current.Text += " " + w.Word
+			current.EndMS = w.EndMS
+		}
+		if current.EndMS-current.StartMS >= targetMS {
+			current = nil
+		}
+	}
+	return segments
+}
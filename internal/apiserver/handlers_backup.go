@@ -0,0 +1,41 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/backup"
+)
+
+// BackupHandler exports the full metadata archive as JSON. Pass
+// ?copy_objects=true to also copy every referenced object's bytes under a
+// timestamped backup/ prefix in the bucket.
+func (s *Server) BackupHandler(c *gin.Context) {
+	svc := backup.NewService(s.store, s.objects)
+	archive, err := svc.Export(c.Request.Context(), c.Query("copy_objects") == "true")
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.Header("Content-Disposition", "attachment; filename=aitestplatform-backup.json")
+	c.JSON(http.StatusOK, archive)
+}
+
+// RestoreHandler loads a previously exported archive into the database.
+// Intended for an empty target; restored rows get fresh IDs with references
+// remapped.
+func (s *Server) RestoreHandler(c *gin.Context) {
+	var archive backup.Archive
+	if err := c.ShouldBindJSON(&archive); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	svc := backup.NewService(s.store, s.objects)
+	report, err := svc.Restore(c.Request.Context(), &archive)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error(), "partial": report})
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,114 @@
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// UpsertBudgetHandler creates or updates a monthly budget for the project,
+// optionally scoped to one vendor config.
+func (s *Server) UpsertBudgetHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		VendorConfigID  *int64  `json:"vendor_config_id"`
+		MonthlyLimitUSD float64 `json:"monthly_limit_usd"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.MonthlyLimitUSD <= 0 {
+		errorJSON(c, http.StatusBadRequest, "monthly_limit_usd must be positive")
+		return
+	}
+	b := &models.Budget{
+		ProjectID:       projectID,
+		VendorConfigID:  req.VendorConfigID,
+		MonthlyLimitUSD: req.MonthlyLimitUSD,
+	}
+	if err := s.store.UpsertBudget(c.Request.Context(), b); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, b)
+}
+
+// ListBudgetsHandler lists a project's budgets together with the current
+// month's spend against each.
+func (s *Server) ListBudgetsHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	budgets, err := s.store.ListBudgets(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	month := time.Now().UTC().Format("2006-01")
+	type budgetStatus struct {
+		*models.Budget
+		Month    string  `json:"month"`
+		SpentUSD float64 `json:"spent_usd"`
+	}
+	out := make([]budgetStatus, 0, len(budgets))
+	for _, b := range budgets {
+		spent, err := s.store.MonthSpend(c.Request.Context(), projectID, b.VendorConfigID, month)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		out = append(out, budgetStatus{Budget: b, Month: month, SpentUSD: spent})
+	}
+	c.JSON(http.StatusOK, gin.H{"budgets": out})
+}
+
+// DeleteBudgetHandler removes a budget.
+func (s *Server) DeleteBudgetHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteBudget(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": id})
+}
+
+// ProjectSpendHandler reports a project's total spend for a month
+// (defaulting to the current month).
+func (s *Server) ProjectSpendHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	month := c.Query("month")
+	if month == "" {
+		month = time.Now().UTC().Format("2006-01")
+	}
+	spent, err := s.store.MonthSpend(c.Request.Context(), projectID, nil, month)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	audioMS, err := s.store.SumProjectAudioMS(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	audioBytes, responseBytes, err := s.store.ProjectBandwidth(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"project_id": projectID, "month": month, "spent_usd": spent,
+		"total_audio_ms": audioMS, "audio_bytes_sent": audioBytes, "response_bytes_received": responseBytes})
+}
@@ -0,0 +1,120 @@
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// confidencePoint is the simulated outcome of one auto-accept threshold:
+// everything at or above the threshold is accepted, the rest is routed to
+// humans.
+type confidencePoint struct {
+	Threshold        float64 `json:"threshold"`
+	Accepted         int     `json:"accepted"`
+	AcceptedRate     float64 `json:"accepted_rate"`
+	AcceptedMeanWER  float64 `json:"accepted_mean_wer"`
+	AcceptedAccuracy float64 `json:"accepted_accuracy"`
+}
+
+// vendorConfidenceSim is one vendor's threshold sweep.
+type vendorConfidenceSim struct {
+	VendorConfigID int64             `json:"vendor_config_id"`
+	Scored         int               `json:"scored"`
+	Points         []confidencePoint `json:"points"`
+}
+
+// ConfidenceSimulationHandler sweeps auto-accept confidence thresholds over
+// a job's stored results: per vendor and threshold it reports the deflection
+// rate and the quality of what would be auto-accepted. A result counts as
+// correct when its WER is at or below accept_wer (default 0.1). Thresholds
+// default to 0.50–0.95 in 0.05 steps; ?thresholds=0.6,0.8 overrides them.
+func (s *Server) ConfidenceSimulationHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	acceptWER := queryFloat(c, "accept_wer", 0.1)
+	thresholds, err := parseThresholds(c.Query("thresholds"))
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	results, err := s.store.ListASRResultsByJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	byVendor := map[int64][]*models.ASRResult{}
+	for _, r := range results {
+		if r.ErrorCode != "" {
+			continue
+		}
+		byVendor[r.VendorConfigID] = append(byVendor[r.VendorConfigID], r)
+	}
+
+	vendorIDs := make([]int64, 0, len(byVendor))
+	for vendorID := range byVendor {
+		vendorIDs = append(vendorIDs, vendorID)
+	}
+	sort.Slice(vendorIDs, func(i, j int) bool { return vendorIDs[i] < vendorIDs[j] })
+
+	sims := make([]vendorConfidenceSim, 0, len(vendorIDs))
+	for _, vendorID := range vendorIDs {
+		rows := byVendor[vendorID]
+		sim := vendorConfidenceSim{VendorConfigID: vendorID, Scored: len(rows)}
+		for _, threshold := range thresholds {
+			point := confidencePoint{Threshold: threshold}
+			correct := 0
+			var werSum float64
+			for _, r := range rows {
+				if r.Confidence < threshold {
+					continue
+				}
+				point.Accepted++
+				werSum += r.WER
+				if r.WER <= acceptWER {
+					correct++
+				}
+			}
+			point.AcceptedRate = float64(point.Accepted) / float64(len(rows))
+			if point.Accepted > 0 {
+				point.AcceptedMeanWER = werSum / float64(point.Accepted)
+				point.AcceptedAccuracy = float64(correct) / float64(point.Accepted)
+			}
+			sim.Points = append(sim.Points, point)
+		}
+		sims = append(sims, sim)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job_id": id, "accept_wer": acceptWER, "vendors": sims})
+}
+
+// parseThresholds parses the comma-separated thresholds override; an empty
+// value yields the default sweep.
+func parseThresholds(raw string) ([]float64, error) {
+	if raw == "" {
+		var thresholds []float64
+		for t := 0.50; t < 0.951; t += 0.05 {
+			thresholds = append(thresholds, t)
+		}
+		return thresholds, nil
+	}
+	var thresholds []float64
+	for _, part := range strings.Split(raw, ",") {
+		t, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || t < 0 || t > 1 {
+			return nil, fmt.Errorf("invalid threshold %q", part)
+		}
+		thresholds = append(thresholds, t)
+	}
+	sort.Float64s(thresholds)
+	return thresholds, nil
+}
@@ -0,0 +1,66 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// FreezeDatasetSnapshotHandler freezes the project's current test cases (or
+// an explicit subset) into an immutable named version that jobs can target.
+func (s *Server) FreezeDatasetSnapshotHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		Name        string  `json:"name" binding:"required"`
+		TestCaseIDs []int64 `json:"test_case_ids"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), projectID); err != nil {
+		storeError(c, err)
+		return
+	}
+	snap := &models.DatasetSnapshot{ProjectID: projectID, Name: req.Name}
+	if err := s.store.FreezeDatasetSnapshot(c.Request.Context(), snap, req.TestCaseIDs); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	c.JSON(http.StatusCreated, snap)
+}
+
+// ListDatasetSnapshotsHandler lists a project's dataset snapshots, newest
+// first.
+func (s *Server) ListDatasetSnapshotsHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	snaps, err := s.store.ListDatasetSnapshots(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"snapshots": snaps})
+}
+
+// GetDatasetSnapshotHandler fetches one dataset snapshot with its frozen
+// case list.
+func (s *Server) GetDatasetSnapshotHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	snap, err := s.store.GetDatasetSnapshot(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, snap)
+}
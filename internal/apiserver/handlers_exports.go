@@ -0,0 +1,169 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/export"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/objectstore"
+)
+
+// exportURLExpiry bounds the presigned download link returned for a
+// completed export.
+const exportURLExpiry = time.Hour
+
+// CreateJobExportHandler starts an asynchronous export of a job's results.
+// Currently only format=parquet is supported. The response is the pending
+// export task; poll GET /admin/exports/:id for the download link.
+func (s *Server) CreateJobExportHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "parquet")
+	if format != "parquet" {
+		errorJSON(c, http.StatusBadRequest, "unsupported export format: "+format)
+		return
+	}
+	if _, err := s.store.GetEvaluationJob(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	task := &models.Export{JobID: id, Format: format}
+	if err := s.store.CreateExport(c.Request.Context(), task); err != nil {
+		storeError(c, err)
+		return
+	}
+	go s.runParquetExport(task)
+	c.JSON(http.StatusAccepted, task)
+}
+
+// runParquetExport streams the job's results into object storage through a
+// pipe, so million-row exports never buffer the whole file. It runs outside
+// the request context: the export outlives the HTTP call that started it.
+func (s *Server) runParquetExport(task *models.Export) {
+	ctx := context.Background()
+	key := objectstore.NewObjectKey("exports", "parquet")
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- s.objects.Put(ctx, key, pr, -1, "application/vnd.apache.parquet")
+	}()
+
+	writeErr := export.WriteParquet(pw, func(afterID int64, limit int) ([]*models.ASRResult, error) {
+		return s.store.ListASRResultsByJobPage(ctx, task.JobID, afterID, limit)
+	})
+	pw.CloseWithError(writeErr)
+	putErr := <-done
+
+	err := writeErr
+	if err == nil {
+		err = putErr
+	}
+	if err != nil {
+		log.Printf("export %d: %v", task.ID, err)
+		if dbErr := s.store.MarkExportFailed(ctx, task.ID, err.Error()); dbErr != nil {
+			log.Printf("export %d: mark failed: %v", task.ID, dbErr)
+		}
+		return
+	}
+	if err := s.store.MarkExportCompleted(ctx, task.ID, key); err != nil {
+		log.Printf("export %d: mark completed: %v", task.ID, err)
+	}
+}
+
+// CreateDatasetExportHandler starts an asynchronous export of a project's
+// dataset as a Common Voice-style archive: audio clips plus a metadata TSV.
+// The response is the pending export task; poll GET /admin/exports/:id for
+// the download link.
+func (s *Server) CreateDatasetExportHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "commonvoice")
+	if format != "commonvoice" {
+		errorJSON(c, http.StatusBadRequest, "unsupported export format: "+format)
+		return
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), projectID); err != nil {
+		storeError(c, err)
+		return
+	}
+	task := &models.Export{ProjectID: projectID, Format: format}
+	if err := s.store.CreateExport(c.Request.Context(), task); err != nil {
+		storeError(c, err)
+		return
+	}
+	go s.runCommonVoiceExport(task)
+	c.JSON(http.StatusAccepted, task)
+}
+
+// runCommonVoiceExport streams the archive into object storage through a
+// pipe, fetching audio one clip at a time, mirroring runParquetExport.
+func (s *Server) runCommonVoiceExport(task *models.Export) {
+	ctx := context.Background()
+	key := objectstore.NewObjectKey("exports", "zip")
+
+	cases, err := s.store.ListASRTestCases(ctx, task.ProjectID, "")
+	if err == nil && len(cases) == 0 {
+		err = fmt.Errorf("project %d has no test cases", task.ProjectID)
+	}
+	if err == nil {
+		pr, pw := io.Pipe()
+		done := make(chan error, 1)
+		go func() {
+			done <- s.objects.Put(ctx, key, pr, -1, "application/zip")
+		}()
+		writeErr := export.WriteCommonVoice(pw, cases, func(tc *models.ASRTestCase) ([]byte, error) {
+			return s.objects.Get(ctx, tc.AudioObjectKey)
+		})
+		pw.CloseWithError(writeErr)
+		putErr := <-done
+		err = writeErr
+		if err == nil {
+			err = putErr
+		}
+	}
+	if err != nil {
+		log.Printf("export %d: %v", task.ID, err)
+		if dbErr := s.store.MarkExportFailed(ctx, task.ID, err.Error()); dbErr != nil {
+			log.Printf("export %d: mark failed: %v", task.ID, dbErr)
+		}
+		return
+	}
+	if err := s.store.MarkExportCompleted(ctx, task.ID, key); err != nil {
+		log.Printf("export %d: mark completed: %v", task.ID, err)
+	}
+}
+
+// GetExportHandler returns an export task, attaching a presigned download
+// URL once the file is in object storage.
+func (s *Server) GetExportHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	task, err := s.store.GetExport(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	downloadURL := ""
+	if task.Status == models.ExportStatusCompleted {
+		downloadURL, err = s.objects.PresignedGetURL(c.Request.Context(), task.ObjectKey, exportURLExpiry)
+		if err != nil {
+			errorJSON(c, http.StatusBadGateway, err.Error())
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"export": task, "download_url": downloadURL})
+}
@@ -0,0 +1,288 @@
+package apiserver
+
+import (
+	"math"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// fairnessMinGroupSize is the smallest per-group sample a significance test
+// is attempted on; tinier groups are still listed but never flagged.
+const fairnessMinGroupSize = 2
+
+// fairnessGroup is one speaker-attribute value's aggregate for a vendor.
+type fairnessGroup struct {
+	Value   string  `json:"value"`
+	Count   int     `json:"count"`
+	MeanWER float64 `json:"mean_wer"`
+}
+
+// fairnessFinding compares a vendor's best and worst speaker groups under
+// one attribute. Flagged findings combine a gap above the threshold with a
+// significant Welch's t-test, so small noisy groups do not raise alarms.
+type fairnessFinding struct {
+	VendorConfigID int64           `json:"vendor_config_id"`
+	Attribute      string          `json:"attribute"`
+	Groups         []fairnessGroup `json:"groups"`
+	BestGroup      string          `json:"best_group"`
+	WorstGroup     string          `json:"worst_group"`
+	// Gap is the worst group's mean WER minus the best group's.
+	Gap        float64 `json:"gap"`
+	TStatistic float64 `json:"t_statistic"`
+	PValue     float64 `json:"p_value"`
+	Flagged    bool    `json:"flagged"`
+}
+
+// FairnessReportHandler compares per-speaker-group accuracy across a job's
+// vendors. For every vendor and annotated speaker attribute it reports each
+// group's mean WER, the gap between the best and worst group, and a Welch's
+// t-test over those two groups' per-result WERs. A vendor is flagged when
+// the gap exceeds ?gap_threshold= (default 0.05) with p below ?alpha=
+// (default 0.05). Cases without speaker annotations are ignored.
+func (s *Server) FairnessReportHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	gapThreshold := queryFloat(c, "gap_threshold", 0.05)
+	alpha := queryFloat(c, "alpha", 0.05)
+
+	job, err := s.store.GetEvaluationJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	cases, err := s.store.GetASRTestCases(c.Request.Context(), job.TestCaseIDs)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	speakers := map[int64]*models.SpeakerInfo{}
+	for _, tc := range cases {
+		if tc.Speaker != nil {
+			speakers[tc.ID] = tc.Speaker
+		}
+	}
+	results, err := s.store.ListASRResultsByJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	// samples[attribute][vendor][group value] = per-result WERs.
+	samples := map[string]map[int64]map[string][]float64{}
+	for _, attr := range speakerBreakdownAttrs {
+		samples[attr] = map[int64]map[string][]float64{}
+	}
+	for _, r := range results {
+		if r.ErrorCode != "" {
+			continue
+		}
+		info := speakers[r.ASRTestCaseID]
+		if info == nil {
+			continue
+		}
+		for attr, value := range map[string]string{
+			"gender":    info.Gender,
+			"age_range": info.AgeRange,
+			"accent":    info.Accent,
+			"device":    info.Device,
+		} {
+			if value == "" {
+				continue
+			}
+			byVendor := samples[attr]
+			if byVendor[r.VendorConfigID] == nil {
+				byVendor[r.VendorConfigID] = map[string][]float64{}
+			}
+			byVendor[r.VendorConfigID][value] = append(byVendor[r.VendorConfigID][value], r.WER)
+		}
+	}
+
+	var findings []*fairnessFinding
+	for _, attr := range speakerBreakdownAttrs {
+		for vendorID, byGroup := range samples[attr] {
+			if len(byGroup) < 2 {
+				continue
+			}
+			finding := &fairnessFinding{VendorConfigID: vendorID, Attribute: attr}
+			for value, wers := range byGroup {
+				finding.Groups = append(finding.Groups, fairnessGroup{
+					Value:   value,
+					Count:   len(wers),
+					MeanWER: mean(wers),
+				})
+			}
+			sort.Slice(finding.Groups, func(i, j int) bool {
+				return finding.Groups[i].Value < finding.Groups[j].Value
+			})
+			best, worst := finding.Groups[0], finding.Groups[0]
+			for _, g := range finding.Groups[1:] {
+				if g.MeanWER < best.MeanWER {
+					best = g
+				}
+				if g.MeanWER > worst.MeanWER {
+					worst = g
+				}
+			}
+			finding.BestGroup = best.Value
+			finding.WorstGroup = worst.Value
+			finding.Gap = worst.MeanWER - best.MeanWER
+			if best.Count >= fairnessMinGroupSize && worst.Count >= fairnessMinGroupSize && best.Value != worst.Value {
+				finding.TStatistic, finding.PValue = welchTTest(byGroup[worst.Value], byGroup[best.Value])
+				finding.Flagged = finding.Gap > gapThreshold && finding.PValue < alpha
+			} else {
+				finding.PValue = 1
+			}
+			findings = append(findings, finding)
+		}
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].VendorConfigID != findings[j].VendorConfigID {
+			return findings[i].VendorConfigID < findings[j].VendorConfigID
+		}
+		return findings[i].Attribute < findings[j].Attribute
+	})
+
+	flagged := 0
+	for _, f := range findings {
+		if f.Flagged {
+			flagged++
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"job_id":        id,
+		"gap_threshold": gapThreshold,
+		"alpha":         alpha,
+		"findings":      findings,
+		"flagged_count": flagged,
+	})
+}
+
+func mean(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// sampleVariance is the unbiased (n-1) variance.
+func sampleVariance(xs []float64, m float64) float64 {
+	if len(xs) < 2 {
+		return 0
+	}
+	var sum float64
+	for _, x := range xs {
+		d := x - m
+		sum += d * d
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// welchTTest runs Welch's unequal-variance two-sample t-test and returns the
+// t statistic and the two-sided p-value. Degenerate inputs (zero variance in
+// both samples) yield p=1 so they never flag.
+func welchTTest(a, b []float64) (t, p float64) {
+	ma, mb := mean(a), mean(b)
+	va, vb := sampleVariance(a, ma), sampleVariance(b, mb)
+	na, nb := float64(len(a)), float64(len(b))
+	se2 := va/na + vb/nb
+	if se2 == 0 {
+		return 0, 1
+	}
+	t = (ma - mb) / math.Sqrt(se2)
+	// Welch–Satterthwaite degrees of freedom.
+	df := se2 * se2 / (va*va/(na*na*(na-1)) + vb*vb/(nb*nb*(nb-1)))
+	if df <= 0 || math.IsNaN(df) {
+		return t, 1
+	}
+	return t, studentTPValue(t, df)
+}
+
+// studentTPValue is the two-sided p-value of a t statistic under Student's t
+// distribution: the regularized incomplete beta I_x(df/2, 1/2) at
+// x = df/(df+t²).
+func studentTPValue(t, df float64) float64 {
+	x := df / (df + t*t)
+	return regularizedIncompleteBeta(df/2, 0.5, x)
+}
+
+// regularizedIncompleteBeta computes I_x(a,b) with the standard continued
+// fraction expansion (Lentz's method), accurate enough for p-values.
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	lnFront := a*math.Log(x) + b*math.Log(1-x) -
+		math.Log(a) - logBeta(a, b)
+	if x < (a+1)/(a+b+2) {
+		return math.Exp(lnFront) * betaContinuedFraction(a, b, x)
+	}
+	// Use the symmetry relation when the fraction converges poorly.
+	return 1 - regularizedIncompleteBeta(b, a, 1-x)
+}
+
+func logBeta(a, b float64) float64 {
+	lgA, _ := math.Lgamma(a)
+	lgB, _ := math.Lgamma(b)
+	lgAB, _ := math.Lgamma(a + b)
+	return lgA + lgB - lgAB
+}
+
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 3e-14
+		tiny          = 1e-30
+	)
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	f := d
+	for m := 1; m <= maxIterations; m++ {
+		mf := float64(m)
+		// Even step.
+		numerator := mf * (b - mf) * x / ((a + 2*mf - 1) * (a + 2*mf))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		f *= d * c
+		// Odd step.
+		numerator = -(a + mf) * (a + b + mf) * x / ((a + 2*mf) * (a + 2*mf + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		f *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return f
+}
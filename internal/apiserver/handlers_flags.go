@@ -0,0 +1,103 @@
+package apiserver
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// featureFlagTTL bounds how long a flag decision is cached; toggles take
+// effect within this window on every replica.
+const featureFlagTTL = 5 * time.Second
+
+// featureEnabled resolves a flag through the query cache so hot paths
+// (maintenance middleware, job submission) don't hit the database per
+// request. Lookup errors fall back to the default, never block traffic.
+func (s *Server) featureEnabled(c *gin.Context, name string, projectID int64, def bool) bool {
+	key := "feature-flag:" + name
+	if projectID != 0 {
+		key += ":" + strconv.FormatInt(projectID, 10)
+	}
+	if cached, ok := s.cache.Get(key); ok {
+		if enabled, ok := cached.(bool); ok {
+			return enabled
+		}
+	}
+	enabled, err := s.store.FeatureEnabled(c.Request.Context(), name, projectID, def)
+	if err != nil {
+		return def
+	}
+	s.cache.Set(key, enabled, featureFlagTTL)
+	return enabled
+}
+
+// MaintenanceGuard refuses mutating requests while the maintenance_mode flag
+// is on, leaving the API read-only. Flag management stays writable so
+// maintenance mode can be turned off again.
+func (s *Server) MaintenanceGuard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+		if strings.HasPrefix(c.FullPath(), "/admin/feature-flags") {
+			c.Next()
+			return
+		}
+		if s.featureEnabled(c, models.FlagMaintenanceMode, 0, false) {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable,
+				gin.H{"error": "platform is in maintenance mode"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// UpsertFeatureFlagHandler sets a flag. project_id 0 (or omitted) makes the
+// flag global.
+func (s *Server) UpsertFeatureFlagHandler(c *gin.Context) {
+	var req struct {
+		Name      string `json:"name" binding:"required"`
+		ProjectID int64  `json:"project_id"`
+		Enabled   *bool  `json:"enabled" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	flag := &models.FeatureFlag{Name: req.Name, ProjectID: req.ProjectID, Enabled: *req.Enabled}
+	if err := s.store.UpsertFeatureFlag(c.Request.Context(), flag); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, flag)
+}
+
+// ListFeatureFlagsHandler lists every flag.
+func (s *Server) ListFeatureFlagsHandler(c *gin.Context) {
+	flags, err := s.store.ListFeatureFlags(c.Request.Context())
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"feature_flags": flags})
+}
+
+// DeleteFeatureFlagHandler removes a flag, restoring the default behavior.
+func (s *Server) DeleteFeatureFlagHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteFeatureFlag(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
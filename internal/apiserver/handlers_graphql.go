@@ -0,0 +1,174 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/graphql"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// GraphQLHandler serves the read-only analytics query endpoint. Field names
+// mirror the JSON wire format of the REST API, and the results field can
+// join its test case and vendor config in the same round trip:
+//
+//	{ results(job_id: 42) { wer latency_ms test_case { name language } } }
+//
+// Supported roots: job, jobs, results, test_case, test_cases,
+// vendor_config, vendor_configs, summary.
+func (s *Server) GraphQLHandler(c *gin.Context) {
+	var req struct {
+		Query string `json:"query" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	selections, err := graphql.Parse(req.Query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"errors": []string{err.Error()}})
+		return
+	}
+	data := map[string]any{}
+	for _, sel := range selections {
+		value, err := s.resolveRoot(c.Request.Context(), sel)
+		if err != nil {
+			c.JSON(http.StatusOK, gin.H{"data": data, "errors": []string{
+				fmt.Sprintf("%s: %v", sel.Name, err)}})
+			return
+		}
+		data[sel.Name] = projectValue(value, sel.Selections)
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+// resolveRoot executes one top-level field against the datastore.
+func (s *Server) resolveRoot(ctx context.Context, sel graphql.Selection) (any, error) {
+	switch sel.Name {
+	case "job":
+		return s.store.GetEvaluationJob(ctx, graphql.IntArg(sel.Args, "id"))
+	case "jobs":
+		return s.store.ListEvaluationJobs(ctx, graphql.IntArg(sel.Args, "project_id"))
+	case "test_case":
+		return s.store.GetASRTestCase(ctx, graphql.IntArg(sel.Args, "id"))
+	case "test_cases":
+		return s.store.ListASRTestCases(ctx, graphql.IntArg(sel.Args, "project_id"),
+			graphql.StringArg(sel.Args, "language"))
+	case "vendor_config":
+		return s.store.GetVendorConfig(ctx, graphql.IntArg(sel.Args, "id"))
+	case "vendor_configs":
+		return s.store.ListVendorConfigs(ctx, graphql.IntArg(sel.Args, "project_id"))
+	case "summary":
+		return s.store.SummarizeJob(ctx, graphql.IntArg(sel.Args, "job_id"), datastore.DefaultWERCap)
+	case "results":
+		return s.resolveResults(ctx, sel)
+	default:
+		return nil, fmt.Errorf("unknown field")
+	}
+}
+
+// resultNode is a result row with its joined neighbours embedded when the
+// query selects them.
+type resultNode struct {
+	*models.ASRResult
+	TestCase     *models.ASRTestCase  `json:"test_case,omitempty"`
+	VendorConfig *models.VendorConfig `json:"vendor_config,omitempty"`
+}
+
+// resolveResults returns a job's results, joining test cases and vendor
+// configs only when the selection asks for them.
+func (s *Server) resolveResults(ctx context.Context, sel graphql.Selection) (any, error) {
+	jobID := graphql.IntArg(sel.Args, "job_id")
+	results, err := s.store.ListASRResultsByJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	wantCases := hasSelection(sel.Selections, "test_case")
+	wantConfigs := hasSelection(sel.Selections, "vendor_config")
+	testCases := map[int64]*models.ASRTestCase{}
+	vendorConfigs := map[int64]*models.VendorConfig{}
+	nodes := make([]resultNode, len(results))
+	for i, r := range results {
+		node := resultNode{ASRResult: r}
+		if wantCases {
+			tc, ok := testCases[r.ASRTestCaseID]
+			if !ok {
+				if tc, err = s.store.GetASRTestCase(ctx, r.ASRTestCaseID); err != nil {
+					return nil, err
+				}
+				testCases[r.ASRTestCaseID] = tc
+			}
+			node.TestCase = tc
+		}
+		if wantConfigs {
+			vc, ok := vendorConfigs[r.VendorConfigID]
+			if !ok {
+				if vc, err = s.store.GetVendorConfig(ctx, r.VendorConfigID); err != nil {
+					return nil, err
+				}
+				vendorConfigs[r.VendorConfigID] = vc
+			}
+			node.VendorConfig = vc
+		}
+		nodes[i] = node
+	}
+	return nodes, nil
+}
+
+func hasSelection(sels []graphql.Selection, name string) bool {
+	for _, sel := range sels {
+		if sel.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// projectValue applies a selection set to a resolved value by walking its
+// JSON shape, so GraphQL field names line up with the REST wire format.
+func projectValue(value any, sels []graphql.Selection) any {
+	if len(sels) == 0 || value == nil {
+		return value
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return value
+	}
+	return projectGeneric(generic, sels)
+}
+
+func projectGeneric(value any, sels []graphql.Selection) any {
+	switch v := value.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = projectGeneric(item, sels)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(sels))
+		for _, sel := range sels {
+			field, ok := v[sel.Name]
+			if !ok {
+				out[sel.Name] = nil
+				continue
+			}
+			if len(sel.Selections) > 0 {
+				field = projectGeneric(field, sel.Selections)
+			}
+			out[sel.Name] = field
+		}
+		return out
+	default:
+		return value
+	}
+}
@@ -0,0 +1,145 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/coreengine"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// PromoteHypothesisHandler promotes a vendor hypothesis — optionally edited
+// by the reviewer — to the test case's ground truth. The previous text is
+// recorded as a GroundTruthRevision with attribution, and every existing
+// result for the case is re-scored against the new reference so its metrics
+// stay truthful.
+func (s *Server) PromoteHypothesisHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		// Text is the reviewer's edited transcript; empty means promote
+		// the hypothesis verbatim.
+		Text   string `json:"text"`
+		Editor string `json:"editor" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := s.store.GetASRResult(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	tc, err := s.store.GetASRTestCase(c.Request.Context(), result.ASRTestCaseID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	newText := req.Text
+	if newText == "" {
+		newText = result.RecognizedText
+	}
+	if newText == "" {
+		errorJSON(c, http.StatusBadRequest, "result has no hypothesis and no text was supplied")
+		return
+	}
+	if newText == tc.GroundTruth {
+		errorJSON(c, http.StatusBadRequest, "text is identical to the current ground truth")
+		return
+	}
+
+	rev := &models.GroundTruthRevision{
+		ASRTestCaseID:  tc.ID,
+		PreviousText:   tc.GroundTruth,
+		NewText:        newText,
+		SourceResultID: &result.ID,
+		Editor:         req.Editor,
+	}
+	if err := s.store.CreateGroundTruthRevision(c.Request.Context(), rev); err != nil {
+		storeError(c, err)
+		return
+	}
+	if err := s.store.SetASRTestCaseGroundTruth(c.Request.Context(), tc.ID, newText); err != nil {
+		storeError(c, err)
+		return
+	}
+	tc.GroundTruth = newText
+
+	rescored, err := s.rescoreTestCase(c, tc)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"test_case": tc, "revision": rev, "rescored_results": rescored})
+}
+
+// rescoreTestCase re-runs the scoring pipeline over every non-errored result
+// of the test case against its current ground truth, reusing each result's
+// original job parameters and vendor config so options like ITN apply
+// exactly as they did at execution time.
+func (s *Server) rescoreTestCase(c *gin.Context, tc *models.ASRTestCase) (int, error) {
+	ctx := c.Request.Context()
+	results, err := s.store.ListASRResultsByTestCase(ctx, tc.ID)
+	if err != nil {
+		return 0, err
+	}
+	paramsByJob := map[int64]map[string]any{}
+	configs := map[int64]*models.VendorConfig{}
+	rescored := 0
+	for _, r := range results {
+		if r.ErrorCode != "" {
+			continue
+		}
+		params, ok := paramsByJob[r.JobID]
+		if !ok {
+			params = map[string]any{}
+			if job, err := s.store.GetEvaluationJob(ctx, r.JobID); err == nil && len(job.Parameters) > 0 {
+				_ = json.Unmarshal(job.Parameters, &params)
+			}
+			paramsByJob[r.JobID] = params
+		}
+		vc, ok := configs[r.VendorConfigID]
+		if !ok {
+			vc, _ = s.store.GetVendorConfig(ctx, r.VendorConfigID)
+			configs[r.VendorConfigID] = vc
+		}
+		// Clear the derived fields so nothing from the previous reference
+		// survives the recompute.
+		r.PostProcessedText = ""
+		r.SegmentScores = nil
+		r.ExtraMetrics = nil
+		coreengine.ScoreTranscript(r, tc, vc, params, r.RecognizedText)
+		if err := s.store.UpdateASRResultScores(ctx, r); err != nil {
+			return rescored, err
+		}
+		rescored++
+		s.cache.Invalidate(cache.JobSummaryKey(r.JobID))
+	}
+	return rescored, nil
+}
+
+// ListGroundTruthRevisionsHandler returns a test case's ground-truth edit
+// history, newest first.
+func (s *Server) ListGroundTruthRevisionsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if _, err := s.store.GetASRTestCase(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	revisions, err := s.store.ListGroundTruthRevisions(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
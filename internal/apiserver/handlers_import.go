@@ -0,0 +1,233 @@
+package apiserver
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/audioinfo"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// maxImportArchiveBytes caps the uploaded ZIP at 1 GiB; individual clips
+// inside it still face the normal per-audio upload limit.
+const maxImportArchiveBytes = 1 << 30
+
+// BulkImportTestCasesHandler imports test cases in bulk from a ZIP of audio
+// files plus a CSV manifest. The manifest needs a header row with at least
+// "file" and "ground_truth" columns; "name", "language", "tags" and "domain"
+// are optional, with tags separated by semicolons. Rows that fail validation
+// are reported individually, and the surviving rows are inserted in one
+// transaction so a database failure never leaves a half-imported corpus.
+func (s *Server) BulkImportTestCasesHandler(c *gin.Context) {
+	projectID := parseInt64(c.PostForm("project_id"))
+	if projectID <= 0 {
+		projectID = queryInt64(c, "project_id", 0)
+	}
+	defaultLanguage := c.PostForm("language")
+	sharedTags := splitTags(c.PostForm("tags"))
+	if projectID <= 0 {
+		errorJSON(c, http.StatusBadRequest, "project_id is required")
+		return
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), projectID); err != nil {
+		storeError(c, err)
+		return
+	}
+
+	archive, err := readFormFile(c, "archive", maxImportArchiveBytes)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	manifest, err := readFormFile(c, "manifest", maxImportArchiveBytes)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, fmt.Sprintf("read archive: %v", err))
+		return
+	}
+	// Entries are addressable both by their full path and by base name, so
+	// manifests don't have to mirror the archive's directory layout.
+	entries := map[string]*zip.File{}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		entries[f.Name] = f
+		entries[path.Base(f.Name)] = f
+	}
+
+	rows, header, err := parseImportManifest(manifest)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var cases []*models.ASRTestCase
+	var importErrors []string
+	rowError := func(line int, name, format string, args ...any) {
+		importErrors = append(importErrors, fmt.Sprintf("row %d (%s): %s", line, name, fmt.Sprintf(format, args...)))
+	}
+	for _, row := range rows {
+		file := row.get(header, "file")
+		name := row.get(header, "name")
+		if name == "" {
+			name = strings.TrimSuffix(path.Base(file), path.Ext(file))
+		}
+		language := row.get(header, "language")
+		if language == "" {
+			language = defaultLanguage
+		}
+		groundTruth := row.get(header, "ground_truth")
+		domain := row.get(header, "domain")
+		switch {
+		case file == "":
+			rowError(row.line, name, "file column is empty")
+			continue
+		case groundTruth == "":
+			rowError(row.line, name, "ground_truth column is empty")
+			continue
+		case language == "":
+			rowError(row.line, name, "no language column and no default language on the request")
+			continue
+		case !models.ValidDomain(domain):
+			rowError(row.line, name, "domain must be one of %s", strings.Join(models.Domains, ", "))
+			continue
+		}
+		entry, ok := entries[file]
+		if !ok {
+			rowError(row.line, name, "audio %q not found in the archive", file)
+			continue
+		}
+		if entry.UncompressedSize64 > maxAudioUploadBytes {
+			rowError(row.line, name, "audio exceeds the %d byte upload limit", maxAudioUploadBytes)
+			continue
+		}
+		rc, err := entry.Open()
+		if err != nil {
+			rowError(row.line, name, "read audio: %v", err)
+			continue
+		}
+		audio, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			rowError(row.line, name, "read audio: %v", err)
+			continue
+		}
+		ext := strings.ToLower(strings.TrimPrefix(path.Ext(entry.Name), "."))
+		if ext == "" {
+			ext = "wav"
+		}
+		// Audio lands in the content store ahead of the transaction; the
+		// content-addressed keys make retried imports converge on the same
+		// objects instead of piling up orphans.
+		key, err := s.content.PutContent(c.Request.Context(), "asr-audio", audio, ext, "application/octet-stream")
+		if err != nil {
+			rowError(row.line, name, "store audio: %v", err)
+			continue
+		}
+		caseTags := append([]string{}, sharedTags...)
+		if raw := row.get(header, "tags"); raw != "" {
+			// Per-row tags use semicolons so they survive the CSV commas.
+			caseTags = append(caseTags, splitTags(strings.ReplaceAll(raw, ";", ","))...)
+		}
+		cases = append(cases, &models.ASRTestCase{
+			ProjectID:      projectID,
+			Name:           name,
+			Language:       language,
+			AudioObjectKey: key,
+			AudioFormat:    ext,
+			DurationMS:     audioinfo.DurationMS(ext, audio),
+			Domain:         domain,
+			GroundTruth:    groundTruth,
+			Tags:           caseTags,
+		})
+	}
+
+	if len(cases) > 0 {
+		if err := s.store.CreateASRTestCases(c.Request.Context(), cases); err != nil {
+			storeError(c, err)
+			return
+		}
+	}
+	status := http.StatusCreated
+	if len(cases) == 0 {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, gin.H{
+		"created": len(cases),
+		"cases":   cases,
+		"errors":  importErrors,
+	})
+}
+
+// importRow is one manifest line plus its 1-based line number for error
+// reporting.
+type importRow struct {
+	line   int
+	fields []string
+}
+
+func (r importRow) get(header map[string]int, column string) string {
+	idx, ok := header[column]
+	if !ok || idx >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[idx])
+}
+
+// parseImportManifest reads the manifest CSV into rows plus a header index.
+func parseImportManifest(data []byte) ([]importRow, map[string]int, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse manifest: %v", err)
+	}
+	if len(records) < 2 {
+		return nil, nil, fmt.Errorf("manifest needs a header row and at least one data row")
+	}
+	header := map[string]int{}
+	for i, col := range records[0] {
+		header[strings.ToLower(strings.TrimSpace(col))] = i
+	}
+	for _, required := range []string{"file", "ground_truth"} {
+		if _, ok := header[required]; !ok {
+			return nil, nil, fmt.Errorf("manifest is missing the %q column", required)
+		}
+	}
+	rows := make([]importRow, 0, len(records)-1)
+	for i, record := range records[1:] {
+		rows = append(rows, importRow{line: i + 2, fields: record})
+	}
+	return rows, header, nil
+}
+
+// readFormFile reads one uploaded form file fully, enforcing a size cap.
+func readFormFile(c *gin.Context, field string, limit int64) ([]byte, error) {
+	fileHeader, err := c.FormFile(field)
+	if err != nil {
+		return nil, fmt.Errorf("%s file is required", field)
+	}
+	if fileHeader.Size > limit {
+		return nil, fmt.Errorf("%s file exceeds the %d byte limit", field, limit)
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
@@ -0,0 +1,40 @@
+package apiserver
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// JobLogsHandler returns a job's execution log, oldest first. ?level=
+// filters to one level (info, warn, error); ?limit= caps the line count.
+func (s *Server) JobLogsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if _, err := s.store.GetEvaluationJob(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	level := strings.ToUpper(c.Query("level"))
+	switch level {
+	case "", models.LogLevelInfo, models.LogLevelWarn, models.LogLevelError:
+	default:
+		errorJSON(c, http.StatusBadRequest, "level must be one of info, warn, error")
+		return
+	}
+	limit := int(queryInt64(c, "limit", 1000))
+	if limit <= 0 || limit > 10000 {
+		limit = 1000
+	}
+	logs, err := s.store.ListJobLogs(c.Request.Context(), id, level, limit)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"job_id": id, "logs": logs})
+}
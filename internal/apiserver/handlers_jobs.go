@@ -0,0 +1,665 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/jobmanagement"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/synthdata"
+)
+
+// CreateJobHandler submits a new ASR evaluation job. When a budget would be
+// exceeded the response is 409 with the violations; resubmitting with
+// override_budget=true pushes past them.
+func (s *Server) CreateJobHandler(c *gin.Context) {
+	var req struct {
+		ProjectID         int64           `json:"project_id"`
+		Name              string          `json:"name" binding:"required"`
+		Language          string          `json:"language"`
+		TestCaseIDs       []int64         `json:"test_case_ids"`
+		TestSuiteID       int64           `json:"test_suite_id"`
+		Split             string          `json:"split"`
+		DatasetSnapshotID int64           `json:"dataset_snapshot_id"`
+		VendorConfigIDs   []int64         `json:"vendor_config_ids"`
+		DependsOnJobIDs   []int64         `json:"depends_on_job_ids"`
+		Parameters        json.RawMessage `json:"parameters"`
+		OverrideBudget    bool            `json:"override_budget"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.ProjectID <= 0 {
+		errorJSON(c, http.StatusBadRequest, "project_id is required")
+		return
+	}
+	if len(req.Parameters) > 0 && !json.Valid(req.Parameters) {
+		errorJSON(c, http.StatusBadRequest, "parameters must be valid JSON")
+		return
+	}
+	if !s.featureEnabled(c, models.FlagJobSubmission, req.ProjectID, true) {
+		errorJSON(c, http.StatusServiceUnavailable, "job submission is currently disabled")
+		return
+	}
+	// Suite-backed jobs materialize the suite's membership at submission
+	// time, so the job keeps its exact dataset even if the suite is edited
+	// or deleted later.
+	if req.TestSuiteID > 0 {
+		if len(req.TestCaseIDs) > 0 || req.Split != "" {
+			errorJSON(c, http.StatusBadRequest, "test_suite_id is mutually exclusive with test_case_ids and split")
+			return
+		}
+		suite, err := s.store.GetTestSuite(c.Request.Context(), req.TestSuiteID)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		if suite.ProjectID != req.ProjectID {
+			errorJSON(c, http.StatusBadRequest, "test suite belongs to another project")
+			return
+		}
+		if len(suite.TestCaseIDs) == 0 {
+			errorJSON(c, http.StatusBadRequest, fmt.Sprintf("test suite %q has no test cases", suite.Name))
+			return
+		}
+		req.TestCaseIDs = suite.TestCaseIDs
+	}
+	if req.Split != "" {
+		if len(req.TestCaseIDs) > 0 {
+			errorJSON(c, http.StatusBadRequest, "test_case_ids and split are mutually exclusive")
+			return
+		}
+		ids, err := s.store.ListTestCaseIDsBySplit(c.Request.Context(), req.ProjectID, req.Split)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		if len(ids) == 0 {
+			errorJSON(c, http.StatusBadRequest, fmt.Sprintf("split %q has no test cases", req.Split))
+			return
+		}
+		req.TestCaseIDs = ids
+	}
+
+	job := &models.EvaluationJob{
+		ProjectID:       req.ProjectID,
+		Name:            req.Name,
+		Language:        req.Language,
+		TestCaseIDs:     req.TestCaseIDs,
+		VendorConfigIDs: req.VendorConfigIDs,
+		DependsOnJobIDs: req.DependsOnJobIDs,
+		Parameters:      req.Parameters,
+	}
+	if req.DatasetSnapshotID > 0 {
+		if len(req.TestCaseIDs) > 0 || req.Split != "" {
+			errorJSON(c, http.StatusBadRequest, "dataset_snapshot_id is mutually exclusive with test_case_ids and split")
+			return
+		}
+		snap, err := s.store.GetDatasetSnapshot(c.Request.Context(), req.DatasetSnapshotID)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		if snap.ProjectID != req.ProjectID {
+			errorJSON(c, http.StatusBadRequest, "dataset snapshot belongs to another project")
+			return
+		}
+		job.DatasetSnapshotID = &snap.ID
+		for _, sc := range snap.Cases {
+			job.TestCaseIDs = append(job.TestCaseIDs, sc.TestCaseID)
+		}
+	}
+	err := s.jobs.SubmitASRJob(c.Request.Context(), job, req.OverrideBudget)
+	if err != nil {
+		var budgetErr *jobmanagement.ErrBudgetExceeded
+		if errors.As(err, &budgetErr) {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":      budgetErr.Error(),
+				"violations": budgetErr.Violations,
+				"hint":       "resubmit with override_budget=true to run anyway",
+			})
+			return
+		}
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	// Echo the total audio volume so the submitter sees up front how many
+	// hours of audio the job entails.
+	audioMS, err := s.store.SumTestCaseAudioMS(c.Request.Context(), job.TestCaseIDs)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, struct {
+		*models.EvaluationJob
+		TotalAudioMS int64 `json:"total_audio_ms"`
+	}{job, audioMS})
+}
+
+// ListJobsHandler lists a project's jobs, newest first.
+func (s *Server) ListJobsHandler(c *gin.Context) {
+	projectID := queryInt64(c, "project_id", 0)
+	if projectID <= 0 {
+		errorJSON(c, http.StatusBadRequest, "project_id query parameter is required")
+		return
+	}
+	jobs, err := s.store.ListEvaluationJobs(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
+
+// GetJobHandler fetches one job.
+func (s *Server) GetJobHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	job, err := s.store.GetEvaluationJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	done, failed, err := s.store.JobProgress(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, struct {
+		*models.EvaluationJob
+		ProgressDone   int64 `json:"progress_done"`
+		ProgressFailed int64 `json:"progress_failed"`
+	}{job, done, failed})
+}
+
+// ListJobResultsHandler returns a job's result rows. The set can be narrowed
+// to the problematic subset with ?wer_gt=, ?latency_gt=, ?error_code= and
+// ?vendor_config_id=, and the response slimmed with ?include_raw=false and
+// ?fields=wer,latency_ms,... for UI listings that do not need the raw vendor
+// blobs.
+func (s *Server) ListJobResultsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	filter := parseResultFilter(c)
+	query := datastore.ResultQuery{
+		VendorConfigID: queryInt64(c, "vendor_config_id", 0),
+		ErrorCode:      c.Query("error_code"),
+		MinWER:         queryFloat(c, "wer_gt", 0),
+		MinLatencyMS:   queryInt64(c, "latency_gt", 0),
+	}
+	var results []*models.ASRResult
+	var err error
+	if query == (datastore.ResultQuery{}) {
+		results, err = s.store.ListASRResultsByJob(c.Request.Context(), id)
+	} else {
+		results, err = s.store.QueryASRResultsByJob(c.Request.Context(), id, query)
+	}
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"results": filter.applyAll(results)})
+}
+
+// GetResultHandler fetches one result row in full, raw vendor response
+// included. The UI uses it to drill into a row fetched from a filtered list.
+func (s *Server) GetResultHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	result, err := s.store.GetASRResult(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// resultStreamPageSize bounds how many result rows a streaming export holds
+// in memory at once.
+const resultStreamPageSize = 500
+
+// StreamJobResultsHandler exports a job's results as NDJSON, one result per
+// line. Rows are paged out of the database by id cursor and flushed after
+// each page, so arbitrarily large jobs stream in constant memory and a slow
+// client simply backpressures the database reads.
+func (s *Server) StreamJobResultsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	filter := parseResultFilter(c)
+	c.Header("Content-Type", "application/x-ndjson")
+	enc := json.NewEncoder(c.Writer)
+	afterID := int64(0)
+	for {
+		page, err := s.store.ListASRResultsByJobPage(ctx, id, afterID, resultStreamPageSize)
+		if err != nil {
+			// Once rows have been written the status line is gone; all we
+			// can do is stop the stream.
+			if afterID == 0 {
+				storeError(c, err)
+			}
+			return
+		}
+		for _, r := range page {
+			if err := enc.Encode(filter.apply(r)); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+		if len(page) < resultStreamPageSize {
+			return
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+// worstSegment is one scored segment with enough context to locate it: the
+// test case and vendor it came from plus the audio offsets.
+type worstSegment struct {
+	ASRTestCaseID  int64 `json:"asr_test_case_id"`
+	VendorConfigID int64 `json:"vendor_config_id"`
+	models.SegmentScore
+}
+
+// WorstSegmentsHandler surfaces a job's worst-scoring segments across all
+// segmented test cases and vendors, sorted by WER descending, so reviewers
+// can jump straight to the problem spans in the audio.
+func (s *Server) WorstSegmentsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	limit := int(queryInt64(c, "limit", 20))
+	results, err := s.store.ListASRResultsByJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	var segments []worstSegment
+	for _, r := range results {
+		for _, score := range r.SegmentScores {
+			segments = append(segments, worstSegment{
+				ASRTestCaseID:  r.ASRTestCaseID,
+				VendorConfigID: r.VendorConfigID,
+				SegmentScore:   score,
+			})
+		}
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].WER > segments[j].WER })
+	if limit > 0 && len(segments) > limit {
+		segments = segments[:limit]
+	}
+	c.JSON(http.StatusOK, gin.H{"job_id": id, "segments": segments})
+}
+
+// trendPoint is one data point of a test case's per-vendor metric history,
+// sized for a small chart: when it ran, in which job, and how it scored.
+type trendPoint struct {
+	JobID     int64     `json:"job_id"`
+	CreatedAt time.Time `json:"created_at"`
+	WER       float64   `json:"wer"`
+	CER       float64   `json:"cer"`
+	LatencyMS int64     `json:"latency_ms"`
+}
+
+// TestCaseResultsHandler returns a test case's full result history across
+// jobs and vendors, plus a per-vendor trend series of the successful runs,
+// so analysts can tell a clip that has always been hard from one that
+// recently regressed. Supports the shared result filtering parameters.
+func (s *Server) TestCaseResultsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	filter := parseResultFilter(c)
+	results, err := s.store.ListASRResultsByTestCase(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	trend := map[int64][]trendPoint{}
+	for _, r := range results {
+		if r.ErrorCode != "" {
+			continue
+		}
+		trend[r.VendorConfigID] = append(trend[r.VendorConfigID], trendPoint{
+			JobID:     r.JobID,
+			CreatedAt: r.CreatedAt,
+			WER:       r.WER,
+			CER:       r.CER,
+			LatencyMS: r.LatencyMS,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"asr_test_case_id": id,
+		"results":          filter.applyAll(results),
+		"trend":            trend,
+	})
+}
+
+// RecoverStuckJobsHandler requeues RUNNING jobs whose worker appears dead.
+// ?max_age_minutes= overrides the default staleness threshold.
+func (s *Server) RecoverStuckJobsHandler(c *gin.Context) {
+	maxAge := time.Duration(queryInt64(c, "max_age_minutes", 0)) * time.Minute
+	recovered, err := s.jobs.RecoverStuckJobs(c.Request.Context(), maxAge)
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"recovered_job_ids": recovered})
+}
+
+// jobSummaryTTL bounds staleness of cached summaries even if an
+// invalidation is missed (e.g. results written by another process).
+const jobSummaryTTL = 30 * time.Second
+
+// werOutlierListLimit caps how many outlier results a summary embeds; the
+// full set remains reachable through the results listing.
+const werOutlierListLimit = 20
+
+// jobSummary is the cached shape of a job summary: the overall per-vendor
+// aggregates plus the code-switched slice when the job touched such cases.
+// speakerBreakdownAttrs are the SpeakerInfo attributes summaries break
+// metrics down by, in the order they appear in models.SpeakerInfo.
+var speakerBreakdownAttrs = []string{"gender", "age_range", "accent", "device"}
+
+type jobSummary struct {
+	Vendors      []*datastore.VendorSummary `json:"vendors"`
+	CodeSwitched []*datastore.VendorSummary `json:"code_switched,omitempty"`
+	// SpeakerBreakdowns slices the metrics by each speaker attribute with
+	// annotated cases in the job, keyed by attribute name. Large gaps
+	// between values of one attribute flag demographic performance gaps.
+	SpeakerBreakdowns map[string][]*datastore.AttributeSlice `json:"speaker_breakdowns,omitempty"`
+	// Domains slices the metrics by the test cases' content domain, for
+	// jobs with domain-classified cases.
+	Domains []*datastore.AttributeSlice `json:"domains,omitempty"`
+	// LanguageMismatches counts results whose transcript script did not
+	// match the test case language — usually a wrong engine default.
+	LanguageMismatches int64 `json:"language_mismatches"`
+	// WERCap is the threshold used for the capped and trimmed WER means;
+	// results above it are listed in WEROutliers, worst first.
+	WERCap      float64                 `json:"wer_cap"`
+	WEROutliers []*datastore.WEROutlier `json:"wer_outliers,omitempty"`
+	// TotalAudioMS is the summed duration of the job's test case audio.
+	TotalAudioMS int64 `json:"total_audio_ms"`
+}
+
+// JobSummaryHandler returns per-vendor aggregates for a job, including a
+// separate breakdown over code-switched test cases when the job has any.
+// ?wer_cap= overrides the default outlier threshold of 1.0. Summaries are
+// cached and invalidated by the engine as new results arrive; only the
+// default-cap shape is cached, so a custom cap always computes fresh.
+func (s *Server) JobSummaryHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	werCap := queryFloat(c, "wer_cap", datastore.DefaultWERCap)
+	if werCap <= 0 {
+		werCap = datastore.DefaultWERCap
+	}
+	weights, err := parseDomainWeights(c.Query("domain_weights"))
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	respond := func(summary *jobSummary, cached bool) {
+		resp := gin.H{"job_id": id, "summary": summary}
+		if cached {
+			resp["cached"] = true
+		}
+		if len(weights) > 0 {
+			resp["domain_weighted"] = domainWeightedScores(summary.Domains, weights)
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+	key := cache.JobSummaryKey(id)
+	if werCap == datastore.DefaultWERCap {
+		if cached, ok := s.cache.Get(key); ok {
+			if summary, ok := cached.(*jobSummary); ok {
+				respond(summary, true)
+				return
+			}
+		}
+	}
+	summary, err := s.computeJobSummary(c.Request.Context(), id, werCap)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	if werCap == datastore.DefaultWERCap {
+		s.cache.Set(key, summary, jobSummaryTTL)
+	}
+	respond(summary, false)
+}
+
+// parseDomainWeights parses ?domain_weights=medical:0.6,finance:0.4 into a
+// domain-to-weight map. Weights must be positive; they need not sum to one,
+// as scoring normalizes over the domains a vendor actually has results in.
+func parseDomainWeights(raw string) (map[string]float64, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	weights := map[string]float64{}
+	for _, part := range strings.Split(raw, ",") {
+		domain, value, found := strings.Cut(strings.TrimSpace(part), ":")
+		if !found {
+			return nil, fmt.Errorf("domain weight %q must be domain:weight", part)
+		}
+		if !models.ValidDomain(domain) || domain == "" {
+			return nil, fmt.Errorf("unknown domain %q", domain)
+		}
+		w, err := strconv.ParseFloat(value, 64)
+		if err != nil || w <= 0 {
+			return nil, fmt.Errorf("invalid weight %q for domain %s", value, domain)
+		}
+		weights[domain] = w
+	}
+	return weights, nil
+}
+
+// domainWeightedScore is one vendor's composite WER under a production
+// traffic mix: each domain's capped mean WER weighted by its share.
+type domainWeightedScore struct {
+	VendorConfigID int64   `json:"vendor_config_id"`
+	Region         string  `json:"region,omitempty"`
+	WeightedWER    float64 `json:"weighted_wer"`
+	// CoveredWeight is the fraction of the requested weight mass the
+	// vendor has results for; below 1 means some weighted domains were
+	// absent from the job and the composite covers only the rest.
+	CoveredWeight float64 `json:"covered_weight"`
+}
+
+// domainWeightedScores folds the per-domain summaries into one composite WER
+// per vendor, normalized over the weighted domains each vendor has results
+// in, so reports reflect the declared traffic mix rather than how many cases
+// each domain happens to have in the dataset.
+func domainWeightedScores(domains []*datastore.AttributeSlice, weights map[string]float64) []*domainWeightedScore {
+	var totalWeight float64
+	for _, w := range weights {
+		totalWeight += w
+	}
+	type key struct {
+		vendor int64
+		region string
+	}
+	sums := map[key]*domainWeightedScore{}
+	var order []key
+	for _, slice := range domains {
+		w, ok := weights[slice.Value]
+		if !ok {
+			continue
+		}
+		for _, vs := range slice.Vendors {
+			k := key{vs.VendorConfigID, vs.Region}
+			score, ok := sums[k]
+			if !ok {
+				score = &domainWeightedScore{VendorConfigID: vs.VendorConfigID, Region: vs.Region}
+				sums[k] = score
+				order = append(order, k)
+			}
+			score.WeightedWER += w * vs.CappedMeanWER
+			score.CoveredWeight += w
+		}
+	}
+	out := make([]*domainWeightedScore, 0, len(order))
+	for _, k := range order {
+		score := sums[k]
+		if score.CoveredWeight > 0 {
+			score.WeightedWER /= score.CoveredWeight
+		}
+		if totalWeight > 0 {
+			score.CoveredWeight /= totalWeight
+		}
+		out = append(out, score)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].VendorConfigID != out[j].VendorConfigID {
+			return out[i].VendorConfigID < out[j].VendorConfigID
+		}
+		return out[i].Region < out[j].Region
+	})
+	return out
+}
+
+// computeJobSummary builds the live summary shape shared by the summary
+// endpoint and report snapshots.
+func (s *Server) computeJobSummary(ctx context.Context, jobID int64, werCap float64) (*jobSummary, error) {
+	vendors, err := s.store.SummarizeJob(ctx, jobID, werCap)
+	if err != nil {
+		return nil, err
+	}
+	codeSwitched, err := s.store.SummarizeJobTag(ctx, jobID, synthdata.CodeSwitchedTag, werCap)
+	if err != nil {
+		return nil, err
+	}
+	var speakerBreakdowns map[string][]*datastore.AttributeSlice
+	for _, attr := range speakerBreakdownAttrs {
+		slices, err := s.store.SummarizeJobSpeakerAttr(ctx, jobID, attr, werCap)
+		if err != nil {
+			return nil, err
+		}
+		if len(slices) == 0 {
+			continue
+		}
+		if speakerBreakdowns == nil {
+			speakerBreakdowns = map[string][]*datastore.AttributeSlice{}
+		}
+		speakerBreakdowns[attr] = slices
+	}
+	domains, err := s.store.SummarizeJobDomain(ctx, jobID, werCap)
+	if err != nil {
+		return nil, err
+	}
+	mismatches, err := s.store.CountLanguageMismatches(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	outliers, err := s.store.ListWEROutliers(ctx, jobID, werCap, werOutlierListLimit)
+	if err != nil {
+		return nil, err
+	}
+	audioMS, err := s.store.SumJobAudioMS(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return &jobSummary{Vendors: vendors, CodeSwitched: codeSwitched,
+		SpeakerBreakdowns: speakerBreakdowns, Domains: domains,
+		LanguageMismatches: mismatches, WERCap: werCap, WEROutliers: outliers,
+		TotalAudioMS: audioMS}, nil
+}
+
+// CreateJobSnapshotHandler freezes the job's current summary as an
+// immutable snapshot and returns it. Published reports cite the snapshot
+// ID; later reruns change the live summary but never the snapshot.
+func (s *Server) CreateJobSnapshotHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	summary, err := s.computeJobSummary(c.Request.Context(), id, datastore.DefaultWERCap)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	snap, err := s.store.CreateReportSnapshot(c.Request.Context(), id, summary)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, snap)
+}
+
+// ListJobSnapshotsHandler lists a job's snapshots, newest first.
+func (s *Server) ListJobSnapshotsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	snapshots, err := s.store.ListReportSnapshots(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"snapshots": snapshots})
+}
+
+// GetSnapshotHandler returns one frozen snapshot verbatim.
+func (s *Server) GetSnapshotHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	snap, err := s.store.GetReportSnapshot(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, snap)
+}
+
+// JobManifestHandler returns a job's reproducibility manifest: the dataset,
+// ground-truth fingerprints, vendor config revisions, effective parameters
+// and engine build the run actually used, as recorded when it started.
+func (s *Server) JobManifestHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if _, err := s.store.GetEvaluationJob(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	manifest, err := s.store.GetJobManifest(c.Request.Context(), id)
+	if errors.Is(err, datastore.ErrNotFound) {
+		errorJSON(c, http.StatusNotFound,
+			"job has no manifest; it has not started yet or predates manifest recording")
+		return
+	}
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=job-%d-manifest.json", id))
+	c.Data(http.StatusOK, "application/json", manifest)
+}
@@ -0,0 +1,134 @@
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/audioinfo"
+	"github.com/Jcateye/AITestPlatform/internal/kaldi"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// kaldiFetchClient fetches the audio referenced by wav.scp entries. The URLs
+// are user-supplied, so the fetches go through the shared vendor transport:
+// its dial-time private-address guard keeps a wav.scp entry from pointing
+// the server at the metadata service or another internal host, including via
+// redirects.
+func kaldiFetchClient() *http.Client {
+	return vendoradapter.SharedHTTPClient()
+}
+
+// ImportKaldiTestCasesHandler imports a Kaldi/ESPnet-style dataset: the
+// verbatim contents of wav.scp, text and optionally utt2spk, with the audio
+// fetched from the URLs the wav.scp references. Utterance IDs become case
+// names and speakers become speaker: tags. Entries that fail to fetch are
+// reported individually without aborting the rest of the import.
+func (s *Server) ImportKaldiTestCasesHandler(c *gin.Context) {
+	var req struct {
+		ProjectID int64    `json:"project_id"`
+		Language  string   `json:"language"`
+		WavSCP    string   `json:"wav_scp" binding:"required"`
+		Text      string   `json:"text" binding:"required"`
+		Utt2Spk   string   `json:"utt2spk"`
+		Tags      []string `json:"tags"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.ProjectID <= 0 || req.Language == "" {
+		errorJSON(c, http.StatusBadRequest, "project_id and language are required")
+		return
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), req.ProjectID); err != nil {
+		storeError(c, err)
+		return
+	}
+	utterances, err := kaldi.Parse(req.WavSCP, req.Text, req.Utt2Spk)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var created []*models.ASRTestCase
+	var importErrors []string
+	for _, utt := range utterances {
+		tc, err := s.importKaldiUtterance(c, req.ProjectID, req.Language, req.Tags, utt)
+		if err != nil {
+			importErrors = append(importErrors, fmt.Sprintf("%s: %v", utt.ID, err))
+			continue
+		}
+		created = append(created, tc)
+	}
+	status := http.StatusCreated
+	if len(created) == 0 {
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, gin.H{
+		"created": len(created),
+		"cases":   created,
+		"errors":  importErrors,
+	})
+}
+
+func (s *Server) importKaldiUtterance(c *gin.Context, projectID int64, language string, tags []string, utt kaldi.Utterance) (*models.ASRTestCase, error) {
+	u, err := url.Parse(utt.Audio)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		// Local corpus paths are not reachable from the server process.
+		return nil, fmt.Errorf("audio location %q is not an HTTP(S) URL; serve the corpus over HTTP to import it", utt.Audio)
+	}
+	resp, err := kaldiFetchClient().Get(utt.Audio)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch audio: HTTP %d", resp.StatusCode)
+	}
+	audio, err := io.ReadAll(io.LimitReader(resp.Body, maxAudioUploadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(audio) > maxAudioUploadBytes {
+		return nil, fmt.Errorf("audio exceeds the %d byte upload limit", maxAudioUploadBytes)
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(u.Path), "."))
+	if ext == "" {
+		ext = "wav"
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	key, err := s.content.PutContent(c.Request.Context(), "asr-audio", audio, ext, contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	caseTags := append([]string{}, tags...)
+	if utt.Speaker != "" {
+		caseTags = append(caseTags, models.SpeakerTagPrefix+utt.Speaker)
+	}
+	tc := &models.ASRTestCase{
+		ProjectID:      projectID,
+		Name:           utt.ID,
+		Language:       language,
+		AudioObjectKey: key,
+		AudioFormat:    ext,
+		DurationMS:     audioinfo.DurationMS(ext, audio),
+		GroundTruth:    utt.Text,
+		Tags:           caseTags,
+	}
+	if err := s.store.CreateASRTestCase(c.Request.Context(), tc); err != nil {
+		return nil, err
+	}
+	return tc, nil
+}
@@ -0,0 +1,36 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListNotificationsHandler lists a project's notifications; pass
+// ?unread=true to restrict to unread ones.
+func (s *Server) ListNotificationsHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	unreadOnly := c.Query("unread") == "true"
+	notifications, err := s.store.ListNotifications(c.Request.Context(), projectID, unreadOnly)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"notifications": notifications})
+}
+
+// MarkNotificationReadHandler flags a notification as read.
+func (s *Server) MarkNotificationReadHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.store.MarkNotificationRead(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"read": id})
+}
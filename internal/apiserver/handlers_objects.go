@@ -0,0 +1,50 @@
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	presignDefaultExpiry = 5 * time.Minute
+	presignMaxExpiry     = time.Hour
+)
+
+// PresignObjectHandler issues a short-lived GET URL for an audio object, so
+// the frontend plays audio straight from object storage instead of routing
+// the bytes through Gin. Only keys owned by a test case are presignable;
+// arbitrary bucket keys are refused.
+func (s *Server) PresignObjectHandler(c *gin.Context) {
+	var req struct {
+		ObjectKey     string `json:"object_key" binding:"required"`
+		ExpirySeconds int64  `json:"expiry_seconds"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	testCaseID, err := s.store.TestCaseIDByAudioObjectKey(c.Request.Context(), req.ObjectKey)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	expiry := presignDefaultExpiry
+	if req.ExpirySeconds > 0 {
+		expiry = time.Duration(req.ExpirySeconds) * time.Second
+		if expiry > presignMaxExpiry {
+			expiry = presignMaxExpiry
+		}
+	}
+	url, err := s.objects.PresignedGetURL(c.Request.Context(), req.ObjectKey, expiry)
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"url":          url,
+		"test_case_id": testCaseID,
+		"expires_at":   time.Now().Add(expiry).UTC(),
+	})
+}
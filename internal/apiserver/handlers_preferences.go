@@ -0,0 +1,58 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPreferencesBytes caps the preference blob; it holds UI state, not data.
+const maxPreferencesBytes = 64 << 10
+
+// preferencesUser resolves the calling user. The platform has a single
+// shared admin token, so the frontend identifies the person behind it with
+// the X-User header; without one, preferences are shared under "admin".
+func preferencesUser(c *gin.Context) string {
+	if user := c.GetHeader("X-User"); user != "" {
+		return user
+	}
+	return "admin"
+}
+
+// GetPreferencesHandler returns the caller's saved preferences: default
+// project, favorite vendor configs, column layouts and so on. The blob is
+// free-form; its shape belongs to the frontend.
+func (s *Server) GetPreferencesHandler(c *gin.Context) {
+	prefs, err := s.store.GetUserPreferences(c.Request.Context(), preferencesUser(c))
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"user": preferencesUser(c), "preferences": prefs})
+}
+
+// PutPreferencesHandler replaces the caller's preferences with the request
+// body, which must be a JSON object.
+func (s *Server) PutPreferencesHandler(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPreferencesBytes+1))
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(body) > maxPreferencesBytes {
+		errorJSON(c, http.StatusRequestEntityTooLarge, "preferences blob too large")
+		return
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(body, &obj); err != nil {
+		errorJSON(c, http.StatusBadRequest, "preferences must be a JSON object")
+		return
+	}
+	if err := s.store.UpsertUserPreferences(c.Request.Context(), preferencesUser(c), body); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"user": preferencesUser(c), "preferences": json.RawMessage(body)})
+}
@@ -0,0 +1,51 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateProjectHandler creates a new project workspace.
+func (s *Server) CreateProjectHandler(c *gin.Context) {
+	var req struct {
+		Name        string `json:"name" binding:"required"`
+		Description string `json:"description"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	p := &models.Project{Name: req.Name, Description: req.Description}
+	if err := s.store.CreateProject(c.Request.Context(), p); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, p)
+}
+
+// ListProjectsHandler lists all projects.
+func (s *Server) ListProjectsHandler(c *gin.Context) {
+	projects, err := s.store.ListProjects(c.Request.Context())
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"projects": projects})
+}
+
+// GetProjectHandler fetches one project.
+func (s *Server) GetProjectHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	p, err := s.store.GetProject(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
@@ -0,0 +1,216 @@
+package apiserver
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateQASamplesHandler randomly samples N scored results per vendor from a
+// job and assigns them to a reviewer. The draw is seeded so a sampling round
+// can be reproduced.
+func (s *Server) CreateQASamplesHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		PerVendor int    `json:"per_vendor" binding:"required"`
+		Reviewer  string `json:"reviewer" binding:"required"`
+		Seed      int64  `json:"seed"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Seed == 0 {
+		req.Seed = time.Now().UnixNano()
+	}
+
+	results, err := s.store.ListASRResultsByJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	byVendor := map[int64][]*models.ASRResult{}
+	for _, r := range results {
+		if r.ErrorCode != "" {
+			continue
+		}
+		byVendor[r.VendorConfigID] = append(byVendor[r.VendorConfigID], r)
+	}
+	vendorIDs := make([]int64, 0, len(byVendor))
+	for vendorID := range byVendor {
+		vendorIDs = append(vendorIDs, vendorID)
+	}
+	sort.Slice(vendorIDs, func(i, j int) bool { return vendorIDs[i] < vendorIDs[j] })
+
+	rng := rand.New(rand.NewSource(req.Seed))
+	var reviews []*models.QAReview
+	for _, vendorID := range vendorIDs {
+		rows := byVendor[vendorID]
+		rng.Shuffle(len(rows), func(i, j int) { rows[i], rows[j] = rows[j], rows[i] })
+		n := req.PerVendor
+		if n > len(rows) {
+			n = len(rows)
+		}
+		for _, r := range rows[:n] {
+			review := &models.QAReview{
+				JobID:          id,
+				ResultID:       r.ID,
+				VendorConfigID: vendorID,
+				Reviewer:       req.Reviewer,
+			}
+			if err := s.store.CreateQAReview(c.Request.Context(), review); err != nil {
+				storeError(c, err)
+				return
+			}
+			reviews = append(reviews, review)
+		}
+	}
+	c.JSON(http.StatusCreated, gin.H{"seed": req.Seed, "reviews": reviews})
+}
+
+// ListQASamplesHandler lists a job's review assignments.
+func (s *Server) ListQASamplesHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	reviews, err := s.store.ListQAReviewsByJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"reviews": reviews})
+}
+
+// CompleteQAReviewHandler records a reviewer's verdict on one sample.
+func (s *Server) CompleteQAReviewHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		Score *float64 `json:"score" binding:"required"`
+		Notes string   `json:"notes"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if *req.Score < 0 || *req.Score > 1 {
+		errorJSON(c, http.StatusBadRequest, "score must be between 0 and 1")
+		return
+	}
+	review, err := s.store.CompleteQAReview(c.Request.Context(), id, *req.Score, req.Notes)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// qaVendorReport compares human judgments with automatic metrics for one
+// vendor: Correlation is the Pearson correlation between the human score
+// and (1 - WER) over the completed reviews.
+type qaVendorReport struct {
+	VendorConfigID int64   `json:"vendor_config_id"`
+	Assigned       int     `json:"assigned"`
+	Completed      int     `json:"completed"`
+	MeanScore      float64 `json:"mean_score"`
+	MeanWER        float64 `json:"mean_wer"`
+	Correlation    float64 `json:"correlation"`
+}
+
+// QAReportHandler summarizes a job's completed reviews per vendor.
+func (s *Server) QAReportHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	reviews, err := s.store.ListQAReviewsByJob(ctx, id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+
+	type sample struct{ score, wer float64 }
+	assigned := map[int64]int{}
+	samples := map[int64][]sample{}
+	for _, review := range reviews {
+		assigned[review.VendorConfigID]++
+		if review.Status != models.QAReviewCompleted || review.Score == nil {
+			continue
+		}
+		result, err := s.store.GetASRResult(ctx, review.ResultID)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		samples[review.VendorConfigID] = append(samples[review.VendorConfigID],
+			sample{score: *review.Score, wer: result.WER})
+	}
+
+	vendorIDs := make([]int64, 0, len(assigned))
+	for vendorID := range assigned {
+		vendorIDs = append(vendorIDs, vendorID)
+	}
+	sort.Slice(vendorIDs, func(i, j int) bool { return vendorIDs[i] < vendorIDs[j] })
+
+	reports := make([]qaVendorReport, 0, len(vendorIDs))
+	for _, vendorID := range vendorIDs {
+		report := qaVendorReport{VendorConfigID: vendorID, Assigned: assigned[vendorID]}
+		rows := samples[vendorID]
+		report.Completed = len(rows)
+		if len(rows) > 0 {
+			scores := make([]float64, len(rows))
+			accuracies := make([]float64, len(rows))
+			for i, row := range rows {
+				scores[i] = row.score
+				accuracies[i] = 1 - row.wer
+				report.MeanScore += row.score
+				report.MeanWER += row.wer
+			}
+			report.MeanScore /= float64(len(rows))
+			report.MeanWER /= float64(len(rows))
+			report.Correlation = pearson(scores, accuracies)
+		}
+		reports = append(reports, report)
+	}
+	c.JSON(http.StatusOK, gin.H{"job_id": id, "vendors": reports})
+}
+
+// pearson is the sample Pearson correlation coefficient; 0 when either side
+// has no variance or there are fewer than two samples.
+func pearson(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	if len(xs) < 2 {
+		return 0
+	}
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= n
+	meanY /= n
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
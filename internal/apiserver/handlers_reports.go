@@ -0,0 +1,129 @@
+package apiserver
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// JobReportHandler generates a CSV or HTML report of a job's results as a
+// chunked response. Rows are paged out of the database by id cursor and
+// flushed page by page, so multi-hundred-MB reports stream in constant
+// memory; a disconnected client stops the database reads at the next page
+// boundary.
+func (s *Server) JobReportHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	job, err := s.store.GetEvaluationJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	loc := time.UTC
+	if project, err := s.store.GetProject(c.Request.Context(), job.ProjectID); err == nil {
+		if l, err := time.LoadLocation(project.TimeZone); err == nil {
+			loc = l
+		}
+	}
+	switch format := c.DefaultQuery("format", "csv"); format {
+	case "csv":
+		s.streamCSVReport(c, job)
+	case "html":
+		s.streamHTMLReport(c, job, loc)
+	default:
+		errorJSON(c, http.StatusBadRequest, "unsupported report format: "+format)
+	}
+}
+
+// forEachResultPage feeds a job's results page by page to fn, flushing the
+// response writer between pages and stopping when the client goes away. Once
+// fn has run the status line is sent, so errors can only end the stream.
+func (s *Server) forEachResultPage(c *gin.Context, jobID int64, fn func([]*models.ASRResult) error) {
+	ctx := c.Request.Context()
+	afterID := int64(0)
+	for {
+		page, err := s.store.ListASRResultsByJobPage(ctx, jobID, afterID, resultStreamPageSize)
+		if err != nil {
+			return
+		}
+		if err := fn(page); err != nil {
+			return
+		}
+		c.Writer.Flush()
+		if ctx.Err() != nil || len(page) < resultStreamPageSize {
+			return
+		}
+		afterID = page[len(page)-1].ID
+	}
+}
+
+func (s *Server) streamCSVReport(c *gin.Context, job *models.EvaluationJob) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=job-%d-report.csv", job.ID))
+	// A UTF-8 BOM up front keeps spreadsheet apps from guessing a legacy
+	// encoding and mangling non-Latin (notably RTL) transcripts.
+	_, _ = c.Writer.WriteString("\uFEFF")
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{"result_id", "asr_test_case_id", "vendor_config_id",
+		"recognized_text", "wer", "cer", "latency_ms", "error_code", "error_message"})
+	s.forEachResultPage(c, job.ID, func(page []*models.ASRResult) error {
+		for _, r := range page {
+			err := w.Write([]string{
+				strconv.FormatInt(r.ID, 10),
+				strconv.FormatInt(r.ASRTestCaseID, 10),
+				strconv.FormatInt(r.VendorConfigID, 10),
+				r.RecognizedText,
+				strconv.FormatFloat(r.WER, 'f', 4, 64),
+				strconv.FormatFloat(r.CER, 'f', 4, 64),
+				strconv.FormatInt(r.LatencyMS, 10),
+				r.ErrorCode,
+				r.ErrorMessage,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	})
+	w.Flush()
+}
+
+var htmlReportRow = template.Must(template.New("row").Parse(
+	// dir="auto" lets the browser pick the base direction per transcript, so
+	// RTL text renders right-to-left without flipping the rest of the table.
+	`<tr><td>{{.ID}}</td><td>{{.ASRTestCaseID}}</td><td>{{.VendorConfigID}}</td>` +
+		`<td dir="auto">{{.RecognizedText}}</td><td>{{printf "%.4f" .WER}}</td>` +
+		`<td>{{printf "%.4f" .CER}}</td><td>{{.LatencyMS}}</td>` +
+		`<td>{{.ErrorCode}}</td></tr>` + "\n"))
+
+func (s *Server) streamHTMLReport(c *gin.Context, job *models.EvaluationJob, loc *time.Location) {
+	c.Header("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(c.Writer, `<!DOCTYPE html><html><head><meta charset="utf-8"><title>%s</title>`+
+		`<style>table{border-collapse:collapse}td,th{border:1px solid #ccc;padding:4px}</style>`+
+		`</head><body><h1>%s</h1><p>Generated %s</p>`+
+		`<table><tr><th>Result</th><th>Test case</th>`+
+		`<th>Vendor</th><th>Transcript</th><th>WER</th><th>CER</th>`+
+		`<th>Latency (ms)</th><th>Error</th></tr>`+"\n",
+		template.HTMLEscapeString(job.Name), template.HTMLEscapeString(job.Name),
+		time.Now().In(loc).Format("2006-01-02 15:04:05 MST"))
+	s.forEachResultPage(c, job.ID, func(page []*models.ASRResult) error {
+		for _, r := range page {
+			if err := htmlReportRow.Execute(c.Writer, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	fmt.Fprint(c.Writer, "</table></body></html>\n")
+}
@@ -0,0 +1,225 @@
+package apiserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/coreengine"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/pricing"
+)
+
+// runnerAudioURLExpiry bounds how long a leased task's presigned audio URL
+// stays valid. Agents are expected to download promptly after leasing.
+const runnerAudioURLExpiry = 15 * time.Minute
+
+// RegisterRunnerAgentHandler registers a remote runner agent and returns its
+// bearer token. The token is shown exactly once; it cannot be retrieved
+// later, only replaced by registering a new agent.
+func (s *Server) RegisterRunnerAgentHandler(c *gin.Context) {
+	var req struct {
+		Name string `json:"name" binding:"required"`
+		Pool string `json:"pool" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+	agent := &models.RunnerAgent{
+		Name:  req.Name,
+		Pool:  req.Pool,
+		Token: hex.EncodeToString(buf),
+	}
+	if err := s.store.CreateRunnerAgent(c.Request.Context(), agent); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, agent)
+}
+
+// ListRunnerAgentsHandler lists registered agents, without tokens.
+func (s *Server) ListRunnerAgentsHandler(c *gin.Context) {
+	agents, err := s.store.ListRunnerAgents(c.Request.Context())
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"agents": agents})
+}
+
+// leasedTask is one task handed to an agent: the assignment itself, the test
+// case metadata, a presigned URL for the audio and the full vendor config so
+// the agent can run the adapter locally. Ground truth is deliberately
+// omitted; scoring happens server-side when the result is pushed.
+type leasedTask struct {
+	Task         *models.RunnerTask   `json:"task"`
+	TestCase     runnerTestCase       `json:"test_case"`
+	AudioURL     string               `json:"audio_url"`
+	VendorConfig *models.VendorConfig `json:"vendor_config"`
+}
+
+// runnerTestCase is the subset of a test case an agent needs to call the
+// vendor.
+type runnerTestCase struct {
+	ID          int64  `json:"id"`
+	Language    string `json:"language"`
+	AudioFormat string `json:"audio_format"`
+}
+
+// LeaseRunnerTasksHandler hands the calling agent up to max pending tasks
+// from its pool, with presigned audio URLs and vendor credentials.
+func (s *Server) LeaseRunnerTasksHandler(c *gin.Context) {
+	agent := runnerAgent(c)
+	var req struct {
+		Max int `json:"max"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.Max <= 0 {
+		req.Max = 1
+	}
+	ctx := c.Request.Context()
+	tasks, err := s.store.LeaseRunnerTasks(ctx, agent.Pool, agent.ID, req.Max)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	leased := make([]leasedTask, 0, len(tasks))
+	for _, task := range tasks {
+		tc, err := s.store.GetASRTestCase(ctx, task.ASRTestCaseID)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		vc, err := s.store.GetVendorConfig(ctx, task.VendorConfigID)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		audioURL, err := s.objects.PresignedGetURL(ctx, tc.AudioObjectKey, runnerAudioURLExpiry)
+		if err != nil {
+			errorJSON(c, http.StatusBadGateway, err.Error())
+			return
+		}
+		leased = append(leased, leasedTask{
+			Task: task,
+			TestCase: runnerTestCase{
+				ID:          tc.ID,
+				Language:    tc.Language,
+				AudioFormat: tc.AudioFormat,
+			},
+			AudioURL:     audioURL,
+			VendorConfig: vc,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"tasks": leased})
+}
+
+// PushRunnerResultHandler accepts a completed task from the agent that
+// leased it. The agent reports only what the vendor returned; WER, CER and
+// segment scores are computed here against the stored ground truth.
+func (s *Server) PushRunnerResultHandler(c *gin.Context) {
+	agent := runnerAgent(c)
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		RecognizedText    string          `json:"recognized_text"`
+		Confidence        float64         `json:"confidence"`
+		LatencyMS         int64           `json:"latency_ms"`
+		VendorLatencyMS   int64           `json:"vendor_latency_ms"`
+		RawVendorResponse json.RawMessage `json:"raw_vendor_response"`
+		ErrorCode         string          `json:"error_code"`
+		ErrorMessage      string          `json:"error_message"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	task, err := s.store.CompleteRunnerTask(ctx, id, agent.ID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	tc, err := s.store.GetASRTestCase(ctx, task.ASRTestCaseID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	job, err := s.store.GetEvaluationJob(ctx, task.JobID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	params := map[string]any{}
+	if len(job.Parameters) > 0 {
+		_ = json.Unmarshal(job.Parameters, &params)
+	}
+
+	result := &models.ASRResult{
+		JobID:             task.JobID,
+		ASRTestCaseID:     task.ASRTestCaseID,
+		VendorConfigID:    task.VendorConfigID,
+		LatencyMS:         req.LatencyMS,
+		RawVendorResponse: req.RawVendorResponse,
+		ErrorCode:         req.ErrorCode,
+		ErrorMessage:      req.ErrorMessage,
+	}
+	if req.ErrorCode == "" {
+		result.RecognizedText = req.RecognizedText
+		result.Confidence = req.Confidence
+		result.VendorLatencyMS = req.VendorLatencyMS
+		// Score server-side with the same pipeline as the in-process
+		// engine, so agent results are directly comparable.
+		var vc *models.VendorConfig
+		if loaded, err := s.store.GetVendorConfig(ctx, task.VendorConfigID); err == nil {
+			vc = loaded
+		}
+		coreengine.ScoreTranscript(result, tc, vc, params, req.RecognizedText)
+	}
+	if err := s.store.InsertASRResult(ctx, result); err != nil {
+		storeError(c, err)
+		return
+	}
+	s.cache.Invalidate(cache.JobSummaryKey(task.JobID))
+
+	if req.ErrorCode == "" {
+		vc, err := s.store.GetVendorConfig(ctx, task.VendorConfigID)
+		if err == nil {
+			if cost := pricing.EstimatePerCall(vc); cost > 0 {
+				_ = s.store.RecordSpend(ctx, &models.SpendRecord{
+					ProjectID:      job.ProjectID,
+					VendorConfigID: vc.ID,
+					JobID:          job.ID,
+					AmountUSD:      cost,
+					Month:          time.Now().UTC().Format("2006-01"),
+				})
+			}
+		}
+	}
+
+	// The last pushed result completes the job.
+	open, err := s.store.CountOpenRunnerTasks(ctx, task.JobID)
+	if err == nil && open == 0 && job.Status == models.JobStatusRunning {
+		if err := s.store.MarkJobCompleted(ctx, task.JobID); err == nil {
+			job.Status = models.JobStatusCompleted
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"task": task, "job_status": job.Status})
+}
@@ -0,0 +1,114 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/jobmanagement"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// SetProjectTimeZoneHandler sets the IANA time zone a project's schedules
+// and report timestamps are expressed in.
+func (s *Server) SetProjectTimeZoneHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		TimeZone string `json:"time_zone" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := time.LoadLocation(req.TimeZone); err != nil {
+		errorJSON(c, http.StatusBadRequest, "unknown time zone: "+req.TimeZone)
+		return
+	}
+	if err := s.store.SetProjectTimeZone(c.Request.Context(), id, req.TimeZone); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"project_id": id, "time_zone": req.TimeZone})
+}
+
+// CreateScheduleHandler registers a recurring job submission for a project.
+// The spec is interpreted in the project's time zone; the stored job
+// template is instantiated on every firing.
+func (s *Server) CreateScheduleHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	project, err := s.store.GetProject(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	var req struct {
+		Name        string          `json:"name" binding:"required"`
+		Spec        string          `json:"spec" binding:"required"`
+		JobTemplate json.RawMessage `json:"job_template" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	spec, err := jobmanagement.ParseScheduleSpec(req.Spec)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !json.Valid(req.JobTemplate) {
+		errorJSON(c, http.StatusBadRequest, "job_template must be valid JSON")
+		return
+	}
+	loc, err := time.LoadLocation(project.TimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+	schedule := &models.JobSchedule{
+		ProjectID:   id,
+		Name:        req.Name,
+		Spec:        req.Spec,
+		JobTemplate: req.JobTemplate,
+		Enabled:     true,
+		NextRunAt:   spec.NextRunAfter(time.Now(), loc),
+	}
+	if err := s.store.CreateJobSchedule(c.Request.Context(), schedule); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// ListSchedulesHandler lists a project's job schedules.
+func (s *Server) ListSchedulesHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	schedules, err := s.store.ListJobSchedules(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"schedules": schedules})
+}
+
+// DeleteScheduleHandler removes a job schedule.
+func (s *Server) DeleteScheduleHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteJobSchedule(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,261 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateScoreFormulaHandler defines a composite score formula for a project.
+func (s *Server) CreateScoreFormulaHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		Name       string                  `json:"name" binding:"required"`
+		Components []models.ScoreComponent `json:"components" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, comp := range req.Components {
+		if comp.Metric == "" {
+			errorJSON(c, http.StatusBadRequest, "every component needs a metric")
+			return
+		}
+		if comp.Weight <= 0 {
+			errorJSON(c, http.StatusBadRequest, fmt.Sprintf("component %s needs a positive weight", comp.Metric))
+			return
+		}
+		if comp.NormalizeMax < 0 {
+			errorJSON(c, http.StatusBadRequest, fmt.Sprintf("component %s has a negative normalize_max", comp.Metric))
+			return
+		}
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), projectID); err != nil {
+		storeError(c, err)
+		return
+	}
+	formula := &models.ScoreFormula{
+		ProjectID:  projectID,
+		Name:       req.Name,
+		Components: req.Components,
+	}
+	if err := s.store.CreateScoreFormula(c.Request.Context(), formula); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, formula)
+}
+
+// ListScoreFormulasHandler lists a project's composite score formulas.
+func (s *Server) ListScoreFormulasHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	formulas, err := s.store.ListScoreFormulas(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"formulas": formulas})
+}
+
+// DeleteScoreFormulaHandler removes a composite score formula.
+func (s *Server) DeleteScoreFormulaHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteScoreFormula(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": id})
+}
+
+// leaderboardEntry is one vendor's composite score under a formula.
+type leaderboardEntry struct {
+	VendorConfigID int64   `json:"vendor_config_id"`
+	Region         string  `json:"region,omitempty"`
+	Score          float64 `json:"score"`
+	// CoveredWeight is the fraction of the formula's weight mass backed by
+	// metrics the vendor actually recorded; terms a vendor has no data for
+	// are dropped and the rest renormalized.
+	CoveredWeight float64 `json:"covered_weight"`
+}
+
+// JobLeaderboardHandler ranks a job's vendors by the project's composite
+// score formula, highest first. ?formula_id= picks the formula; without it
+// the project's single formula applies, and more than one is an error so a
+// leaderboard never silently switches definitions.
+func (s *Server) JobLeaderboardHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	job, err := s.store.GetEvaluationJob(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	formula, err := s.leaderboardFormula(c.Request.Context(), job.ProjectID, queryInt64(c, "formula_id", 0))
+	if err != nil {
+		if errors.Is(err, datastore.ErrNotFound) {
+			storeError(c, err)
+		} else {
+			errorJSON(c, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+	if formula.ProjectID != job.ProjectID {
+		errorJSON(c, http.StatusBadRequest, "score formula belongs to another project")
+		return
+	}
+	entries, err := s.computeLeaderboard(c.Request.Context(), id, formula)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"job_id": id, "formula": formula, "leaderboard": entries})
+}
+
+func (s *Server) leaderboardFormula(ctx context.Context, projectID, formulaID int64) (*models.ScoreFormula, error) {
+	if formulaID > 0 {
+		return s.store.GetScoreFormula(ctx, formulaID)
+	}
+	formulas, err := s.store.ListScoreFormulas(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	switch len(formulas) {
+	case 0:
+		return nil, fmt.Errorf("project %d has no score formulas", projectID)
+	case 1:
+		return formulas[0], nil
+	default:
+		return nil, fmt.Errorf("project %d has %d score formulas; pass formula_id", projectID, len(formulas))
+	}
+}
+
+// computeLeaderboard evaluates the formula per vendor over the job's summary
+// aggregates and extra metric means.
+func (s *Server) computeLeaderboard(ctx context.Context, jobID int64, formula *models.ScoreFormula) ([]*leaderboardEntry, error) {
+	vendors, err := s.store.SummarizeJob(ctx, jobID, datastore.DefaultWERCap)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		vendor int64
+		region string
+	}
+	// Extra metric means are fetched once per distinct metric the formula
+	// references, then looked up per vendor.
+	extra := map[string]map[key]float64{}
+	for _, comp := range formula.Components {
+		if baseMetric(nil, comp.Metric) != nil || extra[comp.Metric] != nil {
+			continue
+		}
+		means, err := s.store.MeanExtraMetricByVendor(ctx, jobID, comp.Metric)
+		if err != nil {
+			return nil, err
+		}
+		byVendor := map[key]float64{}
+		for _, m := range means {
+			byVendor[key{m.VendorConfigID, m.Region}] = m.Mean
+		}
+		extra[comp.Metric] = byVendor
+	}
+
+	var totalWeight float64
+	for _, comp := range formula.Components {
+		totalWeight += comp.Weight
+	}
+	entries := make([]*leaderboardEntry, 0, len(vendors))
+	for _, vs := range vendors {
+		entry := &leaderboardEntry{VendorConfigID: vs.VendorConfigID, Region: vs.Region}
+		var covered float64
+		for _, comp := range formula.Components {
+			value := baseMetric(vs, comp.Metric)
+			if value == nil {
+				if v, ok := extra[comp.Metric][key{vs.VendorConfigID, vs.Region}]; ok {
+					value = &v
+				}
+			}
+			if value == nil {
+				continue
+			}
+			entry.Score += comp.Weight * normalizeComponent(comp, *value)
+			covered += comp.Weight
+		}
+		if covered > 0 {
+			entry.Score /= covered
+		}
+		if totalWeight > 0 {
+			entry.CoveredWeight = covered / totalWeight
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Score != entries[j].Score {
+			return entries[i].Score > entries[j].Score
+		}
+		return entries[i].VendorConfigID < entries[j].VendorConfigID
+	})
+	return entries, nil
+}
+
+// baseMetric resolves a summary-level metric name for a vendor, or nil for
+// names served by the extra metric means. A nil summary only classifies the
+// name.
+func baseMetric(vs *datastore.VendorSummary, metric string) *float64 {
+	if vs == nil {
+		switch metric {
+		case "wer", "cer", "latency_ms":
+			zero := 0.0
+			return &zero
+		}
+		return nil
+	}
+	var v float64
+	switch metric {
+	case "wer":
+		v = vs.CappedMeanWER
+	case "cer":
+		v = vs.MeanCER
+	case "latency_ms":
+		v = vs.MeanLatencyMS
+	default:
+		return nil
+	}
+	return &v
+}
+
+// normalizeComponent maps a raw metric value into the component's [0,1]
+// term: optional scaling by normalize_max, clamping, optional inversion.
+func normalizeComponent(comp models.ScoreComponent, value float64) float64 {
+	if comp.NormalizeMax > 0 {
+		value /= comp.NormalizeMax
+	}
+	if value < 0 {
+		value = 0
+	}
+	if value > 1 {
+		value = 1
+	}
+	if comp.Invert {
+		value = 1 - value
+	}
+	return value
+}
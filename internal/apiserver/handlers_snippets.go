@@ -0,0 +1,140 @@
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/transcode"
+)
+
+// TestCaseSnippetHandler serves the audio span [start_ms, end_ms) of a test
+// case as a standalone WAV, so reviewers can listen to exactly the seconds
+// where a vendor went wrong. PCM WAV sources are sliced in-process; other
+// formats go through the external transcoding service when one is
+// configured.
+func (s *Server) TestCaseSnippetHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	startMS := queryInt64(c, "start_ms", 0)
+	endMS := queryInt64(c, "end_ms", 0)
+	if endMS <= startMS {
+		errorJSON(c, http.StatusBadRequest, "end_ms must be greater than start_ms")
+		return
+	}
+
+	tc, err := s.store.GetASRTestCase(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	audio, err := s.objects.Get(c.Request.Context(), tc.AudioObjectKey)
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, "fetch audio: "+err.Error())
+		return
+	}
+
+	var snippet []byte
+	if tc.AudioFormat == "wav" {
+		snippet, err = transcode.SliceWAV(audio, startMS, endMS)
+		if err != nil {
+			errorJSON(c, http.StatusBadRequest, err.Error())
+			return
+		}
+	} else {
+		if s.transcoder == nil {
+			errorJSON(c, http.StatusServiceUnavailable,
+				"snippets from "+tc.AudioFormat+" audio need a transcoder (AITP_TRANSCODER_URL)")
+			return
+		}
+		snippet, err = s.transcoder.Extract(c.Request.Context(), audio, tc.AudioFormat, startMS, endMS)
+		if err != nil {
+			errorJSON(c, http.StatusBadGateway, err.Error())
+			return
+		}
+	}
+	c.Data(http.StatusOK, "audio/wav", snippet)
+}
+
+// audioURLExpiry is how long a presigned test-case audio link stays valid.
+const audioURLExpiry = 15 * time.Minute
+
+// TestCaseAudioHandler serves a test case's full audio clip. By default the
+// object is streamed through the API with download headers; ?presigned=true
+// instead returns a short-lived direct object-store URL, which is the better
+// fit for players and large files.
+func (s *Server) TestCaseAudioHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	tc, err := s.store.GetASRTestCase(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	if c.Query("presigned") == "true" {
+		url, err := s.objects.PresignedGetURL(c.Request.Context(), tc.AudioObjectKey, audioURLExpiry)
+		if err != nil {
+			errorJSON(c, http.StatusBadGateway, err.Error())
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"test_case_id": tc.ID,
+			"url":          url,
+			"expires_at":   time.Now().Add(audioURLExpiry).UTC(),
+		})
+		return
+	}
+	audio, err := s.objects.Get(c.Request.Context(), tc.AudioObjectKey)
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, "fetch audio: "+err.Error())
+		return
+	}
+	c.Header("Content-Disposition",
+		fmt.Sprintf("attachment; filename=%s.%s", sanitizeFilename(tc.Name), tc.AudioFormat))
+	c.Data(http.StatusOK, audioContentType(tc.AudioFormat), audio)
+}
+
+// audioContentType maps a stored audio format to its MIME type.
+func audioContentType(format string) string {
+	switch format {
+	case "wav":
+		return "audio/wav"
+	case "mp3":
+		return "audio/mpeg"
+	case "flac":
+		return "audio/flac"
+	case "ogg", "opus":
+		return "audio/ogg"
+	case "m4a", "aac", "mp4":
+		return "audio/mp4"
+	case "amr":
+		return "audio/amr"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// sanitizeFilename keeps a case name safe for a Content-Disposition header.
+func sanitizeFilename(name string) string {
+	clean := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case r == '-' || r == '_' || r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+	if clean == "" {
+		clean = "audio"
+	}
+	return clean
+}
@@ -0,0 +1,103 @@
+package apiserver
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+)
+
+// AssignSplitsHandler partitions a project's test cases into named splits.
+// Random mode shuffles every case with the given seed and carves the deck by
+// percentage; manual mode places listed case IDs into one split without
+// touching the rest. Exactly one mode must be used per request.
+func (s *Server) AssignSplitsHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		Seed   int64                 `json:"seed"`
+		Splits []datastore.SplitSpec `json:"splits"`
+		Manual *struct {
+			Split string  `json:"split"`
+			IDs   []int64 `json:"ids"`
+		} `json:"manual"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), projectID); err != nil {
+		storeError(c, err)
+		return
+	}
+
+	if req.Manual != nil {
+		if len(req.Splits) > 0 {
+			errorJSON(c, http.StatusBadRequest, "splits and manual are mutually exclusive")
+			return
+		}
+		if len(req.Manual.IDs) == 0 {
+			errorJSON(c, http.StatusBadRequest, "manual.ids must not be empty")
+			return
+		}
+		assigned, err := s.store.AssignSplitManual(c.Request.Context(), projectID, req.Manual.Split, req.Manual.IDs)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"assigned": assigned})
+		return
+	}
+
+	if len(req.Splits) == 0 {
+		errorJSON(c, http.StatusBadRequest, "either splits or manual is required")
+		return
+	}
+	total := 0.0
+	seen := map[string]bool{}
+	for _, spec := range req.Splits {
+		if spec.Name == "" {
+			errorJSON(c, http.StatusBadRequest, "split names must not be empty")
+			return
+		}
+		if seen[spec.Name] {
+			errorJSON(c, http.StatusBadRequest, "split names must be distinct")
+			return
+		}
+		seen[spec.Name] = true
+		if spec.Percent <= 0 {
+			errorJSON(c, http.StatusBadRequest, "split percentages must be positive")
+			return
+		}
+		total += spec.Percent
+	}
+	if math.Abs(total-100) > 1e-6 {
+		errorJSON(c, http.StatusBadRequest, "split percentages must sum to 100")
+		return
+	}
+	counts, err := s.store.AssignSplitsRandom(c.Request.Context(), projectID, req.Seed, req.Splits)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"seed": req.Seed, "counts": counts})
+}
+
+// ListSplitsHandler reports how many of a project's test cases sit in each
+// split; unassigned cases appear under the empty name.
+func (s *Server) ListSplitsHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	counts, err := s.store.CountTestCasesBySplit(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"counts": counts})
+}
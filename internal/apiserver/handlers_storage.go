@@ -0,0 +1,84 @@
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProjectStorageHandler reports a project's audio storage usage against its
+// soft quota. Quotas never block uploads; over_quota is a flag for the UI.
+func (s *Server) ProjectStorageHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	project, err := s.store.GetProject(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	bytes, objects, err := s.store.ProjectStorageUsage(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":  id,
+		"used_bytes":  bytes,
+		"objects":     objects,
+		"quota_bytes": project.StorageQuotaBytes,
+		"over_quota":  project.StorageQuotaBytes > 0 && bytes > project.StorageQuotaBytes,
+	})
+}
+
+// SetStorageQuotaHandler sets or clears (0) a project's soft storage quota.
+func (s *Server) SetStorageQuotaHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req struct {
+		QuotaBytes *int64 `json:"quota_bytes" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if *req.QuotaBytes < 0 {
+		errorJSON(c, http.StatusBadRequest, "quota_bytes must not be negative")
+		return
+	}
+	if err := s.store.SetProjectStorageQuota(c.Request.Context(), id, *req.QuotaBytes); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"project_id": id, "quota_bytes": *req.QuotaBytes})
+}
+
+// StorageSuggestionsHandler lists cleanup candidates: the project's largest
+// test cases no job has ever referenced.
+func (s *Server) StorageSuggestionsHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	limit := int(queryInt64(c, "limit", 20))
+	if limit <= 0 || limit > 200 {
+		limit = 20
+	}
+	unused, err := s.store.ListUnusedTestCases(c.Request.Context(), id, limit)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	var reclaimable int64
+	for _, u := range unused {
+		reclaimable += u.SizeBytes
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":        id,
+		"reclaimable_bytes": reclaimable,
+		"unused_test_cases": unused,
+	})
+}
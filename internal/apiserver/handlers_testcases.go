@@ -0,0 +1,410 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/audioinfo"
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/subtitle"
+	"github.com/Jcateye/AITestPlatform/internal/synthdata"
+)
+
+// maxAudioUploadBytes caps single audio uploads at 100 MiB.
+const maxAudioUploadBytes = 100 << 20
+
+// CreateASRTestCaseHandler accepts a multipart form with the audio file and
+// its metadata, stores the audio in the object store and the metadata in the
+// database.
+func (s *Server) CreateASRTestCaseHandler(c *gin.Context) {
+	projectID := parseInt64(c.PostForm("project_id"))
+	if projectID <= 0 {
+		projectID = queryInt64(c, "project_id", 0)
+	}
+	form := struct {
+		name        string
+		language    string
+		groundTruth string
+		segments    string
+		entities    string
+		speaker     string
+		domain      string
+		tags        string
+	}{
+		name:        c.PostForm("name"),
+		language:    c.PostForm("language"),
+		groundTruth: c.PostForm("ground_truth"),
+		segments:    c.PostForm("segments"),
+		entities:    c.PostForm("entities"),
+		speaker:     c.PostForm("speaker"),
+		domain:      c.PostForm("domain"),
+		tags:        c.PostForm("tags"),
+	}
+	if !models.ValidDomain(form.domain) {
+		errorJSON(c, http.StatusBadRequest, fmt.Sprintf("domain must be one of %s", strings.Join(models.Domains, ", ")))
+		return
+	}
+
+	// Long recordings may supply timestamped segments instead of (or in
+	// addition to) the flat transcript; the flat ground truth is then the
+	// segment texts joined in order.
+	var segments []models.Segment
+	if form.segments != "" {
+		if err := json.Unmarshal([]byte(form.segments), &segments); err != nil {
+			errorJSON(c, http.StatusBadRequest, "segments must be a JSON array of {start_ms,end_ms,text}")
+			return
+		}
+	}
+	// Time-aligned references may instead arrive as a standard subtitle or
+	// transcription file; the parsed cues become the case's segments.
+	if gtHeader, err := c.FormFile("ground_truth_file"); err == nil {
+		gtFile, err := gtHeader.Open()
+		if err != nil {
+			errorJSON(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		data, err := io.ReadAll(gtFile)
+		gtFile.Close()
+		if err != nil {
+			errorJSON(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		parsed, err := subtitle.Parse(gtHeader.Filename, data)
+		if err != nil {
+			errorJSON(c, http.StatusBadRequest, fmt.Sprintf("parse %s: %v", gtHeader.Filename, err))
+			return
+		}
+		segments = parsed
+	}
+	var entities []models.Entity
+	if form.entities != "" {
+		if err := json.Unmarshal([]byte(form.entities), &entities); err != nil {
+			errorJSON(c, http.StatusBadRequest, "entities must be a JSON array of {type,text}")
+			return
+		}
+	}
+	// Manifest-driven imports pass the speaker attributes through the same
+	// field, so annotated corpora arrive annotated.
+	var speaker *models.SpeakerInfo
+	if form.speaker != "" {
+		if err := json.Unmarshal([]byte(form.speaker), &speaker); err != nil {
+			errorJSON(c, http.StatusBadRequest, "speaker must be a JSON object of {gender,age_range,accent,device}")
+			return
+		}
+	}
+	if form.groundTruth == "" && len(segments) > 0 {
+		texts := make([]string, len(segments))
+		for i, seg := range segments {
+			texts[i] = seg.Text
+		}
+		form.groundTruth = strings.Join(texts, " ")
+	}
+	if projectID <= 0 || form.language == "" || form.groundTruth == "" {
+		errorJSON(c, http.StatusBadRequest, "project_id, language and ground_truth (or segments) are required")
+		return
+	}
+
+	fileHeader, err := c.FormFile("audio")
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, "audio file is required")
+		return
+	}
+	if fileHeader.Size > maxAudioUploadBytes {
+		errorJSON(c, http.StatusRequestEntityTooLarge, "audio file too large")
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+	audio, err := io.ReadAll(file)
+	if err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(fileHeader.Filename), "."))
+	if ext == "" {
+		ext = "wav"
+	}
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	// Content-addressed storage: identical audio uploaded twice shares one
+	// object; each test case holds a reference.
+	key, err := s.content.PutContent(c.Request.Context(), "asr-audio", audio, ext, contentType)
+	if err != nil {
+		errorJSON(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	name := form.name
+	if name == "" {
+		name = fileHeader.Filename
+	}
+	tags := splitTags(form.tags)
+	// Mixed-script ground truth is tagged automatically so the summary's
+	// code-switched breakdown covers uploaded cases too.
+	if synthdata.IsCodeSwitched(form.groundTruth) && !containsTag(tags, synthdata.CodeSwitchedTag) {
+		tags = append(tags, synthdata.CodeSwitchedTag)
+	}
+	tc := &models.ASRTestCase{
+		ProjectID:      projectID,
+		Name:           name,
+		Language:       form.language,
+		AudioObjectKey: key,
+		AudioFormat:    ext,
+		DurationMS:     audioinfo.DurationMS(ext, audio),
+		Domain:         form.domain,
+		Speaker:        speaker,
+		GroundTruth:    form.groundTruth,
+		Segments:       segments,
+		Entities:       entities,
+		Tags:           tags,
+	}
+	if err := s.store.CreateASRTestCase(c.Request.Context(), tc); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, tc)
+}
+
+// GetASRTestCaseHandler fetches one test case.
+func (s *Server) GetASRTestCaseHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	tc, err := s.store.GetASRTestCase(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tc)
+}
+
+// ListASRTestCasesHandler lists a project's test cases, optionally filtered
+// by language.
+func (s *Server) ListASRTestCasesHandler(c *gin.Context) {
+	projectID := queryInt64(c, "project_id", 0)
+	if projectID <= 0 {
+		errorJSON(c, http.StatusBadRequest, "project_id query parameter is required")
+		return
+	}
+	cases, err := s.store.ListASRTestCases(c.Request.Context(), projectID, c.Query("language"))
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"test_cases": cases})
+}
+
+// SetTestCaseSpeakerHandler replaces a test case's speaker attributes. An
+// empty body object clears them.
+func (s *Server) SetTestCaseSpeakerHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var info models.SpeakerInfo
+	if err := c.ShouldBindJSON(&info); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	speaker := &info
+	if info == (models.SpeakerInfo{}) {
+		speaker = nil
+	}
+	if err := s.store.SetASRTestCaseSpeaker(c.Request.Context(), id, speaker); err != nil {
+		storeError(c, err)
+		return
+	}
+	tc, err := s.store.GetASRTestCase(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tc)
+}
+
+// DeleteASRTestCaseHandler removes a test case and its audio object.
+func (s *Server) DeleteASRTestCaseHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	tc, err := s.store.GetASRTestCase(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	if err := s.store.DeleteASRTestCase(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	// Drop this test case's reference; the object itself is only removed
+	// once nothing else points at it. Cleanup stays best-effort: an
+	// orphaned object is preferable to a dangling test case row.
+	_ = s.content.Release(c.Request.Context(), tc.AudioObjectKey)
+	c.JSON(http.StatusOK, gin.H{"deleted": id})
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func splitTags(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if t := strings.TrimSpace(p); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// BulkEditTestCasesHandler applies tag and metadata edits to every test case
+// matching a filter, transactionally. With dry_run=true it reports how many
+// cases would change without touching anything, so curators can check a
+// filter before running it over thousands of cases.
+func (s *Server) BulkEditTestCasesHandler(c *gin.Context) {
+	var req struct {
+		Filter struct {
+			IDs       []int64  `json:"ids"`
+			ProjectID int64    `json:"project_id"`
+			Tags      []string `json:"tags"`
+		} `json:"filter"`
+		AddTags       []string `json:"add_tags"`
+		RemoveTags    []string `json:"remove_tags"`
+		SetLanguage   string   `json:"set_language"`
+		SetDomain     string   `json:"set_domain"`
+		MoveToProject int64    `json:"move_to_project"`
+		DryRun        bool     `json:"dry_run"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(req.Filter.IDs) == 0 && req.Filter.ProjectID <= 0 && len(req.Filter.Tags) == 0 {
+		errorJSON(c, http.StatusBadRequest, "filter needs ids, project_id or tags")
+		return
+	}
+	if len(req.AddTags) == 0 && len(req.RemoveTags) == 0 &&
+		req.SetLanguage == "" && req.SetDomain == "" && req.MoveToProject <= 0 {
+		errorJSON(c, http.StatusBadRequest, "no operations given")
+		return
+	}
+	if !models.ValidDomain(req.SetDomain) {
+		errorJSON(c, http.StatusBadRequest, fmt.Sprintf("domain must be one of %s", strings.Join(models.Domains, ", ")))
+		return
+	}
+	if req.MoveToProject > 0 {
+		if _, err := s.store.GetProject(c.Request.Context(), req.MoveToProject); err != nil {
+			storeError(c, err)
+			return
+		}
+	}
+	matched, err := s.store.BulkUpdateTestCases(c.Request.Context(),
+		datastore.TestCaseFilter{
+			IDs:       req.Filter.IDs,
+			ProjectID: req.Filter.ProjectID,
+			Tags:      req.Filter.Tags,
+		},
+		datastore.TestCaseUpdate{
+			AddTags:       req.AddTags,
+			RemoveTags:    req.RemoveTags,
+			SetLanguage:   req.SetLanguage,
+			SetDomain:     req.SetDomain,
+			MoveToProject: req.MoveToProject,
+		},
+		req.DryRun)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"matched": matched, "dry_run": req.DryRun})
+}
+
+// bulkDeleteDefaultProtectDays protects cases referenced by jobs created in
+// the last N days from bulk deletion unless overridden.
+const bulkDeleteDefaultProtectDays = 30
+
+// BulkDeleteTestCasesHandler deletes every test case matching a filter.
+// The dry run is mandatory: a destructive call must echo the deletable
+// count from a prior dry run in expected_deleted, and is rejected when the
+// dataset changed in between. Cases referenced by recent jobs or holding
+// results are never deleted; audio objects are released asynchronously via
+// the cleanup outbox.
+func (s *Server) BulkDeleteTestCasesHandler(c *gin.Context) {
+	var req struct {
+		Filter struct {
+			IDs       []int64  `json:"ids"`
+			ProjectID int64    `json:"project_id"`
+			Tags      []string `json:"tags"`
+		} `json:"filter"`
+		DryRun          bool  `json:"dry_run"`
+		ExpectedDeleted int64 `json:"expected_deleted"`
+		ProtectDays     int   `json:"protect_days"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(req.Filter.IDs) == 0 && req.Filter.ProjectID <= 0 && len(req.Filter.Tags) == 0 {
+		errorJSON(c, http.StatusBadRequest, "filter needs ids, project_id or tags")
+		return
+	}
+	protectDays := req.ProtectDays
+	if protectDays <= 0 {
+		protectDays = bulkDeleteDefaultProtectDays
+	}
+	cutoff := time.Now().AddDate(0, 0, -protectDays)
+	filter := datastore.TestCaseFilter{
+		IDs:       req.Filter.IDs,
+		ProjectID: req.Filter.ProjectID,
+		Tags:      req.Filter.Tags,
+	}
+
+	if !req.DryRun {
+		preview, err := s.store.BulkDeleteTestCases(c.Request.Context(), filter, cutoff, true)
+		if err != nil {
+			storeError(c, err)
+			return
+		}
+		if deletable := preview.Matched - preview.Protected; deletable != req.ExpectedDeleted {
+			c.JSON(http.StatusConflict, gin.H{
+				"error":    "expected_deleted does not match the current dry-run count",
+				"expected": req.ExpectedDeleted,
+				"actual":   deletable,
+				"hint":     "re-run with dry_run=true and confirm the new count",
+			})
+			return
+		}
+	}
+	report, err := s.store.BulkDeleteTestCases(c.Request.Context(), filter, cutoff, req.DryRun)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"report": report, "dry_run": req.DryRun})
+}
@@ -0,0 +1,146 @@
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+type testSuiteRequest struct {
+	Name        string  `json:"name" binding:"required"`
+	Description string  `json:"description"`
+	TestCaseIDs []int64 `json:"test_case_ids"`
+}
+
+// CreateTestSuiteHandler creates a named test case grouping for a project.
+func (s *Server) CreateTestSuiteHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req testSuiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), projectID); err != nil {
+		storeError(c, err)
+		return
+	}
+	if msg := s.checkSuiteMembers(c, projectID, req.TestCaseIDs); msg != "" {
+		errorJSON(c, http.StatusBadRequest, msg)
+		return
+	}
+	ts := &models.TestSuite{
+		ProjectID:   projectID,
+		Name:        req.Name,
+		Description: req.Description,
+		TestCaseIDs: req.TestCaseIDs,
+	}
+	if err := s.store.CreateTestSuite(c.Request.Context(), ts); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ts)
+}
+
+// ListTestSuitesHandler lists a project's test suites.
+func (s *Server) ListTestSuitesHandler(c *gin.Context) {
+	projectID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	suites, err := s.store.ListTestSuites(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"test_suites": suites})
+}
+
+// GetTestSuiteHandler fetches one test suite.
+func (s *Server) GetTestSuiteHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	ts, err := s.store.GetTestSuite(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ts)
+}
+
+// UpdateTestSuiteHandler replaces a suite's name, description and members.
+func (s *Server) UpdateTestSuiteHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	var req testSuiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	ts, err := s.store.GetTestSuite(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	if msg := s.checkSuiteMembers(c, ts.ProjectID, req.TestCaseIDs); msg != "" {
+		errorJSON(c, http.StatusBadRequest, msg)
+		return
+	}
+	ts.Name = req.Name
+	ts.Description = req.Description
+	ts.TestCaseIDs = req.TestCaseIDs
+	if err := s.store.UpdateTestSuite(c.Request.Context(), ts); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, ts)
+}
+
+// DeleteTestSuiteHandler removes a test suite.
+func (s *Server) DeleteTestSuiteHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if err := s.store.DeleteTestSuite(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"deleted": id})
+}
+
+// checkSuiteMembers verifies every member test case exists and belongs to
+// the suite's project, so a suite can never silently reference cases a job
+// would then fail to load.
+func (s *Server) checkSuiteMembers(c *gin.Context, projectID int64, ids []int64) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	cases, err := s.store.GetASRTestCases(c.Request.Context(), ids)
+	if err != nil {
+		return err.Error()
+	}
+	found := map[int64]int64{}
+	for _, tc := range cases {
+		found[tc.ID] = tc.ProjectID
+	}
+	for _, id := range ids {
+		owner, ok := found[id]
+		if !ok {
+			return fmt.Sprintf("test case %d does not exist", id)
+		}
+		if owner != projectID {
+			return fmt.Sprintf("test case %d belongs to another project", id)
+		}
+	}
+	return ""
+}
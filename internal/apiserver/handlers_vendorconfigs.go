@@ -0,0 +1,245 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+type vendorConfigRequest struct {
+	ProjectID    int64           `json:"project_id"`
+	Name         string          `json:"name" binding:"required"`
+	AdapterKey   string          `json:"adapter_key"`
+	APIType      string          `json:"api_type"`
+	APIKey       string          `json:"api_key"`
+	APISecret    string          `json:"api_secret"`
+	APIEndpoint  string          `json:"api_endpoint"`
+	OtherConfigs json.RawMessage `json:"other_configs"`
+	IsActive     *bool           `json:"is_active"`
+}
+
+func (r *vendorConfigRequest) validate() string {
+	switch r.APIType {
+	case models.APITypeASR, models.APITypeTTS, models.APITypeLLM:
+	default:
+		return "api_type must be one of ASR, TTS, LLM"
+	}
+	if len(r.OtherConfigs) > 0 && !json.Valid(r.OtherConfigs) {
+		return "other_configs must be valid JSON"
+	}
+	return ""
+}
+
+// CreateVendorConfigHandler registers a new vendor configuration.
+func (s *Server) CreateVendorConfigHandler(c *gin.Context) {
+	var req vendorConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.ProjectID <= 0 {
+		errorJSON(c, http.StatusBadRequest, "project_id is required")
+		return
+	}
+	if msg := req.validate(); msg != "" {
+		errorJSON(c, http.StatusBadRequest, msg)
+		return
+	}
+	vc := &models.VendorConfig{
+		ProjectID:    req.ProjectID,
+		Name:         req.Name,
+		AdapterKey:   req.AdapterKey,
+		APIType:      req.APIType,
+		APIKey:       req.APIKey,
+		APISecret:    req.APISecret,
+		APIEndpoint:  req.APIEndpoint,
+		OtherConfigs: req.OtherConfigs,
+		IsActive:     true,
+	}
+	if req.IsActive != nil {
+		vc.IsActive = *req.IsActive
+	}
+	if violations := vendoradapter.ValidateOtherConfigs(vc); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      "other_configs does not match the provider schema",
+			"violations": violations,
+		})
+		return
+	}
+	if err := s.store.CreateVendorConfig(c.Request.Context(), vc); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, vc)
+}
+
+// UpdateVendorConfigHandler overwrites the mutable fields of a config.
+func (s *Server) UpdateVendorConfigHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	existing, err := s.store.GetVendorConfig(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	var req vendorConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorJSON(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	req.APIType = existing.APIType // api_type is immutable after creation
+	if msg := req.validate(); msg != "" {
+		errorJSON(c, http.StatusBadRequest, msg)
+		return
+	}
+	existing.Name = req.Name
+	existing.AdapterKey = req.AdapterKey
+	existing.APIKey = req.APIKey
+	existing.APISecret = req.APISecret
+	existing.APIEndpoint = req.APIEndpoint
+	existing.OtherConfigs = req.OtherConfigs
+	if req.IsActive != nil {
+		existing.IsActive = *req.IsActive
+	}
+	if violations := vendoradapter.ValidateOtherConfigs(existing); len(violations) > 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      "other_configs does not match the provider schema",
+			"violations": violations,
+		})
+		return
+	}
+	if err := s.store.UpdateVendorConfig(c.Request.Context(), existing); err != nil {
+		storeError(c, err)
+		return
+	}
+	// The engine resolves vendor configs through the cache per call;
+	// invalidating here makes the edit take effect in running jobs.
+	s.cache.Invalidate(cache.VendorConfigKey(id))
+	c.JSON(http.StatusOK, existing)
+}
+
+// CloneVendorConfigHandler copies a vendor config into another project, so
+// a new workspace reuses the endpoint/region/app-id plumbing without
+// retyping it. Secrets are dropped unless ?include_secrets=true; with
+// ?template=true the clone stays in the source project, deactivated, as a
+// starting point for future copies.
+func (s *Server) CloneVendorConfigHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	src, err := s.store.GetVendorConfig(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	template := c.Query("template") == "true"
+	targetProject := queryInt64(c, "target_project", 0)
+	switch {
+	case template:
+		targetProject = src.ProjectID
+	case targetProject <= 0:
+		errorJSON(c, http.StatusBadRequest, "target_project query parameter is required")
+		return
+	}
+	if _, err := s.store.GetProject(c.Request.Context(), targetProject); err != nil {
+		storeError(c, err)
+		return
+	}
+	clone := &models.VendorConfig{
+		ProjectID:    targetProject,
+		Name:         src.Name,
+		AdapterKey:   src.AdapterKey,
+		APIType:      src.APIType,
+		APIEndpoint:  src.APIEndpoint,
+		OtherConfigs: src.OtherConfigs,
+		IsActive:     src.IsActive,
+	}
+	if c.Query("include_secrets") == "true" {
+		clone.APIKey = src.APIKey
+		clone.APISecret = src.APISecret
+	}
+	if name := c.Query("name"); name != "" {
+		clone.Name = name
+	}
+	if template {
+		// Same project: the (project_id, name) unique constraint forces a
+		// distinct name, and templates must never be picked up by jobs.
+		if c.Query("name") == "" {
+			clone.Name = src.Name + " (template)"
+		}
+		clone.IsActive = false
+	}
+	if err := s.store.CreateVendorConfig(c.Request.Context(), clone); err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, clone)
+}
+
+// GetVendorConfigHandler fetches one vendor config.
+func (s *Server) GetVendorConfigHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	vc, err := s.store.GetVendorConfig(c.Request.Context(), id)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, vc)
+}
+
+// ListVendorConfigsHandler lists a project's vendor configs.
+func (s *Server) ListVendorConfigsHandler(c *gin.Context) {
+	projectID := queryInt64(c, "project_id", 0)
+	if projectID <= 0 {
+		errorJSON(c, http.StatusBadRequest, "project_id query parameter is required")
+		return
+	}
+	configs, err := s.store.ListVendorConfigs(c.Request.Context(), projectID)
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"vendor_configs": configs})
+}
+
+// VendorAvailabilityHandler returns a vendor's hourly availability and
+// error-class time series (?hours=24, max 720), so reliability trends are
+// visible independent of accuracy metrics.
+func (s *Server) VendorAvailabilityHandler(c *gin.Context) {
+	id, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	if _, err := s.store.GetVendorConfig(c.Request.Context(), id); err != nil {
+		storeError(c, err)
+		return
+	}
+	hours := queryInt64(c, "hours", 24)
+	if hours < 1 {
+		hours = 1
+	}
+	if hours > 720 {
+		hours = 720
+	}
+	buckets, err := s.store.VendorAvailability(c.Request.Context(), id, int(hours))
+	if err != nil {
+		storeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"vendor_config_id": id,
+		"hours":            hours,
+		"buckets":          buckets,
+	})
+}
@@ -0,0 +1,55 @@
+package apiserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// runnerAgentKey is the gin context key RunnerAuth stores the authenticated
+// agent under.
+const runnerAgentKey = "runnerAgent"
+
+// AdminAuth enforces the simplified admin-access model: every /admin request
+// must carry the pre-configured bearer token.
+func AdminAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		provided, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RunnerAuth authenticates /runner requests by resolving the bearer token to
+// a registered runner agent. Resolving also records the agent's heartbeat.
+func (s *Server) RunnerAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		agent, err := s.store.GetRunnerAgentByToken(c.Request.Context(), token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Set(runnerAgentKey, agent)
+		c.Next()
+	}
+}
+
+// runnerAgent returns the agent RunnerAuth attached to the request context.
+func runnerAgent(c *gin.Context) *models.RunnerAgent {
+	agent, _ := c.MustGet(runnerAgentKey).(*models.RunnerAgent)
+	return agent
+}
@@ -0,0 +1,73 @@
+package apiserver
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+)
+
+// errorJSON writes a uniform error envelope.
+func errorJSON(c *gin.Context, status int, msg string) {
+	c.JSON(status, gin.H{"error": msg})
+}
+
+// storeError maps datastore errors onto HTTP responses.
+func storeError(c *gin.Context, err error) {
+	if errors.Is(err, datastore.ErrNotFound) {
+		errorJSON(c, http.StatusNotFound, "not found")
+		return
+	}
+	errorJSON(c, http.StatusInternalServerError, err.Error())
+}
+
+// pathID parses the named path parameter as an int64 ID. On failure it
+// writes a 400 response and returns ok=false.
+func pathID(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		errorJSON(c, http.StatusBadRequest, "invalid "+name)
+		return 0, false
+	}
+	return id, true
+}
+
+// queryInt64 parses an optional int64 query parameter, returning def when
+// absent or malformed.
+func queryInt64(c *gin.Context, name string, def int64) int64 {
+	v := c.Query(name)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// queryFloat parses an optional float64 query parameter, returning def when
+// absent or malformed.
+func queryFloat(c *gin.Context, name string, def float64) float64 {
+	v := c.Query(name)
+	if v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
+// parseInt64 parses a string as int64, returning 0 on failure.
+func parseInt64(s string) int64 {
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
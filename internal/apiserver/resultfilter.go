@@ -0,0 +1,84 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// resultFilter shapes result rows for transport: dropping the raw vendor
+// blob and/or projecting onto a requested field set. The zero value passes
+// rows through unchanged.
+type resultFilter struct {
+	includeRaw bool
+	fields     map[string]bool // JSON field names; nil means all
+}
+
+// parseResultFilter reads the shared result-shaping query parameters:
+// include_raw (default true) and fields, a comma-separated list of JSON
+// field names to keep.
+func parseResultFilter(c *gin.Context) resultFilter {
+	f := resultFilter{includeRaw: c.DefaultQuery("include_raw", "true") != "false"}
+	if raw := c.Query("fields"); raw != "" {
+		f.fields = make(map[string]bool)
+		for _, name := range strings.Split(raw, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				f.fields[name] = true
+			}
+		}
+		// The id always travels so rows stay addressable.
+		f.fields["id"] = true
+	}
+	return f
+}
+
+// active reports whether the filter changes anything, letting callers skip
+// the per-row re-encoding on the common unfiltered path.
+func (f resultFilter) active() bool {
+	return !f.includeRaw || f.fields != nil
+}
+
+// apply returns the transport shape of one result under the filter.
+func (f resultFilter) apply(r *models.ASRResult) any {
+	if !f.active() {
+		return r
+	}
+	if !f.includeRaw {
+		clone := *r
+		clone.RawVendorResponse = nil
+		r = &clone
+	}
+	if f.fields == nil {
+		return r
+	}
+	// Project through JSON so the field names match the wire format.
+	encoded, err := json.Marshal(r)
+	if err != nil {
+		return r
+	}
+	row := map[string]json.RawMessage{}
+	if err := json.Unmarshal(encoded, &row); err != nil {
+		return r
+	}
+	for name := range row {
+		if !f.fields[name] {
+			delete(row, name)
+		}
+	}
+	return row
+}
+
+// applyAll shapes a result slice, preserving order.
+func (f resultFilter) applyAll(results []*models.ASRResult) any {
+	if !f.active() {
+		return results
+	}
+	out := make([]any, len(results))
+	for i, r := range results {
+		out[i] = f.apply(r)
+	}
+	return out
+}
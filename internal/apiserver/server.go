@@ -0,0 +1,162 @@
+// Package apiserver exposes the platform's HTTP API. It is a thin layer over
+// the datastore and job management service: request parsing, auth and
+// response shaping live here, business rules live below.
+package apiserver
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/Jcateye/AITestPlatform/internal/alignment"
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/config"
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/jobmanagement"
+	"github.com/Jcateye/AITestPlatform/internal/objectstore"
+	"github.com/Jcateye/AITestPlatform/internal/transcode"
+)
+
+// Server bundles the dependencies shared by all handlers.
+type Server struct {
+	cfg     *config.Config
+	store   *datastore.Store
+	objects *objectstore.Client
+	content *objectstore.ContentStore
+	jobs    *jobmanagement.Service
+	cache   *cache.Cache
+	aligner *alignment.Client // nil when no aligner is configured
+
+	transcoder *transcode.Client // nil when no transcoder is configured
+}
+
+// New builds a Server.
+func New(cfg *config.Config, store *datastore.Store, objects *objectstore.Client, content *objectstore.ContentStore, jobs *jobmanagement.Service, c *cache.Cache) *Server {
+	s := &Server{cfg: cfg, store: store, objects: objects, content: content, jobs: jobs, cache: c}
+	if cfg.AlignerURL != "" {
+		s.aligner = alignment.NewClient(cfg.AlignerURL)
+	}
+	if cfg.TranscoderURL != "" {
+		s.transcoder = transcode.NewClient(cfg.TranscoderURL)
+	}
+	return s
+}
+
+// Router builds the Gin engine with all routes registered.
+func (s *Server) Router() *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Logger(), gin.Recovery())
+
+	r.GET("/healthz", func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "ok"})
+	})
+
+	admin := r.Group("/admin", AdminAuth(s.cfg.AdminToken), s.MaintenanceGuard())
+
+	admin.PUT("/feature-flags", s.UpsertFeatureFlagHandler)
+	admin.GET("/feature-flags", s.ListFeatureFlagsHandler)
+	admin.DELETE("/feature-flags/:id", s.DeleteFeatureFlagHandler)
+
+	admin.POST("/projects", s.CreateProjectHandler)
+	admin.GET("/projects", s.ListProjectsHandler)
+	admin.GET("/projects/:id", s.GetProjectHandler)
+	admin.GET("/projects/:id/budgets", s.ListBudgetsHandler)
+	admin.PUT("/projects/:id/budgets", s.UpsertBudgetHandler)
+	admin.GET("/projects/:id/spend", s.ProjectSpendHandler)
+	admin.GET("/projects/:id/notifications", s.ListNotificationsHandler)
+	admin.GET("/projects/:id/activity", s.ProjectActivityHandler)
+	admin.GET("/projects/:id/storage", s.ProjectStorageHandler)
+	admin.PUT("/projects/:id/storage-quota", s.SetStorageQuotaHandler)
+	admin.GET("/projects/:id/storage/suggestions", s.StorageSuggestionsHandler)
+	admin.PUT("/projects/:id/time-zone", s.SetProjectTimeZoneHandler)
+	admin.POST("/projects/:id/splits", s.AssignSplitsHandler)
+	admin.GET("/projects/:id/splits", s.ListSplitsHandler)
+	admin.POST("/projects/:id/dataset-snapshots", s.FreezeDatasetSnapshotHandler)
+	admin.GET("/projects/:id/dataset-snapshots", s.ListDatasetSnapshotsHandler)
+	admin.POST("/projects/:id/export", s.CreateDatasetExportHandler)
+	admin.GET("/dataset-snapshots/:id", s.GetDatasetSnapshotHandler)
+	admin.POST("/projects/:id/test-suites", s.CreateTestSuiteHandler)
+	admin.GET("/projects/:id/test-suites", s.ListTestSuitesHandler)
+	admin.GET("/test-suites/:id", s.GetTestSuiteHandler)
+	admin.PUT("/test-suites/:id", s.UpdateTestSuiteHandler)
+	admin.DELETE("/test-suites/:id", s.DeleteTestSuiteHandler)
+	admin.POST("/projects/:id/alert-rules", s.CreateAlertRuleHandler)
+	admin.GET("/projects/:id/alert-rules", s.ListAlertRulesHandler)
+	admin.DELETE("/alert-rules/:id", s.DeleteAlertRuleHandler)
+	admin.POST("/projects/:id/score-formulas", s.CreateScoreFormulaHandler)
+	admin.GET("/projects/:id/score-formulas", s.ListScoreFormulasHandler)
+	admin.DELETE("/score-formulas/:id", s.DeleteScoreFormulaHandler)
+	admin.POST("/projects/:id/schedules", s.CreateScheduleHandler)
+	admin.GET("/projects/:id/schedules", s.ListSchedulesHandler)
+	admin.DELETE("/schedules/:id", s.DeleteScheduleHandler)
+	admin.DELETE("/budgets/:id", s.DeleteBudgetHandler)
+	admin.POST("/notifications/:id/read", s.MarkNotificationReadHandler)
+
+	admin.POST("/vendors", s.CreateVendorConfigHandler)
+	admin.GET("/vendors", s.ListVendorConfigsHandler)
+	admin.GET("/vendors/:id", s.GetVendorConfigHandler)
+	admin.PUT("/vendors/:id", s.UpdateVendorConfigHandler)
+	admin.POST("/vendors/:id/clone", s.CloneVendorConfigHandler)
+	admin.GET("/vendors/:id/availability", s.VendorAvailabilityHandler)
+
+	admin.POST("/asr-test-cases", s.CreateASRTestCaseHandler)
+	admin.POST("/asr-test-cases/generate", s.GenerateASRTestCasesHandler)
+	admin.POST("/asr-test-cases/import", s.BulkImportTestCasesHandler)
+	admin.POST("/asr-test-cases/import-kaldi", s.ImportKaldiTestCasesHandler)
+	admin.GET("/asr-test-cases", s.ListASRTestCasesHandler)
+	admin.PATCH("/asr-test-cases/bulk", s.BulkEditTestCasesHandler)
+	admin.POST("/asr-test-cases/bulk-delete", s.BulkDeleteTestCasesHandler)
+	admin.GET("/asr-test-cases/:id", s.GetASRTestCaseHandler)
+	admin.DELETE("/asr-test-cases/:id", s.DeleteASRTestCaseHandler)
+	admin.PUT("/asr-test-cases/:id/speaker", s.SetTestCaseSpeakerHandler)
+	admin.GET("/asr-test-cases/:id/audio", s.TestCaseAudioHandler)
+	admin.POST("/asr-test-cases/:id/align", s.AlignTestCaseHandler)
+	admin.GET("/asr-test-cases/:id/snippet", s.TestCaseSnippetHandler)
+	admin.GET("/asr-test-cases/:id/results", s.TestCaseResultsHandler)
+	admin.GET("/asr-test-cases/:id/gt-revisions", s.ListGroundTruthRevisionsHandler)
+
+	admin.POST("/objects/presign", s.PresignObjectHandler)
+
+	admin.GET("/backup", s.BackupHandler)
+	admin.POST("/restore", s.RestoreHandler)
+
+	admin.POST("/jobs", s.CreateJobHandler)
+	admin.GET("/jobs", s.ListJobsHandler)
+	admin.GET("/jobs/:id", s.GetJobHandler)
+	admin.GET("/jobs/:id/results", s.ListJobResultsHandler)
+	admin.GET("/jobs/:id/results/stream", s.StreamJobResultsHandler)
+	admin.GET("/jobs/:id/report", s.JobReportHandler)
+	admin.GET("/jobs/:id/manifest", s.JobManifestHandler)
+	admin.GET("/jobs/:id/logs", s.JobLogsHandler)
+	// Not under /jobs/: a literal segment there would clash with the
+	// /jobs/:id wildcard in gin's route tree.
+	admin.POST("/recover-stuck-jobs", s.RecoverStuckJobsHandler)
+	admin.GET("/results/:id", s.GetResultHandler)
+	admin.POST("/results/:id/promote", s.PromoteHypothesisHandler)
+	admin.POST("/graphql", s.GraphQLHandler)
+	admin.POST("/jobs/:id/export", s.CreateJobExportHandler)
+	admin.GET("/exports/:id", s.GetExportHandler)
+	admin.POST("/jobs/:id/snapshots", s.CreateJobSnapshotHandler)
+	admin.GET("/jobs/:id/snapshots", s.ListJobSnapshotsHandler)
+	admin.GET("/snapshots/:id", s.GetSnapshotHandler)
+	admin.GET("/jobs/:id/confidence-simulation", s.ConfidenceSimulationHandler)
+	admin.POST("/jobs/:id/qa-samples", s.CreateQASamplesHandler)
+	admin.GET("/jobs/:id/qa-samples", s.ListQASamplesHandler)
+	admin.GET("/jobs/:id/qa-report", s.QAReportHandler)
+	admin.POST("/qa-reviews/:id", s.CompleteQAReviewHandler)
+	admin.GET("/jobs/:id/summary", s.JobSummaryHandler)
+	admin.GET("/jobs/:id/fairness", s.FairnessReportHandler)
+	admin.GET("/jobs/:id/leaderboard", s.JobLeaderboardHandler)
+	admin.GET("/jobs/:id/worst-segments", s.WorstSegmentsHandler)
+
+	me := r.Group("/me", AdminAuth(s.cfg.AdminToken))
+	me.GET("/preferences", s.GetPreferencesHandler)
+	me.PUT("/preferences", s.PutPreferencesHandler)
+
+	admin.POST("/runner-agents", s.RegisterRunnerAgentHandler)
+	admin.GET("/runner-agents", s.ListRunnerAgentsHandler)
+
+	runner := r.Group("/runner", s.RunnerAuth())
+	runner.POST("/lease", s.LeaseRunnerTasksHandler)
+	runner.POST("/tasks/:id/result", s.PushRunnerResultHandler)
+
+	return r
+}
@@ -0,0 +1,46 @@
+// Package audioinfo extracts basic metadata from uploaded audio without
+// external tooling. Only the WAV container is parsed natively; other formats
+// report zero and callers treat the value as unknown.
+package audioinfo
+
+import "encoding/binary"
+
+// DurationMS returns the playback duration of the audio in milliseconds, or
+// 0 when the format is unsupported or the header is malformed. format is the
+// lowercase file extension, matching ASRTestCase.AudioFormat.
+func DurationMS(format string, data []byte) int64 {
+	if format != "wav" {
+		return 0
+	}
+	return wavDurationMS(data)
+}
+
+// wavDurationMS walks the RIFF chunk list for the fmt byte rate and the data
+// chunk size; duration is their quotient. Malformed files yield 0 rather
+// than an error — duration is advisory metadata, never a gate.
+func wavDurationMS(data []byte) int64 {
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return 0
+	}
+	var byteRate, dataLen uint32
+	for off := 12; off+8 <= len(data); {
+		id := string(data[off : off+4])
+		size := binary.LittleEndian.Uint32(data[off+4 : off+8])
+		body := off + 8
+		switch id {
+		case "fmt ":
+			if body+12 > len(data) {
+				return 0
+			}
+			byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+		case "data":
+			dataLen = size
+		}
+		// Chunks are word-aligned; odd sizes carry a pad byte.
+		off = body + int(size) + int(size%2)
+	}
+	if byteRate == 0 || dataLen == 0 {
+		return 0
+	}
+	return int64(dataLen) * 1000 / int64(byteRate)
+}
@@ -0,0 +1,117 @@
+// Package backup exports and restores the platform's metadata as a single
+// JSON archive: projects, vendor configs, test cases, budgets, jobs and
+// results, plus a manifest of the MinIO objects they reference. Operators
+// previously had to stitch pg_dump and bucket listings together by hand.
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/objectstore"
+)
+
+// ArchiveVersion is bumped whenever the archive layout changes
+// incompatibly; Restore refuses archives from a newer version.
+const ArchiveVersion = 1
+
+// Archive is the complete backup payload.
+type Archive struct {
+	Version       int                        `json:"version"`
+	CreatedAt     time.Time                  `json:"created_at"`
+	Projects      []*models.Project          `json:"projects"`
+	VendorConfigs []*models.VendorConfig     `json:"vendor_configs"`
+	ASRTestCases  []*models.ASRTestCase      `json:"asr_test_cases"`
+	Budgets       []*models.Budget           `json:"budgets"`
+	Jobs          []*models.EvaluationJob    `json:"jobs"`
+	Results       []*models.ASRResult        `json:"results"`
+	Objects       []*datastore.StorageObject `json:"objects"`
+
+	// ObjectCopyPrefix is set when the backup also copied object bytes
+	// into the bucket under this prefix.
+	ObjectCopyPrefix string `json:"object_copy_prefix,omitempty"`
+}
+
+// Service performs backups and restores against the live stores.
+type Service struct {
+	store   *datastore.Store
+	objects *objectstore.Client
+}
+
+// NewService builds a backup Service.
+func NewService(store *datastore.Store, objects *objectstore.Client) *Service {
+	return &Service{store: store, objects: objects}
+}
+
+// Export assembles a full archive. When copyObjects is true, every tracked
+// object's bytes are additionally copied under a timestamped backup/ prefix
+// in the bucket, so the archive survives later deletions.
+func (s *Service) Export(ctx context.Context, copyObjects bool) (*Archive, error) {
+	a := &Archive{Version: ArchiveVersion, CreatedAt: time.Now().UTC()}
+
+	projects, err := s.store.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.Projects = projects
+
+	for _, p := range projects {
+		vcs, err := s.store.ListVendorConfigs(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		a.VendorConfigs = append(a.VendorConfigs, vcs...)
+
+		tcs, err := s.store.ListASRTestCases(ctx, p.ID, "")
+		if err != nil {
+			return nil, err
+		}
+		a.ASRTestCases = append(a.ASRTestCases, tcs...)
+
+		budgets, err := s.store.ListBudgets(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		a.Budgets = append(a.Budgets, budgets...)
+
+		jobs, err := s.store.ListEvaluationJobs(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+		a.Jobs = append(a.Jobs, jobs...)
+		for _, job := range jobs {
+			results, err := s.store.ListASRResultsByJob(ctx, job.ID)
+			if err != nil {
+				return nil, err
+			}
+			a.Results = append(a.Results, results...)
+		}
+	}
+
+	objects, err := s.store.ListStorageObjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a.Objects = objects
+
+	if copyObjects {
+		prefix := "backup/" + a.CreatedAt.Format("20060102-150405")
+		for _, o := range objects {
+			data, err := s.objects.Get(ctx, o.ObjectKey)
+			if err != nil {
+				return nil, fmt.Errorf("copy object %q: %w", o.ObjectKey, err)
+			}
+			dst := prefix + "/" + o.ObjectKey
+			if err := s.objects.Put(ctx, dst, bytes.NewReader(data),
+				int64(len(data)), "application/octet-stream"); err != nil {
+				return nil, fmt.Errorf("copy object %q: %w", o.ObjectKey, err)
+			}
+		}
+		a.ObjectCopyPrefix = prefix
+	}
+	return a, nil
+}
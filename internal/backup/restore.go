@@ -0,0 +1,151 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// RestoreReport summarizes what a restore created.
+type RestoreReport struct {
+	Projects      int `json:"projects"`
+	VendorConfigs int `json:"vendor_configs"`
+	ASRTestCases  int `json:"asr_test_cases"`
+	Budgets       int `json:"budgets"`
+	Jobs          int `json:"jobs"`
+	Results       int `json:"results"`
+}
+
+// Restore loads an archive into the database. It is intended for an empty
+// target: rows are created fresh and receive new IDs, with references
+// between entities remapped. Object bytes are not moved; the archive's
+// object keys are content-addressed and expected to exist in the bucket (or
+// be copied back from the archive's ObjectCopyPrefix beforehand).
+func (s *Service) Restore(ctx context.Context, a *Archive) (*RestoreReport, error) {
+	if a.Version > ArchiveVersion {
+		return nil, fmt.Errorf("archive version %d is newer than supported version %d",
+			a.Version, ArchiveVersion)
+	}
+	report := &RestoreReport{}
+
+	projectIDs := map[int64]int64{}
+	for _, p := range a.Projects {
+		np := &models.Project{Name: p.Name, Description: p.Description}
+		if err := s.store.CreateProject(ctx, np); err != nil {
+			return report, fmt.Errorf("restore project %q: %w", p.Name, err)
+		}
+		projectIDs[p.ID] = np.ID
+		report.Projects++
+	}
+
+	vendorIDs := map[int64]int64{}
+	for _, vc := range a.VendorConfigs {
+		nvc := &models.VendorConfig{
+			ProjectID:    projectIDs[vc.ProjectID],
+			Name:         vc.Name,
+			AdapterKey:   vc.AdapterKey,
+			APIType:      vc.APIType,
+			APIKey:       vc.APIKey,
+			APISecret:    vc.APISecret,
+			APIEndpoint:  vc.APIEndpoint,
+			OtherConfigs: vc.OtherConfigs,
+			IsActive:     vc.IsActive,
+		}
+		if err := s.store.CreateVendorConfig(ctx, nvc); err != nil {
+			return report, fmt.Errorf("restore vendor config %q: %w", vc.Name, err)
+		}
+		vendorIDs[vc.ID] = nvc.ID
+		report.VendorConfigs++
+	}
+
+	testCaseIDs := map[int64]int64{}
+	for _, tc := range a.ASRTestCases {
+		ntc := &models.ASRTestCase{
+			ProjectID:      projectIDs[tc.ProjectID],
+			Name:           tc.Name,
+			Language:       tc.Language,
+			AudioObjectKey: tc.AudioObjectKey,
+			AudioFormat:    tc.AudioFormat,
+			GroundTruth:    tc.GroundTruth,
+			Tags:           tc.Tags,
+		}
+		if err := s.store.CreateASRTestCase(ctx, ntc); err != nil {
+			return report, fmt.Errorf("restore test case %q: %w", tc.Name, err)
+		}
+		testCaseIDs[tc.ID] = ntc.ID
+		report.ASRTestCases++
+	}
+
+	// Restore object tracking rows verbatim so reference counts match the
+	// restored test cases.
+	for _, o := range a.Objects {
+		if err := s.store.UpsertStorageObject(ctx, o); err != nil {
+			return report, err
+		}
+	}
+
+	for _, b := range a.Budgets {
+		nb := &models.Budget{
+			ProjectID:       projectIDs[b.ProjectID],
+			MonthlyLimitUSD: b.MonthlyLimitUSD,
+		}
+		if b.VendorConfigID != nil {
+			id := vendorIDs[*b.VendorConfigID]
+			nb.VendorConfigID = &id
+		}
+		if err := s.store.UpsertBudget(ctx, nb); err != nil {
+			return report, fmt.Errorf("restore budget: %w", err)
+		}
+		report.Budgets++
+	}
+
+	jobIDs := map[int64]int64{}
+	for _, job := range a.Jobs {
+		njob := &models.EvaluationJob{
+			ProjectID:       projectIDs[job.ProjectID],
+			Name:            job.Name,
+			APIType:         job.APIType,
+			Language:        job.Language,
+			TestCaseIDs:     remapIDs(job.TestCaseIDs, testCaseIDs),
+			VendorConfigIDs: remapIDs(job.VendorConfigIDs, vendorIDs),
+			Parameters:      job.Parameters,
+		}
+		if err := s.store.CreateEvaluationJob(ctx, njob); err != nil {
+			return report, fmt.Errorf("restore job %q: %w", job.Name, err)
+		}
+		if err := s.store.SetJobStatus(ctx, njob.ID, job.Status, job.ErrorMessage); err != nil {
+			return report, fmt.Errorf("restore job %q status: %w", job.Name, err)
+		}
+		jobIDs[job.ID] = njob.ID
+		report.Jobs++
+	}
+
+	for _, r := range a.Results {
+		nr := &models.ASRResult{
+			JobID:             jobIDs[r.JobID],
+			ASRTestCaseID:     testCaseIDs[r.ASRTestCaseID],
+			VendorConfigID:    vendorIDs[r.VendorConfigID],
+			RecognizedText:    r.RecognizedText,
+			WER:               r.WER,
+			CER:               r.CER,
+			LatencyMS:         r.LatencyMS,
+			RawVendorResponse: r.RawVendorResponse,
+			ErrorCode:         r.ErrorCode,
+			ErrorMessage:      r.ErrorMessage,
+		}
+		if err := s.store.InsertASRResult(ctx, nr); err != nil {
+			return report, fmt.Errorf("restore result: %w", err)
+		}
+		report.Results++
+	}
+	return report, nil
+}
+
+func remapIDs(ids []int64, mapping map[int64]int64) []int64 {
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, mapping[id])
+	}
+	return out
+}
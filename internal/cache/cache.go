@@ -0,0 +1,84 @@
+// Package cache provides a small in-process TTL cache used to keep
+// dashboard queries (summaries, leaderboards, trends) from recomputing on
+// every request. Keys are namespaced strings such as "job-summary:42" so
+// writers can invalidate whole families by prefix. A Redis-backed
+// implementation can replace this behind the same interface if the platform
+// ever runs multiple API replicas.
+package cache
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	value   any
+	expires time.Time
+}
+
+// Cache is a concurrency-safe map with per-entry expiry.
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// New builds an empty cache.
+func New() *Cache {
+	return &Cache{entries: make(map[string]entry)}
+}
+
+// Get returns the cached value for key if present and unexpired.
+func (c *Cache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set stores value under key for ttl.
+func (c *Cache) Set(key string, value any, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry{value: value, expires: time.Now().Add(ttl)}
+}
+
+// Invalidate removes one key.
+func (c *Cache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+// JobSummaryKey names the cached per-vendor summary of a job. Defined here
+// so producers (the engine, which invalidates) and consumers (the API, which
+// populates) agree on the key.
+func JobSummaryKey(jobID int64) string {
+	return "job-summary:" + strconv.FormatInt(jobID, 10)
+}
+
+// VendorConfigKey names the cached resolved vendor config. The engine reads
+// through it per call; the API invalidates it on update so config changes
+// take effect mid-job.
+func VendorConfigKey(id int64) string {
+	return "vendor-config:" + strconv.FormatInt(id, 10)
+}
+
+// InvalidatePrefix removes every key starting with prefix.
+func (c *Cache) InvalidatePrefix(prefix string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if strings.HasPrefix(k, prefix) {
+			delete(c.entries, k)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Package config loads platform configuration from the environment.
+//
+// The platform follows the simplified-admin model described in the design
+// document: a single pre-configured administrator token guards the API, and
+// all other settings (database, object storage, engine tuning) come from
+// environment variables so deployments stay twelve-factor friendly.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Config holds every runtime setting the server needs. Fields map 1:1 to
+// environment variables; see Load for the variable names and defaults.
+type Config struct {
+	// ListenAddr is the host:port the HTTP API binds to.
+	ListenAddr string
+
+	// DatabaseURL is the PostgreSQL connection string (lib/pq format).
+	DatabaseURL string
+
+	// DatabaseReplicaURLs are optional read replica connection strings.
+	// Heavy result and summary reads are spread across them.
+	DatabaseReplicaURLs []string
+
+	// AdminToken is the bearer token required on all /admin routes.
+	AdminToken string
+
+	// MinIO object storage settings.
+	MinioEndpoint  string
+	MinioAccessKey string
+	MinioSecretKey string
+	MinioBucket    string
+	MinioUseSSL    bool
+
+	// EngineWorkers is the number of concurrent evaluation jobs the
+	// in-process dispatcher will run.
+	EngineWorkers int
+
+	// BudgetEnforcement controls whether monthly spend budgets block job
+	// submission. When false budgets are still tracked but only warn.
+	BudgetEnforcement bool
+
+	// CompressRawResponses stores raw_vendor_response blobs gzipped. Reads
+	// transparently decompress, so toggling is safe at any time.
+	CompressRawResponses bool
+
+	// AlignerURL is the base URL of an optional forced-alignment service.
+	// When empty, alignment endpoints respond 503.
+	AlignerURL string
+
+	// TranscoderURL is the base URL of an optional audio transcoding
+	// service, used for snippet extraction from non-WAV audio.
+	TranscoderURL string
+
+	// PublicURL is the externally reachable base URL of this API, handed to
+	// remote runner workers so they can lease tasks and push results.
+	PublicURL string
+
+	// Kubernetes execution backend settings. The backend is enabled when
+	// KubeWorkerImage is set; KubeAPIURL empty means in-cluster.
+	KubeAPIURL      string
+	KubeNamespace   string
+	KubeWorkerImage string
+
+	// HTTP transport tuning for vendor adapters: idle connection pooling
+	// and the per-call timeout. All adapters share one transport.
+	HTTPMaxIdleConns        int
+	HTTPMaxIdleConnsPerHost int
+	HTTPTimeoutSeconds      int
+
+	// BlockPrivateEgress refuses vendor calls whose endpoints resolve to
+	// private, loopback or link-local addresses (SSRF guard). Individual
+	// vendor configs opt out with allow_private_egress.
+	BlockPrivateEgress bool
+
+	// HTTPProxyURL routes all vendor calls through an outbound proxy;
+	// HTTPCACertFile adds a PEM CA bundle for TLS-intercepting networks.
+	// Individual vendor configs can override both via OtherConfigs.
+	HTTPProxyURL   string
+	HTTPCACertFile string
+
+	// AllowUnknownAdapters resolves vendor configs with an unrecognized
+	// adapter key to the mock adapter instead of failing their calls.
+	// Intended for demos; in real deployments a typo'd key should surface
+	// as errors, not simulated transcripts.
+	AllowUnknownAdapters bool
+
+	// VaultAddr and VaultToken enable resolving vault:// secret references
+	// in vendor configs against a HashiCorp Vault server. Empty VaultAddr
+	// leaves the vault scheme unregistered.
+	VaultAddr  string
+	VaultToken string
+
+	// RecordFixturesDir records sanitized vendor HTTP exchanges into this
+	// directory as replayable fixtures. Development use only; leave empty
+	// in production.
+	RecordFixturesDir string
+}
+
+// Load reads the configuration from the environment. Missing mandatory
+// values (database URL, admin token) are reported as errors rather than
+// defaulted so misconfigured deployments fail fast.
+func Load() (*Config, error) {
+	cfg := &Config{
+		ListenAddr:              envOr("AITP_LISTEN_ADDR", ":8080"),
+		DatabaseURL:             os.Getenv("AITP_DATABASE_URL"),
+		DatabaseReplicaURLs:     envList("AITP_DATABASE_REPLICA_URLS"),
+		AdminToken:              os.Getenv("AITP_ADMIN_TOKEN"),
+		MinioEndpoint:           envOr("AITP_MINIO_ENDPOINT", "localhost:9000"),
+		MinioAccessKey:          os.Getenv("AITP_MINIO_ACCESS_KEY"),
+		MinioSecretKey:          os.Getenv("AITP_MINIO_SECRET_KEY"),
+		MinioBucket:             envOr("AITP_MINIO_BUCKET", "aitestplatform"),
+		MinioUseSSL:             envBool("AITP_MINIO_USE_SSL", false),
+		EngineWorkers:           envInt("AITP_ENGINE_WORKERS", 2),
+		BudgetEnforcement:       envBool("AITP_BUDGET_ENFORCEMENT", true),
+		CompressRawResponses:    envBool("AITP_COMPRESS_RAW_RESPONSES", false),
+		AlignerURL:              os.Getenv("AITP_ALIGNER_URL"),
+		TranscoderURL:           os.Getenv("AITP_TRANSCODER_URL"),
+		PublicURL:               os.Getenv("AITP_PUBLIC_URL"),
+		KubeAPIURL:              os.Getenv("AITP_KUBE_API_URL"),
+		KubeNamespace:           envOr("AITP_KUBE_NAMESPACE", "default"),
+		KubeWorkerImage:         os.Getenv("AITP_KUBE_WORKER_IMAGE"),
+		HTTPMaxIdleConns:        envInt("AITP_HTTP_MAX_IDLE_CONNS", 100),
+		HTTPMaxIdleConnsPerHost: envInt("AITP_HTTP_MAX_IDLE_CONNS_PER_HOST", 16),
+		HTTPTimeoutSeconds:      envInt("AITP_HTTP_TIMEOUT_S", 60),
+		BlockPrivateEgress:      envBool("AITP_BLOCK_PRIVATE_EGRESS", true),
+		HTTPProxyURL:            os.Getenv("AITP_HTTP_PROXY_URL"),
+		HTTPCACertFile:          os.Getenv("AITP_HTTP_CA_FILE"),
+		AllowUnknownAdapters:    envBool("AITP_ALLOW_UNKNOWN_ADAPTERS", false),
+		VaultAddr:               os.Getenv("AITP_VAULT_ADDR"),
+		VaultToken:              os.Getenv("AITP_VAULT_TOKEN"),
+		RecordFixturesDir:       os.Getenv("AITP_RECORD_FIXTURES_DIR"),
+	}
+	if cfg.DatabaseURL == "" {
+		return nil, fmt.Errorf("AITP_DATABASE_URL is required")
+	}
+	if cfg.AdminToken == "" {
+		return nil, fmt.Errorf("AITP_ADMIN_TOKEN is required")
+	}
+	return cfg, nil
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func envList(key string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(v, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func envBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return def
+	}
+	return b
+}
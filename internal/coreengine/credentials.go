@@ -0,0 +1,82 @@
+package coreengine
+
+import (
+	"log"
+	"sync"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// credentialPools rotates calls across a vendor config's credential pool so
+// large benchmarks spread load over several API keys instead of exhausting
+// one key's QPS. Rotation state is in-memory and per engine instance:
+// credentials that hit quota errors are disabled until the process restarts,
+// which matches how long vendor quota windows typically outlive a job.
+type credentialPools struct {
+	mu    sync.Mutex
+	state map[int64]*credentialState // by vendor config id
+}
+
+type credentialState struct {
+	next     int
+	calls    []int64
+	disabled []bool
+}
+
+func newCredentialPools() *credentialPools {
+	return &credentialPools{state: make(map[int64]*credentialState)}
+}
+
+// pick returns the vendor config to call with: a clone carrying the next
+// enabled pool credential, plus the credential index for error feedback.
+// Configs without a pool (or with every credential disabled) are returned
+// unchanged with index -1.
+func (p *credentialPools) pick(cfg *models.VendorConfig) (*models.VendorConfig, int) {
+	pool := vendoradapter.CredentialPool(cfg)
+	if len(pool) == 0 {
+		return cfg, -1
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.state[cfg.ID]
+	if st == nil || len(st.disabled) != len(pool) {
+		st = &credentialState{calls: make([]int64, len(pool)), disabled: make([]bool, len(pool))}
+		p.state[cfg.ID] = st
+	}
+	for range pool {
+		idx := st.next
+		st.next = (st.next + 1) % len(pool)
+		if st.disabled[idx] {
+			continue
+		}
+		st.calls[idx]++
+		clone := *cfg
+		clone.APIKey = pool[idx].APIKey
+		if pool[idx].APISecret != "" {
+			clone.APISecret = pool[idx].APISecret
+		}
+		return &clone, idx
+	}
+	return cfg, -1
+}
+
+// noteError disables a pool credential after a quota or auth failure so the
+// remaining rotation skips it. Other error codes leave the pool untouched.
+func (p *credentialPools) noteError(cfg *models.VendorConfig, idx int, errorCode string) {
+	if idx < 0 {
+		return
+	}
+	if errorCode != vendoradapter.ErrCodeRateLimited && errorCode != vendoradapter.ErrCodeAuthFailed {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.state[cfg.ID]
+	if st == nil || idx >= len(st.disabled) || st.disabled[idx] {
+		return
+	}
+	st.disabled[idx] = true
+	log.Printf("vendor %d: credential %d disabled after %s (%d calls)",
+		cfg.ID, idx, errorCode, st.calls[idx])
+}
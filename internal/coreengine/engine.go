@@ -0,0 +1,579 @@
+// Package coreengine executes evaluation jobs: it fans test cases out to
+// vendor adapters, scores the responses and persists results. It is the only
+// component allowed to call vendor APIs during a job.
+package coreengine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/kubedispatch"
+	"github.com/Jcateye/AITestPlatform/internal/metrics"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/pricing"
+	"github.com/Jcateye/AITestPlatform/internal/subtitle"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// ObjectStore is the subset of the object store the engine needs. It is an
+// interface so tests can substitute an in-memory implementation.
+type ObjectStore interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+}
+
+// Engine runs evaluation jobs against the datastore and object store.
+type Engine struct {
+	store   *datastore.Store
+	objects ObjectStore
+	cache   *cache.Cache
+	creds   *credentialPools
+	limits  *rateLimiters
+	kube    *kubedispatch.Client // nil unless the Kubernetes backend is configured
+}
+
+// New builds an Engine. The cache is invalidated as results land so cached
+// summaries never lag behind a running job.
+func New(store *datastore.Store, objects ObjectStore, c *cache.Cache) *Engine {
+	return &Engine{store: store, objects: objects, cache: c, creds: newCredentialPools(), limits: newRateLimiters()}
+}
+
+// RunASREvaluation executes one ASR job to completion. Each test case ×
+// vendor pair produces exactly one result row; vendor call failures are
+// stored as errored results and do not abort the job.
+func (e *Engine) RunASREvaluation(ctx context.Context, jobID int64) error {
+	job, err := e.store.GetEvaluationJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("load job %d: %w", jobID, err)
+	}
+	if err := e.store.MarkJobRunning(ctx, jobID); err != nil {
+		return fmt.Errorf("mark job %d running: %w", jobID, err)
+	}
+	stopHeartbeat := e.startHeartbeat(ctx, jobID)
+	defer stopHeartbeat()
+
+	testCases, err := e.store.GetASRTestCases(ctx, job.TestCaseIDs)
+	if err != nil {
+		return e.failJob(ctx, jobID, fmt.Errorf("load test cases: %w", err))
+	}
+	// Jobs pinned to a dataset snapshot score against the ground truths
+	// frozen at snapshot time, so transcript corrections made since don't
+	// shift historical benchmarks.
+	if job.DatasetSnapshotID != nil {
+		snap, err := e.store.GetDatasetSnapshot(ctx, *job.DatasetSnapshotID)
+		if err != nil {
+			return e.failJob(ctx, jobID, fmt.Errorf("load dataset snapshot: %w", err))
+		}
+		frozen := make(map[int64]string, len(snap.Cases))
+		for _, sc := range snap.Cases {
+			frozen[sc.TestCaseID] = sc.GroundTruth
+		}
+		for _, tc := range testCases {
+			if gt, ok := frozen[tc.ID]; ok {
+				tc.GroundTruth = gt
+			}
+		}
+	}
+	vendorConfigs, err := e.store.GetVendorConfigs(ctx, job.VendorConfigIDs)
+	if err != nil {
+		return e.failJob(ctx, jobID, fmt.Errorf("load vendor configs: %w", err))
+	}
+
+	params := map[string]any{}
+	if len(job.Parameters) > 0 {
+		if err := json.Unmarshal(job.Parameters, &params); err != nil {
+			return e.failJob(ctx, jobID, fmt.Errorf("parse job parameters: %w", err))
+		}
+	}
+
+	testCases, plan, err := sampleTestCases(testCases, params)
+	if err != nil {
+		return e.failJob(ctx, jobID, fmt.Errorf("sample test cases: %w", err))
+	}
+	report := map[string]any{}
+	if plan != nil {
+		report["sampling"] = plan
+		// Record the plan up front so the seed survives even if the job
+		// fails or hands off to another backend below.
+		if err := e.store.SetJobReport(ctx, jobID, report); err != nil {
+			e.logJob(ctx, jobID, models.LogLevelWarn, "store sampling plan: %v", err)
+		}
+	}
+
+	// The manifest is written after sampling so it pins the cases that were
+	// actually scored, and before the mode dispatch so every kind of run
+	// gets one.
+	e.recordManifest(ctx, job, testCases, vendorConfigs, params)
+
+	if sourceJobID := replaySourceJobID(params); sourceJobID > 0 {
+		return e.runReplay(ctx, job, testCases, vendorConfigs, sourceJobID)
+	}
+	if isLoadTest(params) {
+		return e.runLoadTest(ctx, job, testCases, vendorConfigs, params)
+	}
+	if isExperiment(params) {
+		return e.runExperiment(ctx, job, testCases, vendorConfigs, params)
+	}
+	if isSweep(params) {
+		return e.runSweep(ctx, job, testCases, vendorConfigs, params)
+	}
+	if isKubernetes(params) {
+		return e.runKubernetes(ctx, job, testCases, vendorConfigs, params)
+	}
+
+	// Configs assigned to a runner pool are executed by remote agents; the
+	// rest run in-process below.
+	var localConfigs, remoteConfigs []*models.VendorConfig
+	for _, vc := range vendorConfigs {
+		if vendoradapter.RunnerPool(vc) != "" {
+			remoteConfigs = append(remoteConfigs, vc)
+		} else {
+			localConfigs = append(localConfigs, vc)
+		}
+	}
+
+	// Pairs that already have a result row are skipped, so a job requeued
+	// by crash recovery resumes where it stopped instead of re-billing
+	// every vendor call. On a fresh job the set is simply empty.
+	done, err := e.store.CompletedPairs(ctx, jobID)
+	if err != nil {
+		return e.failJob(ctx, jobID, fmt.Errorf("load completed pairs: %w", err))
+	}
+	if len(done) > 0 {
+		e.logJob(ctx, jobID, models.LogLevelInfo, "resuming: %d pairs already have results", len(done))
+		report["resumed_pairs"] = len(done)
+	}
+
+	// Record the pair total up front so the job detail endpoint can render
+	// a progress bar; done and failed counts are derived from result rows.
+	totalPairs := int64(len(remoteConfigs) * len(testCases))
+	for _, vc := range localConfigs {
+		totalPairs += int64(len(regionVariants(vc, params)) * len(testCases))
+	}
+	if err := e.store.SetJobProgressTotal(ctx, jobID, totalPairs); err != nil {
+		e.logJob(ctx, jobID, models.LogLevelWarn, "store progress total: %v", err)
+	}
+
+	month := time.Now().UTC().Format("2006-01")
+	warmupCalls := intParam(params, "warmup_calls", 0)
+
+	// One entry per (config, region) variant. Warm-up runs serially per
+	// variant before any pairs are dispatched, so warm-up calls never race
+	// the measured ones.
+	type localVariant struct {
+		variant      vendorVariant
+		firstLatency int64
+	}
+	var variants []*localVariant
+	for _, vc := range localConfigs {
+		for _, variant := range regionVariants(vc, params) {
+			lv := &localVariant{variant: variant, firstLatency: -1}
+			if warmupCalls > 0 && len(testCases) > 0 {
+				adapter := vendoradapter.GetASRAdapter(variant.cfg)
+				lv.firstLatency = e.warmUp(ctx, job, adapter, variant, testCases[0], params, warmupCalls, month)
+			}
+			variants = append(variants, lv)
+		}
+	}
+
+	type pairTask struct {
+		lv *localVariant
+		tc *models.ASRTestCase
+	}
+	var tasks []pairTask
+	for _, lv := range variants {
+		vc := lv.variant.cfg
+		// Match the region label runPair will store, which falls back to
+		// the host tag for self-hosted configs.
+		region := lv.variant.region
+		if region == "" {
+			region = vendoradapter.HostTag(vc)
+		}
+		for _, tc := range testCases {
+			if done[datastore.CompletedPair{
+				ASRTestCaseID:  tc.ID,
+				VendorConfigID: vc.ID,
+				Region:         region,
+			}] {
+				continue
+			}
+			tasks = append(tasks, pairTask{lv: lv, tc: tc})
+		}
+	}
+
+	// Pairs run on a bounded worker pool. The job parameter "concurrency"
+	// sets the pool size (default 1 keeps the historical serial order);
+	// each vendor config can additionally cap its own in-flight calls via
+	// the OtherConfigs key "max_concurrency" so one aggressive job cannot
+	// blow through a vendor's connection limits.
+	concurrency := intParam(params, "concurrency", 1)
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > len(tasks) && len(tasks) > 0 {
+		concurrency = len(tasks)
+	}
+	vendorSlots := map[int64]chan struct{}{}
+	for _, lv := range variants {
+		vc := lv.variant.cfg
+		if _, ok := vendorSlots[vc.ID]; ok {
+			continue
+		}
+		if max := vendoradapter.MaxConcurrency(vc); max > 0 {
+			vendorSlots[vc.ID] = make(chan struct{}, max)
+		}
+	}
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		queue = make(chan pairTask)
+	)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				if ctx.Err() != nil {
+					continue // drain the queue; the job fails below
+				}
+				vc := task.lv.variant.cfg
+				slots := vendorSlots[vc.ID]
+				if slots != nil {
+					slots <- struct{}{}
+				}
+				// Re-resolve the config per pair so credential or
+				// OtherConfigs edits apply to in-flight jobs.
+				fresh := e.refreshVariant(ctx, task.lv.variant)
+				adapter := vendoradapter.GetASRAdapter(fresh.cfg)
+				latency := e.runPair(ctx, job, adapter, fresh, task.tc, params, month)
+				if slots != nil {
+					<-slots
+				}
+				mu.Lock()
+				if task.lv.firstLatency < 0 {
+					task.lv.firstLatency = latency
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return e.failJob(ctx, jobID, fmt.Errorf("job interrupted: %w", err))
+	}
+
+	var coldStarts []ColdStartEntry
+	for _, lv := range variants {
+		if lv.firstLatency >= 0 {
+			coldStarts = append(coldStarts, ColdStartEntry{
+				VendorConfigID:     lv.variant.cfg.ID,
+				Region:             lv.variant.region,
+				FirstCallLatencyMS: lv.firstLatency,
+			})
+		}
+	}
+
+	if concurrency > 1 {
+		report["concurrency"] = concurrency
+	}
+	if len(coldStarts) > 0 {
+		report["warmup_calls"] = warmupCalls
+		report["cold_start"] = coldStarts
+	}
+	if len(report) > 0 {
+		if err := e.store.SetJobReport(ctx, jobID, report); err != nil {
+			e.logJob(ctx, jobID, models.LogLevelWarn, "store job report: %v", err)
+		}
+	}
+
+	// Enqueue remote pairs after the local loop so agents cannot race the
+	// job to completion while local results are still being written.
+	remoteTasks := 0
+	for _, vc := range remoteConfigs {
+		pool := vendoradapter.RunnerPool(vc)
+		for _, tc := range testCases {
+			// Agent results carry no region label, so the resume key for a
+			// remote pair matches on an empty Region.
+			if done[datastore.CompletedPair{ASRTestCaseID: tc.ID, VendorConfigID: vc.ID}] {
+				continue
+			}
+			task := &models.RunnerTask{
+				JobID:          job.ID,
+				ASRTestCaseID:  tc.ID,
+				VendorConfigID: vc.ID,
+				Pool:           pool,
+			}
+			if err := e.store.CreateRunnerTask(ctx, task); err != nil {
+				return e.failJob(ctx, jobID, fmt.Errorf("enqueue runner task: %w", err))
+			}
+			remoteTasks++
+		}
+	}
+	if remoteTasks > 0 {
+		// The job stays RUNNING; the result-push handler marks it completed
+		// once the last runner task is done.
+		e.logJob(ctx, jobID, models.LogLevelInfo, "%d runner tasks enqueued, awaiting agents", remoteTasks)
+		return nil
+	}
+
+	if err := e.store.MarkJobCompleted(ctx, jobID); err != nil {
+		return fmt.Errorf("mark job %d completed: %w", jobID, err)
+	}
+	e.logJob(ctx, jobID, models.LogLevelInfo, "completed: %d cases x %d vendors", len(testCases), len(vendorConfigs))
+	return nil
+}
+
+// vendorVariant is one concrete target to call: the vendor config, possibly
+// re-pointed at a regional endpoint.
+type vendorVariant struct {
+	cfg    *models.VendorConfig
+	region string // empty outside region-benchmarking mode
+}
+
+// regionVariants expands a vendor config for region benchmarking. With the
+// benchmark_regions job parameter set and regional endpoints declared on the
+// config, the same audio is fanned to every region; otherwise the config runs
+// once, unchanged.
+func regionVariants(vc *models.VendorConfig, params map[string]any) []vendorVariant {
+	if benchmark, _ := params["benchmark_regions"].(bool); !benchmark {
+		return []vendorVariant{{cfg: vc}}
+	}
+	endpoints := vendoradapter.RegionalEndpoints(vc)
+	if len(endpoints) == 0 {
+		return []vendorVariant{{cfg: vc}}
+	}
+	regions := make([]string, 0, len(endpoints))
+	for region := range endpoints {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	variants := make([]vendorVariant, 0, len(regions))
+	for _, region := range regions {
+		clone := *vc
+		clone.APIEndpoint = endpoints[region]
+		variants = append(variants, vendorVariant{cfg: &clone, region: region})
+	}
+	return variants
+}
+
+// runPair evaluates one test case against one vendor and stores the result.
+// It returns the vendor call's wall-clock latency in milliseconds, or -1 when
+// no vendor call was made.
+func (e *Engine) runPair(ctx context.Context, job *models.EvaluationJob,
+	adapter vendoradapter.ASRAdapter, variant vendorVariant,
+	tc *models.ASRTestCase, params map[string]any, month string) int64 {
+
+	vc := variant.cfg
+	region := variant.region
+	if region == "" {
+		// Self-hosted configs label their hardware instead of a region.
+		region = vendoradapter.HostTag(vc)
+	}
+	result := &models.ASRResult{
+		JobID:          job.ID,
+		ASRTestCaseID:  tc.ID,
+		VendorConfigID: vc.ID,
+		Region:         region,
+	}
+
+	// Configs with a credential pool rotate keys per call; quota failures
+	// feed back below so exhausted keys drop out of the rotation.
+	callCfg, credIdx := e.creds.pick(vc)
+	if credIdx >= 0 {
+		adapter = vendoradapter.GetASRAdapter(callCfg)
+	}
+
+	// Pre-flight egress check: a custom endpoint outside the vendor's
+	// allowlist is refused before any audio leaves the platform.
+	if egressErr := vendoradapter.CheckEgress(callCfg); egressErr != nil {
+		e.logJob(ctx, job.ID, models.LogLevelError, "vendor %d egress violation: %s", vc.ID, egressErr.Message)
+		result.ErrorCode = egressErr.Code
+		result.ErrorMessage = egressErr.Message
+		e.storeResult(ctx, result)
+		return -1
+	}
+
+	audio, err := e.objects.Get(ctx, tc.AudioObjectKey)
+	if err != nil {
+		result.ErrorCode = "AUDIO_UNAVAILABLE"
+		result.ErrorMessage = err.Error()
+		e.storeResult(ctx, result)
+		return -1
+	}
+	result.AudioBytes = int64(len(audio))
+
+	// Pairs over the config's declared payload limits are skipped up front
+	// instead of paying for a call the vendor would reject anyway.
+	if maxBytes, maxDurationMS := vendoradapter.SizeLimits(callCfg); (maxBytes > 0 && result.AudioBytes > maxBytes) ||
+		(maxDurationMS > 0 && tc.DurationMS > maxDurationMS) {
+		result.ErrorCode = vendoradapter.ErrCodeUnsupportedSize
+		result.ErrorMessage = fmt.Sprintf("audio is %d bytes / %d ms, over the vendor's configured payload limit",
+			result.AudioBytes, tc.DurationMS)
+		e.storeResult(ctx, result)
+		return -1
+	}
+
+	req := &vendoradapter.ASRRequest{
+		Audio:       audio,
+		Format:      tc.AudioFormat,
+		Language:    tc.Language,
+		Params:      params,
+		GroundTruth: tc.GroundTruth,
+	}
+	// Honor the config's max_qps cap before the clock starts, so throttle
+	// waits never inflate the measured latency.
+	e.limits.wait(ctx, vc)
+
+	start := time.Now()
+	resp, err := adapter.Recognize(ctx, req)
+	result.LatencyMS = time.Since(start).Milliseconds()
+
+	if err != nil {
+		var adapterErr *vendoradapter.AdapterError
+		if errors.As(err, &adapterErr) {
+			result.ErrorCode = adapterErr.Code
+			result.ErrorMessage = adapterErr.Message
+		} else {
+			result.ErrorCode = vendoradapter.ErrCodeVendorError
+			result.ErrorMessage = err.Error()
+		}
+		e.creds.noteError(vc, credIdx, result.ErrorCode)
+		e.storeResult(ctx, result)
+		return result.LatencyMS
+	}
+
+	result.RecognizedText = resp.Transcript
+	result.RawVendorResponse = resp.RawResponse
+	result.ResponseBytes = int64(len(resp.RawResponse))
+	result.VendorLatencyMS = resp.VendorProcessingMS
+	result.Confidence = resp.Confidence
+	ScoreTranscript(result, tc, vc, params, resp.Transcript)
+
+	// Captioning jobs additionally build subtitles from the vendor's word
+	// timestamps and score them against the reference cues.
+	if subEval, _ := params["subtitle_eval"].(bool); subEval &&
+		len(resp.Words) > 0 && len(tc.Segments) > 0 {
+		cues := subtitle.FromWords(resp.Words, subtitle.DefaultCueOptions())
+		q := subtitle.Evaluate(tc.Segments, cues)
+		addExtraMetric(result, "subtitle_timing_drift_ms", q.MeanTimingDriftMS)
+		addExtraMetric(result, "subtitle_segmentation_f1", q.SegmentationF1)
+		addExtraMetric(result, "subtitle_reading_speed_violations", float64(q.ReadingSpeedViolations))
+	}
+	// Jobs with a configured LLM judge add a semantic-correctness verdict
+	// alongside the edit-distance metrics.
+	e.judgeTranscript(ctx, job, tc, result, params)
+	e.storeResult(ctx, result)
+
+	// Successful vendor calls cost money; keep the spend ledger current so
+	// budget checks reflect in-flight jobs.
+	if cost := pricing.EstimatePerCall(vc); cost > 0 {
+		err := e.store.RecordSpend(ctx, &models.SpendRecord{
+			ProjectID:      job.ProjectID,
+			VendorConfigID: vc.ID,
+			JobID:          job.ID,
+			AmountUSD:      cost,
+			Month:          month,
+		})
+		if err != nil {
+			e.logJob(ctx, job.ID, models.LogLevelWarn, "record spend for vendor %d: %v", vc.ID, err)
+		}
+	}
+	return result.LatencyMS
+}
+
+// ScoreSegments computes per-segment WER for segmented test cases: the
+// hypothesis is partitioned across the ground-truth segments by a global
+// alignment, and each segment keeps its audio offsets so reviewers can jump
+// straight to the worst spans. Unsegmented cases return nil.
+func ScoreSegments(tc *models.ASRTestCase, transcript string) []models.SegmentScore {
+	if len(tc.Segments) == 0 {
+		return nil
+	}
+	refs := make([]string, len(tc.Segments))
+	for i, seg := range tc.Segments {
+		refs[i] = seg.Text
+	}
+	aligned := metrics.AlignSegments(refs, transcript)
+	scores := make([]models.SegmentScore, len(aligned))
+	for i, a := range aligned {
+		scores[i] = models.SegmentScore{
+			Index:      i,
+			StartMS:    tc.Segments[i].StartMS,
+			EndMS:      tc.Segments[i].EndMS,
+			Reference:  tc.Segments[i].Text,
+			Hypothesis: a.Hypothesis,
+			WER:        a.Detail.Rate,
+		}
+	}
+	return scores
+}
+
+func (e *Engine) storeResult(ctx context.Context, r *models.ASRResult) {
+	if err := e.store.InsertASRResult(ctx, r); err != nil {
+		e.logJob(ctx, r.JobID, models.LogLevelError, "store result case=%d vendor=%d: %v",
+			r.ASRTestCaseID, r.VendorConfigID, err)
+		return
+	}
+	e.cache.Invalidate(cache.JobSummaryKey(r.JobID))
+}
+
+// heartbeatInterval is how often a running worker stamps the job's
+// last_heartbeat_at. It must stay well under the recovery threshold so a
+// live-but-slow job is never mistaken for an abandoned one.
+const heartbeatInterval = 15 * time.Second
+
+// startHeartbeat stamps the job's heartbeat on a ticker until the returned
+// stop function is called or ctx is cancelled. Operators use the heartbeat
+// to tell a slow job from a dead worker, and recovery uses its age to decide
+// when to reassign a job.
+func (e *Engine) startHeartbeat(ctx context.Context, jobID int64) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := e.store.TouchJobHeartbeat(ctx, jobID); err != nil {
+					log.Printf("job %d: heartbeat: %v", jobID, err)
+				}
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+func (e *Engine) failJob(ctx context.Context, jobID int64, cause error) error {
+	e.logJob(ctx, jobID, models.LogLevelError, "failed: %v", cause)
+	if err := e.store.MarkJobFailed(ctx, jobID, cause.Error()); err != nil {
+		log.Printf("mark job %d failed: %v", jobID, err)
+	}
+	return cause
+}
+
+// logJob writes one execution log line both to stdout and to the job_logs
+// table, so progress and warnings stay inspectable through the API after the
+// fact. Persisting the line is best-effort and never fails the job.
+func (e *Engine) logJob(ctx context.Context, jobID int64, level, format string, args ...any) {
+	msg := fmt.Sprintf(format, args...)
+	log.Printf("job %d: %s", jobID, msg)
+	if err := e.store.AppendJobLog(ctx, jobID, level, msg); err != nil {
+		log.Printf("job %d: append log: %v", jobID, err)
+	}
+}
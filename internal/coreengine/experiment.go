@@ -0,0 +1,225 @@
+package coreengine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// Experiment arm labels, stored in the result's Region column so the two
+// runs of each pair stay distinguishable.
+const (
+	experimentArmA = "arm-a"
+	experimentArmB = "arm-b"
+)
+
+// isExperiment reports whether the job is an A/B experiment: two parameter
+// sets ("a" and "b") run against the same test cases and vendors.
+func isExperiment(params map[string]any) bool {
+	_, _, ok := experimentArms(params)
+	return ok
+}
+
+// experimentArms extracts the per-arm parameter overrides from the
+// experiment job parameter.
+func experimentArms(params map[string]any) (a, b map[string]any, ok bool) {
+	exp, _ := params["experiment"].(map[string]any)
+	if exp == nil {
+		return nil, nil, false
+	}
+	a, _ = exp["a"].(map[string]any)
+	b, _ = exp["b"].(map[string]any)
+	if a == nil || b == nil {
+		return nil, nil, false
+	}
+	return a, b, true
+}
+
+// ArmSummary aggregates one experiment arm across all pairs.
+type ArmSummary struct {
+	MeanWER       float64 `json:"mean_wer"`
+	MeanCER       float64 `json:"mean_cer"`
+	MeanLatencyMS float64 `json:"mean_latency_ms"`
+	Errors        int     `json:"errors"`
+}
+
+// PairedComparison is the paired A-vs-B delta over test cases both arms
+// scored successfully. The significance test is a paired t-test on the
+// per-case WER deltas; PValue is a two-sided normal approximation.
+type PairedComparison struct {
+	N            int     `json:"n"`
+	MeanDeltaWER float64 `json:"mean_delta_wer"`
+	TStat        float64 `json:"t_stat"`
+	PValue       float64 `json:"p_value"`
+	Significant  bool    `json:"significant"`
+}
+
+// ExperimentReport is stored as the job's report for A/B experiment jobs.
+type ExperimentReport struct {
+	ArmA   ArmSummary       `json:"arm_a"`
+	ArmB   ArmSummary       `json:"arm_b"`
+	Paired PairedComparison `json:"paired"`
+}
+
+// runExperiment executes an A/B experiment: every test case runs twice per
+// vendor, once with each arm's parameter overrides merged over the base job
+// parameters, and the report compares the arms pairwise.
+func (e *Engine) runExperiment(ctx context.Context, job *models.EvaluationJob,
+	testCases []*models.ASRTestCase, vendorConfigs []*models.VendorConfig,
+	params map[string]any) error {
+
+	armA, armB, _ := experimentArms(params)
+	arms := []struct {
+		label     string
+		overrides map[string]any
+	}{
+		{experimentArmA, armA},
+		{experimentArmB, armB},
+	}
+
+	month := time.Now().UTC().Format("2006-01")
+	for _, vc := range vendorConfigs {
+		for _, arm := range arms {
+			armParams := mergeParams(params, arm.overrides)
+			variant := vendorVariant{cfg: vc, region: arm.label}
+			for _, tc := range testCases {
+				if err := ctx.Err(); err != nil {
+					return e.failJob(ctx, job.ID, fmt.Errorf("job interrupted: %w", err))
+				}
+				fresh := e.refreshVariant(ctx, variant)
+				adapter := vendoradapter.GetASRAdapter(fresh.cfg)
+				e.runPair(ctx, job, adapter, fresh, tc, armParams, month)
+			}
+		}
+	}
+
+	report, err := e.buildExperimentReport(ctx, job.ID)
+	if err != nil {
+		return e.failJob(ctx, job.ID, fmt.Errorf("build experiment report: %w", err))
+	}
+	if err := e.store.SetJobReport(ctx, job.ID, map[string]any{"experiment": report}); err != nil {
+		log.Printf("job %d: store experiment report: %v", job.ID, err)
+	}
+
+	if err := e.store.MarkJobCompleted(ctx, job.ID); err != nil {
+		return fmt.Errorf("mark job %d completed: %w", job.ID, err)
+	}
+	log.Printf("job %d completed (experiment): %d cases x %d vendors x 2 arms",
+		job.ID, len(testCases), len(vendorConfigs))
+	return nil
+}
+
+// mergeParams overlays a sub-run's overrides on the base job parameters.
+// Control blocks (experiment, sweep) are dropped so adapters never see them.
+func mergeParams(base, overrides map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overrides))
+	for k, v := range base {
+		if k == "experiment" || k == "sweep" {
+			continue
+		}
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
+
+// buildExperimentReport reads the job's stored results back and computes the
+// per-arm summaries and the paired comparison.
+func (e *Engine) buildExperimentReport(ctx context.Context, jobID int64) (*ExperimentReport, error) {
+	results, err := e.store.ListASRResultsByJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Key paired lookups by test case × vendor so multi-vendor experiments
+	// still pair correctly.
+	type pairKey struct {
+		testCaseID     int64
+		vendorConfigID int64
+	}
+	byArm := map[string]map[pairKey]*models.ASRResult{
+		experimentArmA: {},
+		experimentArmB: {},
+	}
+	report := &ExperimentReport{}
+	for _, r := range results {
+		arm, ok := byArm[r.Region]
+		if !ok {
+			continue
+		}
+		summary := &report.ArmA
+		if r.Region == experimentArmB {
+			summary = &report.ArmB
+		}
+		if r.ErrorCode != "" {
+			summary.Errors++
+			continue
+		}
+		arm[pairKey{r.ASRTestCaseID, r.VendorConfigID}] = r
+		summary.MeanWER += r.WER
+		summary.MeanCER += r.CER
+		summary.MeanLatencyMS += float64(r.LatencyMS)
+	}
+	for _, summary := range []*ArmSummary{&report.ArmA, &report.ArmB} {
+		n := 0
+		if summary == &report.ArmA {
+			n = len(byArm[experimentArmA])
+		} else {
+			n = len(byArm[experimentArmB])
+		}
+		if n > 0 {
+			summary.MeanWER /= float64(n)
+			summary.MeanCER /= float64(n)
+			summary.MeanLatencyMS /= float64(n)
+		}
+	}
+
+	var deltas []float64
+	for key, ra := range byArm[experimentArmA] {
+		if rb, ok := byArm[experimentArmB][key]; ok {
+			deltas = append(deltas, rb.WER-ra.WER)
+		}
+	}
+	report.Paired = pairedTTest(deltas)
+	return report, nil
+}
+
+// pairedTTest runs a paired t-test on the per-case deltas, using the normal
+// approximation for the two-sided p-value. Fewer than two pairs, or zero
+// variance, yields no significance claim.
+func pairedTTest(deltas []float64) PairedComparison {
+	cmp := PairedComparison{N: len(deltas)}
+	if len(deltas) < 2 {
+		return cmp
+	}
+	var sum float64
+	for _, d := range deltas {
+		sum += d
+	}
+	mean := sum / float64(len(deltas))
+	var sumSq float64
+	for _, d := range deltas {
+		sumSq += (d - mean) * (d - mean)
+	}
+	variance := sumSq / float64(len(deltas)-1)
+	cmp.MeanDeltaWER = mean
+	if variance == 0 {
+		return cmp
+	}
+	cmp.TStat = mean / math.Sqrt(variance/float64(len(deltas)))
+	cmp.PValue = 2 * (1 - normalCDF(math.Abs(cmp.TStat)))
+	cmp.Significant = cmp.PValue < 0.05
+	return cmp
+}
+
+// normalCDF is the standard normal cumulative distribution function.
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
@@ -0,0 +1,142 @@
+package coreengine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// LLM-as-judge scoring: jobs whose parameters carry llm_judge_vendor_id (an
+// APITypeLLM vendor config) additionally send each (ground truth, hypothesis)
+// pair to that LLM and store its semantic-correctness verdict as the
+// semantic_correctness extra metric. It complements WER/CER when strict edit
+// distance over-penalizes valid paraphrases or formatting differences.
+
+// judgePrompt instructs the LLM to answer with a bare number so the reply
+// parses without structured-output support on the judge side.
+const judgePrompt = "You grade speech recognition output. Given a reference " +
+	"transcript and a hypothesis, reply with only a number between 0 and 1: " +
+	"1 when the hypothesis preserves the reference's full meaning (wording, " +
+	"formatting and punctuation differences do not matter), 0 when the " +
+	"meaning is lost, intermediate values for partial preservation."
+
+// judgeTranscript scores one successful result with the job's configured LLM
+// judge, if any. Judge failures degrade to a log line rather than failing
+// the pair: the primary metrics are already on the result.
+func (e *Engine) judgeTranscript(ctx context.Context, job *models.EvaluationJob,
+	tc *models.ASRTestCase, result *models.ASRResult, params map[string]any) {
+
+	vendorID := int64(intParam(params, "llm_judge_vendor_id", 0))
+	if vendorID <= 0 || result.ErrorCode != "" {
+		return
+	}
+	vc, err := e.store.GetVendorConfig(ctx, vendorID)
+	if err != nil {
+		e.logJob(ctx, job.ID, models.LogLevelWarn, "llm judge config %d: %v", vendorID, err)
+		return
+	}
+	if vc.APIType != models.APITypeLLM {
+		e.logJob(ctx, job.ID, models.LogLevelWarn, "llm judge config %d is %s, not %s", vendorID, vc.APIType, models.APITypeLLM)
+		return
+	}
+	if egressErr := vendoradapter.CheckEgress(vc); egressErr != nil {
+		e.logJob(ctx, job.ID, models.LogLevelWarn, "llm judge egress violation: %s", egressErr.Message)
+		return
+	}
+	hypothesis := result.RecognizedText
+	if result.PostProcessedText != "" {
+		hypothesis = result.PostProcessedText
+	}
+	score, err := judgeScore(ctx, vc, tc.GroundTruth, hypothesis)
+	if err != nil {
+		e.logJob(ctx, job.ID, models.LogLevelWarn, "llm judge case %d: %v", tc.ID, err)
+		return
+	}
+	addExtraMetric(result, "semantic_correctness", score)
+}
+
+// judgeScore sends one pair to the judge's OpenAI-compatible chat completion
+// endpoint and parses the numeric verdict. The judge model comes from the
+// config's OtherConfigs key "judge_model".
+func judgeScore(ctx context.Context, vc *models.VendorConfig, groundTruth, hypothesis string) (float64, error) {
+	var other struct {
+		Model string `json:"judge_model"`
+	}
+	if len(vc.OtherConfigs) > 0 {
+		_ = json.Unmarshal(vc.OtherConfigs, &other)
+	}
+	body, err := json.Marshal(map[string]any{
+		"model":       other.Model,
+		"temperature": 0,
+		"messages": []map[string]string{
+			{"role": "system", "content": judgePrompt},
+			{"role": "user", "content": fmt.Sprintf("Reference: %s\nHypothesis: %s", groundTruth, hypothesis)},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, vc.APIEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+vc.APIKey)
+	// The judge endpoint is user-editable config, so the call goes through
+	// the vendor transport: CheckEgress above only vets scheme and
+	// allowlist, the dial-time guard in the client vets the resolved IPs.
+	resp, err := vendoradapter.HTTPClientFor(vc).Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("judge returned HTTP %d", resp.StatusCode)
+	}
+	var reply struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(raw, &reply); err != nil {
+		return 0, fmt.Errorf("decode judge reply: %w", err)
+	}
+	if len(reply.Choices) == 0 {
+		return 0, fmt.Errorf("judge reply has no choices")
+	}
+	return parseJudgeVerdict(reply.Choices[0].Message.Content)
+}
+
+// parseJudgeVerdict extracts the first number from the judge's reply and
+// clamps it to [0,1], tolerating judges that wrap the score in prose.
+func parseJudgeVerdict(content string) (float64, error) {
+	for _, field := range strings.Fields(content) {
+		field = strings.Trim(field, ".,:;()[]")
+		score, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			continue
+		}
+		if score < 0 {
+			score = 0
+		}
+		if score > 1 {
+			score = 1
+		}
+		return score, nil
+	}
+	return 0, fmt.Errorf("no numeric verdict in judge reply %q", content)
+}
@@ -0,0 +1,93 @@
+package coreengine
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+
+	"github.com/Jcateye/AITestPlatform/internal/kubedispatch"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// isKubernetes reports whether the job requested the Kubernetes execution
+// backend via the execution_backend parameter.
+func isKubernetes(params map[string]any) bool {
+	backend, _ := params["execution_backend"].(string)
+	return backend == "kubernetes"
+}
+
+// SetKubeDispatcher enables the Kubernetes execution backend. Without it,
+// jobs requesting the backend fail with a clear error.
+func (e *Engine) SetKubeDispatcher(k *kubedispatch.Client) {
+	e.kube = k
+}
+
+// runKubernetes shards a job across Kubernetes worker pods. Every test case
+// × vendor pair becomes a runner task in a job-private pool; one worker Job
+// is launched per shard_size tasks (default 100, max_workers capped at 20).
+// Workers lease from the pool and push results through the runner API, so
+// the last pushed result completes the job — the engine does not wait.
+func (e *Engine) runKubernetes(ctx context.Context, job *models.EvaluationJob,
+	testCases []*models.ASRTestCase, vendorConfigs []*models.VendorConfig,
+	params map[string]any) error {
+
+	if e.kube == nil {
+		return e.failJob(ctx, job.ID, fmt.Errorf("kubernetes execution backend is not configured"))
+	}
+	if len(testCases) == 0 || len(vendorConfigs) == 0 {
+		return e.failJob(ctx, job.ID, fmt.Errorf("kubernetes job needs test cases and vendor configs"))
+	}
+
+	pool := fmt.Sprintf("k8s-job-%d", job.ID)
+	total := 0
+	for _, vc := range vendorConfigs {
+		for _, tc := range testCases {
+			task := &models.RunnerTask{
+				JobID:          job.ID,
+				ASRTestCaseID:  tc.ID,
+				VendorConfigID: vc.ID,
+				Pool:           pool,
+			}
+			if err := e.store.CreateRunnerTask(ctx, task); err != nil {
+				return e.failJob(ctx, job.ID, fmt.Errorf("enqueue runner task: %w", err))
+			}
+			total++
+		}
+	}
+
+	shardSize := intParam(params, "shard_size", 100)
+	if shardSize <= 0 {
+		shardSize = 100
+	}
+	workers := (total + shardSize - 1) / shardSize
+	if max := intParam(params, "max_workers", 20); workers > max {
+		workers = max
+	}
+	for i := 0; i < workers; i++ {
+		name := fmt.Sprintf("%s-worker-%d", pool, i)
+		token, err := newRunnerToken()
+		if err != nil {
+			return e.failJob(ctx, job.ID, fmt.Errorf("generate worker token: %w", err))
+		}
+		agent := &models.RunnerAgent{Name: name, Pool: pool, Token: token}
+		if err := e.store.CreateRunnerAgent(ctx, agent); err != nil {
+			return e.failJob(ctx, job.ID, fmt.Errorf("register worker agent: %w", err))
+		}
+		if err := e.kube.CreateWorkerJob(ctx, name, token, pool); err != nil {
+			return e.failJob(ctx, job.ID, err)
+		}
+	}
+	log.Printf("job %d: %d runner tasks dispatched to %d kubernetes workers", job.ID, total, workers)
+	return nil
+}
+
+// newRunnerToken generates a per-worker bearer token for the runner API.
+func newRunnerToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
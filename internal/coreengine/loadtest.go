@@ -0,0 +1,222 @@
+package coreengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/pricing"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// Load-test job parameters (all under the job's Parameters blob):
+//
+//	mode             "load_test" selects this path
+//	load_concurrency parallel callers (default 4)
+//	load_qps         target calls per second across all callers; 0 = unthrottled
+//	load_duration_s  how long to sustain the load (default 60)
+//
+// Unlike the accuracy path, per-call results are not persisted; the job gets
+// one aggregate report with latency percentiles and error rates under load.
+
+func isLoadTest(params map[string]any) bool {
+	mode, _ := params["mode"].(string)
+	return mode == "load_test"
+}
+
+// LoadTestReport is the aggregate outcome of a load-test job, stored as the
+// job's report.
+type LoadTestReport struct {
+	VendorConfigID int64          `json:"vendor_config_id"`
+	Concurrency    int            `json:"concurrency"`
+	TargetQPS      float64        `json:"target_qps,omitempty"`
+	DurationS      float64        `json:"duration_s"`
+	TotalCalls     int            `json:"total_calls"`
+	ErrorCount     int            `json:"error_count"`
+	ErrorRate      float64        `json:"error_rate"`
+	AchievedQPS    float64        `json:"achieved_qps"`
+	LatencyP50MS   int64          `json:"latency_p50_ms"`
+	LatencyP90MS   int64          `json:"latency_p90_ms"`
+	LatencyP99MS   int64          `json:"latency_p99_ms"`
+	LatencyMaxMS   int64          `json:"latency_max_ms"`
+	ErrorCodes     map[string]int `json:"error_codes,omitempty"`
+}
+
+// runLoadTest hammers one vendor with the job's dataset at the configured
+// concurrency and QPS, then stores an aggregate report on the job. It reuses
+// the normal adapters and datasets but skips accuracy scoring entirely.
+func (e *Engine) runLoadTest(ctx context.Context, job *models.EvaluationJob,
+	testCases []*models.ASRTestCase, vendorConfigs []*models.VendorConfig,
+	params map[string]any) error {
+
+	if len(vendorConfigs) != 1 {
+		return e.failJob(ctx, job.ID, fmt.Errorf("load_test mode requires exactly one vendor config, got %d", len(vendorConfigs)))
+	}
+	vc := vendorConfigs[0]
+	adapter := vendoradapter.GetASRAdapter(vc)
+
+	// Preload every audio clip so object-store reads don't pollute the
+	// latency measurements.
+	type loadCase struct {
+		tc    *models.ASRTestCase
+		audio []byte
+	}
+	cases := make([]loadCase, 0, len(testCases))
+	for _, tc := range testCases {
+		audio, err := e.objects.Get(ctx, tc.AudioObjectKey)
+		if err != nil {
+			return e.failJob(ctx, job.ID, fmt.Errorf("preload audio for case %d: %w", tc.ID, err))
+		}
+		cases = append(cases, loadCase{tc: tc, audio: audio})
+	}
+	if len(cases) == 0 {
+		return e.failJob(ctx, job.ID, fmt.Errorf("load_test mode needs at least one test case"))
+	}
+
+	concurrency := intParam(params, "load_concurrency", 4)
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	qps := floatParam(params, "load_qps", 0)
+	durationS := floatParam(params, "load_duration_s", 60)
+	deadline := time.Now().Add(time.Duration(durationS * float64(time.Second)))
+
+	// Pace callers through a shared ticket channel when a QPS target is set.
+	var tickets <-chan time.Time
+	if qps > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / qps))
+		defer ticker.Stop()
+		tickets = ticker.C
+	}
+
+	var (
+		mu         sync.Mutex
+		latencies  []int64
+		errorCodes = map[string]int{}
+		errCount   int
+	)
+	started := time.Now()
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for call := worker; ; call += concurrency {
+				if time.Now().After(deadline) || ctx.Err() != nil {
+					return
+				}
+				if tickets != nil {
+					select {
+					case <-tickets:
+					case <-ctx.Done():
+						return
+					}
+				}
+				lc := cases[call%len(cases)]
+				req := &vendoradapter.ASRRequest{
+					Audio:    lc.audio,
+					Format:   lc.tc.AudioFormat,
+					Language: lc.tc.Language,
+					Params:   params,
+				}
+				start := time.Now()
+				_, err := adapter.Recognize(ctx, req)
+				latency := time.Since(start).Milliseconds()
+
+				mu.Lock()
+				latencies = append(latencies, latency)
+				if err != nil {
+					errCount++
+					errorCodes[errorCodeOf(err)]++
+				}
+				mu.Unlock()
+			}
+		}(w)
+	}
+	wg.Wait()
+	elapsed := time.Since(started).Seconds()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	report := &LoadTestReport{
+		VendorConfigID: vc.ID,
+		Concurrency:    concurrency,
+		TargetQPS:      qps,
+		DurationS:      elapsed,
+		TotalCalls:     len(latencies),
+		ErrorCount:     errCount,
+		LatencyP50MS:   percentile(latencies, 0.50),
+		LatencyP90MS:   percentile(latencies, 0.90),
+		LatencyP99MS:   percentile(latencies, 0.99),
+	}
+	if len(latencies) > 0 {
+		report.ErrorRate = float64(errCount) / float64(len(latencies))
+		report.AchievedQPS = float64(len(latencies)) / elapsed
+		report.LatencyMaxMS = latencies[len(latencies)-1]
+	}
+	if len(errorCodes) > 0 {
+		report.ErrorCodes = errorCodes
+	}
+	if err := e.store.SetJobReport(ctx, job.ID, report); err != nil {
+		return e.failJob(ctx, job.ID, fmt.Errorf("store load test report: %w", err))
+	}
+
+	// Successful calls still cost money; record the spend in one aggregate
+	// ledger entry.
+	success := len(latencies) - errCount
+	if cost := pricing.EstimatePerCall(vc); cost > 0 && success > 0 {
+		err := e.store.RecordSpend(ctx, &models.SpendRecord{
+			ProjectID:      job.ProjectID,
+			VendorConfigID: vc.ID,
+			JobID:          job.ID,
+			AmountUSD:      cost * float64(success),
+			Month:          time.Now().UTC().Format("2006-01"),
+		})
+		if err != nil {
+			log.Printf("job %d: record load test spend: %v", job.ID, err)
+		}
+	}
+
+	if err := e.store.MarkJobCompleted(ctx, job.ID); err != nil {
+		return fmt.Errorf("mark job %d completed: %w", job.ID, err)
+	}
+	log.Printf("job %d completed (load test): %d calls, %.1f qps, %d errors",
+		job.ID, report.TotalCalls, report.AchievedQPS, errCount)
+	return nil
+}
+
+// percentile reads the p-th percentile from an ascending-sorted slice.
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)-1))
+	return sorted[idx]
+}
+
+// errorCodeOf extracts the adapter error code, falling back to VENDOR_ERROR.
+func errorCodeOf(err error) string {
+	var adapterErr *vendoradapter.AdapterError
+	if errors.As(err, &adapterErr) {
+		return adapterErr.Code
+	}
+	return vendoradapter.ErrCodeVendorError
+}
+
+func intParam(params map[string]any, key string, def int) int {
+	if v, ok := params[key].(float64); ok {
+		return int(v)
+	}
+	return def
+}
+
+func floatParam(params map[string]any, key string, def float64) float64 {
+	if v, ok := params[key].(float64); ok {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,139 @@
+package coreengine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"runtime/debug"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// ReproManifest captures everything needed to audit or re-run a job exactly
+// as it executed: the dataset (with per-case ground-truth fingerprints), the
+// vendor configs as they stood at run time, the effective parameters and
+// normalization options, and the engine build. It is recorded when the run
+// starts, before any state can drift.
+type ReproManifest struct {
+	JobID             int64                  `json:"job_id"`
+	GeneratedAt       time.Time              `json:"generated_at"`
+	EngineVersion     string                 `json:"engine_version"`
+	DatasetSnapshotID *int64                 `json:"dataset_snapshot_id,omitempty"`
+	Parameters        map[string]any         `json:"parameters,omitempty"`
+	Normalization     map[string]bool        `json:"normalization"`
+	TestCases         []ManifestTestCase     `json:"test_cases"`
+	VendorConfigs     []ManifestVendorConfig `json:"vendor_configs"`
+}
+
+// ManifestTestCase pins one test case: the ground truth is fingerprinted
+// rather than copied, so the manifest stays small but a later edit is still
+// detectable.
+type ManifestTestCase struct {
+	ID                int64     `json:"id"`
+	Name              string    `json:"name"`
+	GroundTruthSHA256 string    `json:"ground_truth_sha256"`
+	AudioObjectKey    string    `json:"audio_object_key"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ManifestVendorConfig pins one vendor config revision. Secrets are never
+// included; OtherConfigs is fingerprinted for the same reason as ground
+// truths.
+type ManifestVendorConfig struct {
+	ID                 int64     `json:"id"`
+	Name               string    `json:"name"`
+	AdapterKey         string    `json:"adapter_key,omitempty"`
+	APIType            string    `json:"api_type"`
+	APIEndpoint        string    `json:"api_endpoint,omitempty"`
+	OtherConfigsSHA256 string    `json:"other_configs_sha256,omitempty"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// normalizationOptions are the scoring options recorded in the manifest;
+// they change metric values without changing the data, so a reproduction
+// must replay them exactly.
+var normalizationOptions = []string{
+	"normalize_itn", "normalize_profanity", "normalize_diacritics", "pinyin_score",
+}
+
+// recordManifest builds and stores the job's reproducibility manifest. It
+// runs after snapshot ground truths have been substituted, so the
+// fingerprints describe the text actually scored. Failures only log: a
+// missing manifest must never fail the run it documents.
+func (e *Engine) recordManifest(ctx context.Context, job *models.EvaluationJob,
+	testCases []*models.ASRTestCase, vendorConfigs []*models.VendorConfig,
+	params map[string]any) {
+
+	m := &ReproManifest{
+		JobID:             job.ID,
+		GeneratedAt:       time.Now().UTC(),
+		EngineVersion:     engineVersion,
+		DatasetSnapshotID: job.DatasetSnapshotID,
+		Normalization:     map[string]bool{},
+	}
+	if len(params) > 0 {
+		m.Parameters = params
+	}
+	for _, opt := range normalizationOptions {
+		enabled, _ := params[opt].(bool)
+		m.Normalization[opt] = enabled
+	}
+	for _, tc := range testCases {
+		m.TestCases = append(m.TestCases, ManifestTestCase{
+			ID:                tc.ID,
+			Name:              tc.Name,
+			GroundTruthSHA256: sha256Hex([]byte(tc.GroundTruth)),
+			AudioObjectKey:    tc.AudioObjectKey,
+			UpdatedAt:         tc.UpdatedAt,
+		})
+	}
+	for _, vc := range vendorConfigs {
+		mv := ManifestVendorConfig{
+			ID:          vc.ID,
+			Name:        vc.Name,
+			AdapterKey:  vc.AdapterKey,
+			APIType:     vc.APIType,
+			APIEndpoint: vc.APIEndpoint,
+			UpdatedAt:   vc.UpdatedAt,
+		}
+		if len(vc.OtherConfigs) > 0 {
+			mv.OtherConfigsSHA256 = sha256Hex(vc.OtherConfigs)
+		}
+		m.VendorConfigs = append(m.VendorConfigs, mv)
+	}
+	if err := e.store.SetJobManifest(ctx, job.ID, m); err != nil {
+		e.logJob(ctx, job.ID, models.LogLevelWarn, "store reproducibility manifest: %v", err)
+	}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// engineVersion is the binary's VCS revision, resolved once at startup.
+var engineVersion = func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				modified = "-dirty"
+			}
+		}
+	}
+	if revision != "" {
+		return revision + modified
+	}
+	if info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "unknown"
+}()
@@ -0,0 +1,74 @@
+package coreengine
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// rateLimiters hands out one token bucket per vendor config, so a vendor's
+// declared QPS cap holds across every worker and concurrent job calling it.
+// Buckets are rebuilt when the config's cap changes, which matters because
+// configs are re-resolved per pair.
+type rateLimiters struct {
+	mu      sync.Mutex
+	buckets map[int64]*tokenBucket
+}
+
+func newRateLimiters() *rateLimiters {
+	return &rateLimiters{buckets: map[int64]*tokenBucket{}}
+}
+
+// wait blocks until the vendor's bucket grants a token. Configs without a
+// max_qps cap return immediately; a cancelled ctx aborts the wait and the
+// caller's own ctx checks surface the cancellation.
+func (l *rateLimiters) wait(ctx context.Context, vc *models.VendorConfig) {
+	qps := vendoradapter.MaxQPS(vc)
+	if qps <= 0 {
+		return
+	}
+	l.mu.Lock()
+	b := l.buckets[vc.ID]
+	if b == nil || b.qps != qps {
+		b = &tokenBucket{qps: qps, tokens: 1, last: time.Now()}
+		l.buckets[vc.ID] = b
+	}
+	l.mu.Unlock()
+	b.wait(ctx)
+}
+
+// tokenBucket refills qps tokens per second and holds at most one second of
+// burst. Callers that find the bucket empty sleep until their token would
+// have accrued, which spaces calls at the configured rate under saturation.
+type tokenBucket struct {
+	mu     sync.Mutex
+	qps    float64
+	tokens float64
+	last   time.Time
+}
+
+func (b *tokenBucket) wait(ctx context.Context) {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.qps
+	if b.tokens > b.qps {
+		b.tokens = b.qps
+	}
+	b.last = now
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.qps * float64(time.Second))
+	}
+	b.mu.Unlock()
+	if delay <= 0 {
+		return
+	}
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+	}
+}
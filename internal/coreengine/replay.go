@@ -0,0 +1,90 @@
+package coreengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/Jcateye/AITestPlatform/internal/metrics"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// replaySourceJobID extracts the replay_of_job_id job parameter; 0 means the
+// job is a normal live run.
+func replaySourceJobID(params map[string]any) int64 {
+	if v, ok := params["replay_of_job_id"].(float64); ok {
+		return int64(v)
+	}
+	return 0
+}
+
+// runReplay re-runs a job deterministically from the raw vendor responses
+// recorded by a previous job: no vendor calls, no spend, just the parsing
+// and metric layers. This validates adapter parsing or normalization changes
+// against historical data without touching vendor quota.
+func (e *Engine) runReplay(ctx context.Context, job *models.EvaluationJob,
+	testCases []*models.ASRTestCase, vendorConfigs []*models.VendorConfig,
+	sourceJobID int64) error {
+
+	sourceResults, err := e.store.ListASRResultsByJob(ctx, sourceJobID)
+	if err != nil {
+		return e.failJob(ctx, job.ID, fmt.Errorf("load replay source job %d: %w", sourceJobID, err))
+	}
+	type pair struct{ tc, vc int64 }
+	source := make(map[pair]*models.ASRResult, len(sourceResults))
+	for _, r := range sourceResults {
+		source[pair{r.ASRTestCaseID, r.VendorConfigID}] = r
+	}
+
+	for _, vc := range vendorConfigs {
+		adapter := vendoradapter.GetASRAdapter(vc)
+		replayer, canReplay := adapter.(vendoradapter.RawReplayer)
+		for _, tc := range testCases {
+			result := &models.ASRResult{
+				JobID:          job.ID,
+				ASRTestCaseID:  tc.ID,
+				VendorConfigID: vc.ID,
+			}
+			src, ok := source[pair{tc.ID, vc.ID}]
+			switch {
+			case !canReplay:
+				result.ErrorCode = "REPLAY_UNSUPPORTED"
+				result.ErrorMessage = fmt.Sprintf("adapter %s cannot re-parse raw responses", adapter.Name())
+			case !ok || len(src.RawVendorResponse) == 0:
+				result.ErrorCode = "REPLAY_NO_SOURCE"
+				result.ErrorMessage = fmt.Sprintf("job %d has no raw response for this pair", sourceJobID)
+			default:
+				resp, err := replayer.ParseRaw(src.RawVendorResponse)
+				if err != nil {
+					var adapterErr *vendoradapter.AdapterError
+					if errors.As(err, &adapterErr) {
+						result.ErrorCode = adapterErr.Code
+						result.ErrorMessage = adapterErr.Message
+					} else {
+						result.ErrorCode = vendoradapter.ErrCodeVendorError
+						result.ErrorMessage = err.Error()
+					}
+					break
+				}
+				result.RecognizedText = resp.Transcript
+				result.RawVendorResponse = resp.RawResponse
+				result.WER = metrics.WER(tc.GroundTruth, resp.Transcript).Rate
+				result.CER = metrics.CER(tc.GroundTruth, resp.Transcript).Rate
+				result.SegmentScores = ScoreSegments(tc, resp.Transcript)
+				// Carry the original latency through so latency summaries
+				// of replayed jobs stay meaningful.
+				result.LatencyMS = src.LatencyMS
+				result.VendorLatencyMS = resp.VendorProcessingMS
+			}
+			e.storeResult(ctx, result)
+		}
+	}
+
+	if err := e.store.MarkJobCompleted(ctx, job.ID); err != nil {
+		return fmt.Errorf("mark job %d completed: %w", job.ID, err)
+	}
+	log.Printf("job %d completed (replay of job %d)", job.ID, sourceJobID)
+	return nil
+}
@@ -0,0 +1,103 @@
+package coreengine
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// samplePlan records how a job's test cases were subsampled. It is stored in
+// the job report so the exact subset can be reproduced: rerunning with the
+// recorded seed selects the same cases.
+type samplePlan struct {
+	Seed       int64   `json:"seed"`
+	SampleSize int     `json:"sample_size"`
+	StratifyBy string  `json:"stratify_by,omitempty"`
+	Selected   []int64 `json:"selected_test_case_ids"`
+}
+
+// sampleTestCases applies the job's sampling parameters: sample_size caps
+// the number of test cases, stratify_by ("language" or "tag") balances the
+// subset across strata, and sample_seed makes the draw reproducible (a seed
+// is generated and recorded when none is given). Returns the input unchanged
+// with a nil plan when no sampling is requested.
+func sampleTestCases(testCases []*models.ASRTestCase, params map[string]any) ([]*models.ASRTestCase, *samplePlan, error) {
+	size := intParam(params, "sample_size", 0)
+	if size <= 0 || size >= len(testCases) {
+		return testCases, nil, nil
+	}
+	stratifyBy, _ := params["stratify_by"].(string)
+	keyOf, err := stratumKeyFunc(stratifyBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	seed := int64(intParam(params, "sample_seed", 0))
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	// Group into strata, shuffle within each, then draw round-robin over the
+	// sorted stratum keys so every stratum is represented before any repeats.
+	strata := map[string][]*models.ASRTestCase{}
+	for _, tc := range testCases {
+		k := keyOf(tc)
+		strata[k] = append(strata[k], tc)
+	}
+	keys := make([]string, 0, len(strata))
+	for k := range strata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		group := strata[k]
+		sort.Slice(group, func(i, j int) bool { return group[i].ID < group[j].ID })
+		rng.Shuffle(len(group), func(i, j int) { group[i], group[j] = group[j], group[i] })
+	}
+
+	var selected []*models.ASRTestCase
+	for len(selected) < size {
+		drew := false
+		for _, k := range keys {
+			if len(selected) == size {
+				break
+			}
+			if group := strata[k]; len(group) > 0 {
+				selected = append(selected, group[0])
+				strata[k] = group[1:]
+				drew = true
+			}
+		}
+		if !drew {
+			break
+		}
+	}
+
+	plan := &samplePlan{Seed: seed, SampleSize: size, StratifyBy: stratifyBy}
+	for _, tc := range selected {
+		plan.Selected = append(plan.Selected, tc.ID)
+	}
+	return selected, plan, nil
+}
+
+// stratumKeyFunc maps a stratify_by value to a grouping key function.
+func stratumKeyFunc(stratifyBy string) (func(*models.ASRTestCase) string, error) {
+	switch stratifyBy {
+	case "":
+		return func(*models.ASRTestCase) string { return "" }, nil
+	case "language":
+		return func(tc *models.ASRTestCase) string { return tc.Language }, nil
+	case "tag":
+		return func(tc *models.ASRTestCase) string {
+			if len(tc.Tags) > 0 {
+				return tc.Tags[0]
+			}
+			return ""
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported stratify_by %q", stratifyBy)
+	}
+}
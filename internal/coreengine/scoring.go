@@ -0,0 +1,105 @@
+package coreengine
+
+import (
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/metrics"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// ScoreTranscript fills a result's metric fields from a vendor transcript,
+// applying the job's scoring options in order: the vendor's post-process
+// chain, then ITN normalization, then profanity normalization. It is shared
+// by the in-process engine and the runner push path so agent results score
+// identically to local ones.
+func ScoreTranscript(result *models.ASRResult, tc *models.ASRTestCase,
+	vc *models.VendorConfig, params map[string]any, transcript string) {
+
+	// Configs with a post-process chain score the cleaned transcript; both
+	// the raw and the cleaned text are kept on the result.
+	scored := transcript
+	if vc != nil {
+		if rules := vendoradapter.PostProcessRules(vc); len(rules) > 0 {
+			scored = vendoradapter.ApplyPostProcess(rules, transcript)
+			result.PostProcessedText = scored
+		}
+	}
+
+	// The ITN option rewrites written-form numbers to spoken form on both
+	// sides, so client-side inverse text normalization is not penalized.
+	reference := tc.GroundTruth
+	if itn, _ := params["normalize_itn"].(bool); itn {
+		reference = metrics.DeITN(tc.Language, reference)
+		scored = metrics.DeITN(tc.Language, scored)
+	}
+
+	// The diacritics option strips optional pointing (Arabic tashkeel,
+	// Hebrew niqqud) from both sides, since references and vendors write
+	// it inconsistently and it would otherwise inflate CER.
+	if dia, _ := params["normalize_diacritics"].(bool); dia {
+		reference = metrics.StripDiacritics(tc.Language, reference)
+		scored = metrics.StripDiacritics(tc.Language, scored)
+	}
+
+	// The profanity option first measures how much of the reference
+	// profanity the vendor surfaced (masked or verbatim), then strips it
+	// from both sides so masking style does not distort WER.
+	if prof, _ := params["normalize_profanity"].(bool); prof {
+		if refCount := metrics.CountProfanity(reference); refCount > 0 {
+			detected := metrics.CountProfanity(scored) + metrics.CountProfanityMasks(scored)
+			if detected > refCount {
+				detected = refCount
+			}
+			addExtraMetric(result, "profanity_detection", float64(detected)/float64(refCount))
+		}
+		reference = metrics.StripProfanity(reference)
+		scored = metrics.StripProfanity(scored)
+	}
+
+	// Digit-exact numeric accuracy for finance/telephony jobs, where one
+	// wrong digit matters far more than overall WER. Note this runs on the
+	// written form, so it pairs with vendors doing client-side ITN.
+	if num, _ := params["numeric_accuracy"].(bool); num {
+		if acc, ok := metrics.NumericAccuracy(reference, scored); ok {
+			addExtraMetric(result, "numeric_accuracy", acc)
+		}
+	}
+
+	// Entity recall runs whenever the case carries entity annotations; the
+	// product metric is whether the spans that matter came through.
+	if len(tc.Entities) > 0 {
+		texts := make([]string, len(tc.Entities))
+		for i, entity := range tc.Entities {
+			texts[i] = entity.Text
+		}
+		addExtraMetric(result, "entity_recall", metrics.EntityRecall(texts, scored))
+	}
+
+	// Sanity check: a transcript in the wrong script means the vendor ran
+	// the wrong engine (bad engine_type default, wrong model), a failure
+	// WER alone reports only as uniformly terrible accuracy.
+	if tc.Language != "" && transcript != "" && !metrics.LanguageMatches(tc.Language, transcript) {
+		addExtraMetric(result, "language_mismatch", 1)
+	}
+
+	// Mandarin jobs can opt into pinyin error rates next to CER. A CER-PER
+	// gap is homophone confusion — the right sound, the wrong character —
+	// and the toneless variant additionally forgives tone-only differences.
+	if py, _ := params["pinyin_score"].(bool); py && strings.HasPrefix(tc.Language, "zh") {
+		addExtraMetric(result, "per_toned", metrics.PER(reference, scored, true).Rate)
+		addExtraMetric(result, "per_toneless", metrics.PER(reference, scored, false).Rate)
+	}
+
+	result.WER = metrics.WER(reference, scored).Rate
+	result.CER = metrics.CER(reference, scored).Rate
+	result.SegmentScores = ScoreSegments(tc, scored)
+}
+
+// addExtraMetric records one named auxiliary metric on a result.
+func addExtraMetric(result *models.ASRResult, name string, value float64) {
+	if result.ExtraMetrics == nil {
+		result.ExtraMetrics = map[string]float64{}
+	}
+	result.ExtraMetrics[name] = value
+}
@@ -0,0 +1,184 @@
+package coreengine
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// isSweep reports whether the job is a parameter sweep: a grid of parameter
+// values expanded into one sub-run per combination.
+func isSweep(params map[string]any) bool {
+	return len(sweepGrid(params)) > 0
+}
+
+// sweepGrid extracts the sweep axes: parameter name to candidate values.
+func sweepGrid(params map[string]any) map[string][]any {
+	raw, _ := params["sweep"].(map[string]any)
+	grid := map[string][]any{}
+	for key, v := range raw {
+		values, ok := v.([]any)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		grid[key] = values
+	}
+	return grid
+}
+
+// sweepCombo is one point of the grid: a label (stored in the result's
+// Region column, like experiment arms) plus the parameter values it sets.
+type sweepCombo struct {
+	Label  string         `json:"label"`
+	Params map[string]any `json:"params"`
+}
+
+// expandSweepGrid enumerates the cartesian product of the grid axes in a
+// deterministic order, so labels are stable across reruns.
+func expandSweepGrid(grid map[string][]any) []sweepCombo {
+	keys := make([]string, 0, len(grid))
+	for k := range grid {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	combos := []sweepCombo{{Params: map[string]any{}}}
+	for _, key := range keys {
+		var next []sweepCombo
+		for _, combo := range combos {
+			for _, value := range grid[key] {
+				merged := make(map[string]any, len(combo.Params)+1)
+				for k, v := range combo.Params {
+					merged[k] = v
+				}
+				merged[key] = value
+				next = append(next, sweepCombo{Params: merged})
+			}
+		}
+		combos = next
+	}
+	for i := range combos {
+		combos[i].Label = fmt.Sprintf("sweep-%d", i)
+	}
+	return combos
+}
+
+// SweepRun aggregates one grid point across all pairs.
+type SweepRun struct {
+	Label         string         `json:"label"`
+	Params        map[string]any `json:"params"`
+	MeanWER       float64        `json:"mean_wer"`
+	MeanCER       float64        `json:"mean_cer"`
+	MeanLatencyMS float64        `json:"mean_latency_ms"`
+	Errors        int            `json:"errors"`
+	Scored        int            `json:"scored"`
+}
+
+// SweepReport is stored as the job's report for sweep jobs. Best names the
+// winning label per metric.
+type SweepReport struct {
+	Runs []SweepRun        `json:"runs"`
+	Best map[string]string `json:"best"`
+}
+
+// runSweep executes a parameter sweep: every test case runs once per grid
+// combination and vendor, and the report identifies the best combination per
+// metric.
+func (e *Engine) runSweep(ctx context.Context, job *models.EvaluationJob,
+	testCases []*models.ASRTestCase, vendorConfigs []*models.VendorConfig,
+	params map[string]any) error {
+
+	combos := expandSweepGrid(sweepGrid(params))
+	month := time.Now().UTC().Format("2006-01")
+	for _, vc := range vendorConfigs {
+		for _, combo := range combos {
+			comboParams := mergeParams(params, combo.Params)
+			variant := vendorVariant{cfg: vc, region: combo.Label}
+			for _, tc := range testCases {
+				if err := ctx.Err(); err != nil {
+					return e.failJob(ctx, job.ID, fmt.Errorf("job interrupted: %w", err))
+				}
+				fresh := e.refreshVariant(ctx, variant)
+				adapter := vendoradapter.GetASRAdapter(fresh.cfg)
+				e.runPair(ctx, job, adapter, fresh, tc, comboParams, month)
+			}
+		}
+	}
+
+	report, err := e.buildSweepReport(ctx, job.ID, combos)
+	if err != nil {
+		return e.failJob(ctx, job.ID, fmt.Errorf("build sweep report: %w", err))
+	}
+	if err := e.store.SetJobReport(ctx, job.ID, map[string]any{"sweep": report}); err != nil {
+		log.Printf("job %d: store sweep report: %v", job.ID, err)
+	}
+
+	if err := e.store.MarkJobCompleted(ctx, job.ID); err != nil {
+		return fmt.Errorf("mark job %d completed: %w", job.ID, err)
+	}
+	log.Printf("job %d completed (sweep): %d cases x %d vendors x %d combos",
+		job.ID, len(testCases), len(vendorConfigs), len(combos))
+	return nil
+}
+
+// buildSweepReport reads the job's stored results back and ranks the grid
+// combinations per metric.
+func (e *Engine) buildSweepReport(ctx context.Context, jobID int64, combos []sweepCombo) (*SweepReport, error) {
+	results, err := e.store.ListASRResultsByJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]SweepRun, len(combos))
+	index := map[string]int{}
+	for i, combo := range combos {
+		runs[i] = SweepRun{Label: combo.Label, Params: combo.Params}
+		index[combo.Label] = i
+	}
+	for _, r := range results {
+		i, ok := index[r.Region]
+		if !ok {
+			continue
+		}
+		if r.ErrorCode != "" {
+			runs[i].Errors++
+			continue
+		}
+		runs[i].Scored++
+		runs[i].MeanWER += r.WER
+		runs[i].MeanCER += r.CER
+		runs[i].MeanLatencyMS += float64(r.LatencyMS)
+	}
+	for i := range runs {
+		if runs[i].Scored > 0 {
+			runs[i].MeanWER /= float64(runs[i].Scored)
+			runs[i].MeanCER /= float64(runs[i].Scored)
+			runs[i].MeanLatencyMS /= float64(runs[i].Scored)
+		}
+	}
+
+	best := map[string]string{}
+	bestBy := func(metric string, value func(SweepRun) float64) {
+		bestIdx := -1
+		for i, run := range runs {
+			if run.Scored == 0 {
+				continue
+			}
+			if bestIdx < 0 || value(run) < value(runs[bestIdx]) {
+				bestIdx = i
+			}
+		}
+		if bestIdx >= 0 {
+			best[metric] = runs[bestIdx].Label
+		}
+	}
+	bestBy("wer", func(r SweepRun) float64 { return r.MeanWER })
+	bestBy("cer", func(r SweepRun) float64 { return r.MeanCER })
+	bestBy("latency_ms", func(r SweepRun) float64 { return r.MeanLatencyMS })
+
+	return &SweepReport{Runs: runs, Best: best}, nil
+}
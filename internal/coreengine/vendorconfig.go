@@ -0,0 +1,54 @@
+package coreengine
+
+import (
+	"context"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// vendorConfigTTL bounds how stale a resolved vendor config can get when an
+// invalidation is missed (e.g. an update applied by another process).
+const vendorConfigTTL = 30 * time.Second
+
+// resolveVendorConfig returns the current vendor config, read through the
+// cache. The API invalidates the cache entry on update, so edits to
+// credentials or OtherConfigs take effect on the next call instead of the
+// next job.
+func (e *Engine) resolveVendorConfig(ctx context.Context, id int64) (*models.VendorConfig, error) {
+	key := cache.VendorConfigKey(id)
+	if cached, ok := e.cache.Get(key); ok {
+		if vc, ok := cached.(*models.VendorConfig); ok {
+			return vc, nil
+		}
+	}
+	vc, err := e.store.GetVendorConfig(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	e.cache.Set(key, vc, vendorConfigTTL)
+	return vc, nil
+}
+
+// refreshVariant re-resolves a variant's vendor config and re-applies its
+// regional endpoint override. On a resolution failure the variant is
+// returned unchanged, so a transient datastore blip degrades to slightly
+// stale config rather than erroring every pair.
+func (e *Engine) refreshVariant(ctx context.Context, variant vendorVariant) vendorVariant {
+	vc, err := e.resolveVendorConfig(ctx, variant.cfg.ID)
+	if err != nil {
+		return variant
+	}
+	if variant.region == "" {
+		return vendorVariant{cfg: vc}
+	}
+	clone := *vc
+	if endpoint := vendoradapter.RegionalEndpoints(vc)[variant.region]; endpoint != "" {
+		clone.APIEndpoint = endpoint
+	} else {
+		clone.APIEndpoint = variant.cfg.APIEndpoint
+	}
+	return vendorVariant{cfg: &clone, region: variant.region}
+}
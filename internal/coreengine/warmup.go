@@ -0,0 +1,70 @@
+package coreengine
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/pricing"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// ColdStartEntry records one vendor variant's first-call latency within this
+// job's session, so reports can separate cold-start from steady-state
+// behavior. With warm-up calls enabled the first call is a warm-up; without,
+// it is the first scored call.
+type ColdStartEntry struct {
+	VendorConfigID     int64  `json:"vendor_config_id"`
+	Region             string `json:"region,omitempty"`
+	FirstCallLatencyMS int64  `json:"first_call_latency_ms"`
+}
+
+// warmUp issues n throwaway recognition calls against a vendor before the
+// scored run starts. The calls reuse the first test case's audio and are
+// excluded from all metrics; errors are deliberately ignored since a failing
+// warm-up still warms the path. Returns the first call's latency, or -1 if no
+// call could be made.
+func (e *Engine) warmUp(ctx context.Context, job *models.EvaluationJob,
+	adapter vendoradapter.ASRAdapter, variant vendorVariant,
+	tc *models.ASRTestCase, params map[string]any, n int, month string) int64 {
+
+	audio, err := e.objects.Get(ctx, tc.AudioObjectKey)
+	if err != nil {
+		log.Printf("job %d: warm-up audio for case %d: %v", job.ID, tc.ID, err)
+		return -1
+	}
+	req := &vendoradapter.ASRRequest{
+		Audio:    audio,
+		Format:   tc.AudioFormat,
+		Language: tc.Language,
+		Params:   params,
+	}
+	first := int64(-1)
+	for i := 0; i < n; i++ {
+		// Warm-up calls count against the vendor's max_qps cap like any
+		// other call; only the measurement excludes the wait.
+		e.limits.wait(ctx, variant.cfg)
+		start := time.Now()
+		_, _ = adapter.Recognize(ctx, req)
+		if i == 0 {
+			first = time.Since(start).Milliseconds()
+		}
+	}
+
+	// Warm-up calls hit the real vendor and cost real money; charge them to
+	// the ledger in one aggregate entry.
+	if cost := pricing.EstimatePerCall(variant.cfg); cost > 0 && n > 0 {
+		err := e.store.RecordSpend(ctx, &models.SpendRecord{
+			ProjectID:      job.ProjectID,
+			VendorConfigID: variant.cfg.ID,
+			JobID:          job.ID,
+			AmountUSD:      cost * float64(n),
+			Month:          month,
+		})
+		if err != nil {
+			log.Printf("job %d: record warm-up spend for vendor %d: %v", job.ID, variant.cfg.ID, err)
+		}
+	}
+	return first
+}
@@ -0,0 +1,166 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const alertRuleColumns = `id, project_id, name, metric, vendor_config_id, tag,
+	comparison, threshold, window_jobs, enabled, firing, created_at`
+
+func scanAlertRule(row interface{ Scan(...any) error }) (*models.AlertRule, error) {
+	r := &models.AlertRule{}
+	err := row.Scan(&r.ID, &r.ProjectID, &r.Name, &r.Metric, &r.VendorConfigID, &r.Tag,
+		&r.Comparison, &r.Threshold, &r.WindowJobs, &r.Enabled, &r.Firing, &r.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// CreateAlertRule inserts a rule and fills in the generated fields.
+func (s *Store) CreateAlertRule(ctx context.Context, r *models.AlertRule) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO alert_rules
+			(project_id, name, metric, vendor_config_id, tag, comparison, threshold, window_jobs, enabled)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 RETURNING id, created_at`,
+		r.ProjectID, r.Name, r.Metric, r.VendorConfigID, r.Tag,
+		r.Comparison, r.Threshold, r.WindowJobs, r.Enabled,
+	).Scan(&r.ID, &r.CreatedAt)
+}
+
+// ListAlertRules returns a project's rules.
+func (s *Store) ListAlertRules(ctx context.Context, projectID int64) ([]*models.AlertRule, error) {
+	return s.queryAlertRules(ctx,
+		`SELECT `+alertRuleColumns+` FROM alert_rules WHERE project_id = $1 ORDER BY id`, projectID)
+}
+
+// ListEnabledAlertRules returns every enabled rule across all projects, for
+// the periodic evaluation sweep.
+func (s *Store) ListEnabledAlertRules(ctx context.Context) ([]*models.AlertRule, error) {
+	return s.queryAlertRules(ctx,
+		`SELECT `+alertRuleColumns+` FROM alert_rules WHERE enabled ORDER BY id`)
+}
+
+// ListEnabledAlertRulesByProject returns a project's enabled rules, for the
+// post-job evaluation.
+func (s *Store) ListEnabledAlertRulesByProject(ctx context.Context, projectID int64) ([]*models.AlertRule, error) {
+	return s.queryAlertRules(ctx,
+		`SELECT `+alertRuleColumns+` FROM alert_rules WHERE enabled AND project_id = $1 ORDER BY id`, projectID)
+}
+
+func (s *Store) queryAlertRules(ctx context.Context, query string, args ...any) ([]*models.AlertRule, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list alert rules: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.AlertRule
+	for rows.Next() {
+		r, err := scanAlertRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// DeleteAlertRule removes a rule.
+func (s *Store) DeleteAlertRule(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM alert_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete alert rule %d: %w", id, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetAlertRuleFiring records a rule's current state after an evaluation.
+func (s *Store) SetAlertRuleFiring(ctx context.Context, id int64, firing bool) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE alert_rules SET firing = $2 WHERE id = $1`, id, firing)
+	if err != nil {
+		return fmt.Errorf("set alert rule %d firing: %w", id, err)
+	}
+	return nil
+}
+
+// EvaluateAlertMetric computes a rule's metric over the project's most
+// recent WindowJobs completed jobs, honoring the rule's vendor and tag
+// scope. The sample count is zero when no results fall inside the window,
+// in which case the rule is skipped rather than compared against zero.
+func (s *Store) EvaluateAlertMetric(ctx context.Context, rule *models.AlertRule) (value float64, samples int64, err error) {
+	window := rule.WindowJobs
+	if window < 1 {
+		window = 1
+	}
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT id FROM evaluation_jobs
+		 WHERE project_id = $1 AND status = $2 ORDER BY id DESC LIMIT $3`,
+		rule.ProjectID, models.JobStatusCompleted, window)
+	if err != nil {
+		return 0, 0, fmt.Errorf("alert rule %d: list recent jobs: %w", rule.ID, err)
+	}
+	defer rows.Close()
+	var jobIDs []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return 0, 0, err
+		}
+		jobIDs = append(jobIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, 0, err
+	}
+	if len(jobIDs) == 0 {
+		return 0, 0, nil
+	}
+
+	where := `r.job_id = ANY($1)`
+	args := []any{pq.Array(jobIDs)}
+	if rule.VendorConfigID != nil {
+		args = append(args, *rule.VendorConfigID)
+		where += fmt.Sprintf(` AND r.vendor_config_id = $%d`, len(args))
+	}
+	from := `asr_results r`
+	if rule.Tag != "" {
+		args = append(args, rule.Tag)
+		from += ` JOIN asr_test_cases t ON t.id = r.asr_test_case_id`
+		where += fmt.Sprintf(` AND $%d = ANY(t.tags)`, len(args))
+	}
+
+	var selectExpr string
+	switch rule.Metric {
+	case "mean_wer":
+		selectExpr = `COALESCE(AVG(r.wer) FILTER (WHERE r.error_code = ''), 0),
+			COUNT(*) FILTER (WHERE r.error_code = '')`
+	case "mean_cer":
+		selectExpr = `COALESCE(AVG(r.cer) FILTER (WHERE r.error_code = ''), 0),
+			COUNT(*) FILTER (WHERE r.error_code = '')`
+	case "mean_latency_ms":
+		selectExpr = `COALESCE(AVG(r.latency_ms) FILTER (WHERE r.error_code = ''), 0),
+			COUNT(*) FILTER (WHERE r.error_code = '')`
+	case "error_rate":
+		selectExpr = `COALESCE(COUNT(*) FILTER (WHERE r.error_code <> '')::double precision
+			/ NULLIF(COUNT(*), 0), 0), COUNT(*)`
+	default:
+		return 0, 0, fmt.Errorf("alert rule %d: unknown metric %q", rule.ID, rule.Metric)
+	}
+
+	err = s.reader().QueryRowContext(ctx,
+		`SELECT `+selectExpr+` FROM `+from+` WHERE `+where, args...).Scan(&value, &samples)
+	if err != nil {
+		return 0, 0, fmt.Errorf("alert rule %d: evaluate %s: %w", rule.ID, rule.Metric, err)
+	}
+	return value, samples, nil
+}
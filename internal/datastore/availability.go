@@ -0,0 +1,60 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AvailabilityBucket is one hour of a vendor's call outcomes, aggregated
+// across all jobs. Availability is the fraction of calls that did not fail.
+type AvailabilityBucket struct {
+	Hour          time.Time `json:"hour"`
+	TotalCalls    int64     `json:"total_calls"`
+	Errors        int64     `json:"errors"`
+	Timeouts      int64     `json:"timeouts"`
+	AuthFailures  int64     `json:"auth_failures"`
+	RateLimited   int64     `json:"rate_limited"`
+	NetworkErrors int64     `json:"network_errors"`
+	VendorErrors  int64     `json:"vendor_errors"`
+	Availability  float64   `json:"availability"`
+}
+
+// VendorAvailability aggregates a vendor config's result rows from the last
+// `hours` hours into hourly availability buckets. Hours with no calls are
+// simply absent. The error-code literals match the stable codes declared in
+// vendoradapter; anything else non-empty counts as a vendor error.
+func (s *Store) VendorAvailability(ctx context.Context, vendorConfigID int64, hours int) ([]*AvailabilityBucket, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT date_trunc('hour', created_at) AS hour,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE error_code <> ''),
+			COUNT(*) FILTER (WHERE error_code = 'TIMEOUT'),
+			COUNT(*) FILTER (WHERE error_code = 'AUTH_FAILED'),
+			COUNT(*) FILTER (WHERE error_code = 'RATE_LIMITED'),
+			COUNT(*) FILTER (WHERE error_code = 'NETWORK_ERROR'),
+			COUNT(*) FILTER (WHERE error_code NOT IN
+				('', 'TIMEOUT', 'AUTH_FAILED', 'RATE_LIMITED', 'NETWORK_ERROR'))
+		 FROM asr_results
+		 WHERE vendor_config_id = $1 AND created_at >= NOW() - make_interval(hours => $2)
+		 GROUP BY hour ORDER BY hour`,
+		vendorConfigID, hours)
+	if err != nil {
+		return nil, fmt.Errorf("vendor %d availability: %w", vendorConfigID, err)
+	}
+	defer rows.Close()
+	var out []*AvailabilityBucket
+	for rows.Next() {
+		b := &AvailabilityBucket{}
+		err := rows.Scan(&b.Hour, &b.TotalCalls, &b.Errors, &b.Timeouts,
+			&b.AuthFailures, &b.RateLimited, &b.NetworkErrors, &b.VendorErrors)
+		if err != nil {
+			return nil, err
+		}
+		if b.TotalCalls > 0 {
+			b.Availability = 1 - float64(b.Errors)/float64(b.TotalCalls)
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,101 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// UpsertBudget creates or updates the budget for a (project, vendor) scope.
+// A nil VendorConfigID addresses the project-wide budget.
+func (s *Store) UpsertBudget(ctx context.Context, b *models.Budget) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO budgets (project_id, vendor_config_id, monthly_limit_usd)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (project_id, COALESCE(vendor_config_id, 0))
+		 DO UPDATE SET monthly_limit_usd = EXCLUDED.monthly_limit_usd, updated_at = now()
+		 RETURNING id, created_at, updated_at`,
+		b.ProjectID, b.VendorConfigID, b.MonthlyLimitUSD,
+	).Scan(&b.ID, &b.CreatedAt, &b.UpdatedAt)
+}
+
+// ListBudgets returns every budget configured for a project.
+func (s *Store) ListBudgets(ctx context.Context, projectID int64) ([]*models.Budget, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, project_id, vendor_config_id, monthly_limit_usd, created_at, updated_at
+		 FROM budgets WHERE project_id = $1 ORDER BY id`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list budgets: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.Budget
+	for rows.Next() {
+		b := &models.Budget{}
+		if err := rows.Scan(&b.ID, &b.ProjectID, &b.VendorConfigID,
+			&b.MonthlyLimitUSD, &b.CreatedAt, &b.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
+
+// DeleteBudget removes a budget by ID.
+func (s *Store) DeleteBudget(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM budgets WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete budget %d: %w", id, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RecordSpend appends one entry to the spend ledger.
+func (s *Store) RecordSpend(ctx context.Context, r *models.SpendRecord) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO spend_records (project_id, vendor_config_id, job_id, amount_usd, month)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, created_at`,
+		r.ProjectID, r.VendorConfigID, r.JobID, r.AmountUSD, r.Month,
+	).Scan(&r.ID, &r.CreatedAt)
+}
+
+// MonthSpend sums a project's ledger for one YYYY-MM month. When
+// vendorConfigID is non-nil the sum is restricted to that vendor config.
+func (s *Store) MonthSpend(ctx context.Context, projectID int64, vendorConfigID *int64, month string) (float64, error) {
+	var total float64
+	var err error
+	if vendorConfigID != nil {
+		err = s.db.QueryRowContext(ctx,
+			`SELECT COALESCE(SUM(amount_usd), 0) FROM spend_records
+			 WHERE project_id = $1 AND vendor_config_id = $2 AND month = $3`,
+			projectID, *vendorConfigID, month).Scan(&total)
+	} else {
+		err = s.db.QueryRowContext(ctx,
+			`SELECT COALESCE(SUM(amount_usd), 0) FROM spend_records
+			 WHERE project_id = $1 AND month = $2`,
+			projectID, month).Scan(&total)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("month spend: %w", err)
+	}
+	return total, nil
+}
+
+// ProjectBandwidth sums the payload sizes of every vendor call made for a
+// project's jobs: audio bytes uploaded to vendors and response bytes
+// received back.
+func (s *Store) ProjectBandwidth(ctx context.Context, projectID int64) (audioBytes, responseBytes int64, err error) {
+	err = s.reader().QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(r.audio_bytes), 0), COALESCE(SUM(r.response_bytes), 0)
+		 FROM asr_results r JOIN evaluation_jobs j ON j.id = r.job_id
+		 WHERE j.project_id = $1`, projectID).Scan(&audioBytes, &responseBytes)
+	if err != nil {
+		return 0, 0, fmt.Errorf("project %d bandwidth: %w", projectID, err)
+	}
+	return audioBytes, responseBytes, nil
+}
@@ -0,0 +1,110 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// FreezeDatasetSnapshot captures the current state of a project's test cases
+// as an immutable named version. With ids the snapshot covers only those
+// cases, otherwise the whole project. The ground truth of each case is copied
+// into the snapshot, so later corrections don't retroactively change what the
+// version means.
+func (s *Store) FreezeDatasetSnapshot(ctx context.Context, snap *models.DatasetSnapshot, ids []int64) error {
+	query := `SELECT id, ground_truth FROM asr_test_cases WHERE project_id = $1`
+	args := []any{snap.ProjectID}
+	if len(ids) > 0 {
+		query += ` AND id = ANY($2)`
+		args = append(args, pq.Array(ids))
+	}
+	query += ` ORDER BY id`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("freeze dataset snapshot: %w", err)
+	}
+	defer rows.Close()
+	snap.Cases = nil
+	for rows.Next() {
+		var sc models.DatasetSnapshotCase
+		if err := rows.Scan(&sc.TestCaseID, &sc.GroundTruth); err != nil {
+			return err
+		}
+		snap.Cases = append(snap.Cases, sc)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(snap.Cases) == 0 {
+		return fmt.Errorf("dataset snapshot %q: no test cases to freeze", snap.Name)
+	}
+
+	cases, err := json.Marshal(snap.Cases)
+	if err != nil {
+		return fmt.Errorf("encode snapshot cases: %w", err)
+	}
+	err = s.db.QueryRowContext(ctx,
+		`INSERT INTO dataset_snapshots (project_id, name, cases)
+		 VALUES ($1, $2, $3)
+		 RETURNING id, created_at`,
+		snap.ProjectID, snap.Name, cases,
+	).Scan(&snap.ID, &snap.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create dataset snapshot %q: %w", snap.Name, err)
+	}
+	return nil
+}
+
+const datasetSnapshotColumns = `id, project_id, name, cases, created_at`
+
+func scanDatasetSnapshot(row interface{ Scan(...any) error }) (*models.DatasetSnapshot, error) {
+	snap := &models.DatasetSnapshot{}
+	var cases []byte
+	err := row.Scan(&snap.ID, &snap.ProjectID, &snap.Name, &cases, &snap.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(cases, &snap.Cases); err != nil {
+		return nil, fmt.Errorf("decode cases for dataset snapshot %d: %w", snap.ID, err)
+	}
+	return snap, nil
+}
+
+// GetDatasetSnapshot fetches one dataset snapshot by ID.
+func (s *Store) GetDatasetSnapshot(ctx context.Context, id int64) (*models.DatasetSnapshot, error) {
+	snap, err := scanDatasetSnapshot(s.db.QueryRowContext(ctx,
+		`SELECT `+datasetSnapshotColumns+` FROM dataset_snapshots WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get dataset snapshot %d: %w", id, err)
+	}
+	return snap, nil
+}
+
+// ListDatasetSnapshots returns a project's dataset snapshots, newest first.
+func (s *Store) ListDatasetSnapshots(ctx context.Context, projectID int64) ([]*models.DatasetSnapshot, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+datasetSnapshotColumns+` FROM dataset_snapshots
+		 WHERE project_id = $1 ORDER BY id DESC`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list dataset snapshots: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.DatasetSnapshot
+	for rows.Next() {
+		snap, err := scanDatasetSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, snap)
+	}
+	return out, rows.Err()
+}
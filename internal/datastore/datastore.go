@@ -0,0 +1,103 @@
+// Package datastore provides PostgreSQL persistence for the platform. It
+// uses database/sql with lib/pq directly (no ORM) so queries stay explicit
+// and easy to tune as result volumes grow.
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+
+	_ "github.com/lib/pq"
+)
+
+// Store wraps the SQL connection pools and exposes typed accessors for each
+// entity. All methods take a context so callers control timeouts.
+type Store struct {
+	// db is the primary; all writes and consistency-sensitive reads go
+	// here.
+	db *sql.DB
+
+	// replicas are optional read replicas. Heavy result and summary reads
+	// are spread across them round-robin; everything else stays on the
+	// primary so fresh writes are always visible to the workflows that
+	// just made them.
+	replicas []*sql.DB
+	next     atomic.Uint64
+
+	// compressRaw gzips raw_vendor_response blobs on write. Reads handle
+	// both representations, so the flag can be toggled at any time.
+	compressRaw bool
+}
+
+// SetRawResponseCompression enables or disables gzip compression of raw
+// vendor response blobs on write.
+func (s *Store) SetRawResponseCompression(enabled bool) {
+	s.compressRaw = enabled
+}
+
+// Open connects to the primary PostgreSQL instance and, optionally, any
+// number of read replicas. Replicas that fail to connect abort startup
+// rather than silently shrinking read capacity.
+func Open(ctx context.Context, databaseURL string, replicaURLs ...string) (*Store, error) {
+	db, err := connect(ctx, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("primary: %w", err)
+	}
+	s := &Store{db: db}
+	for i, u := range replicaURLs {
+		replica, err := connect(ctx, u)
+		if err != nil {
+			s.Close()
+			return nil, fmt.Errorf("replica %d: %w", i, err)
+		}
+		s.replicas = append(s.replicas, replica)
+	}
+	return s, nil
+}
+
+func connect(ctx context.Context, databaseURL string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	return db, nil
+}
+
+// reader picks a connection for heavy read-only queries: round-robin across
+// replicas when configured, otherwise the primary.
+func (s *Store) reader() *sql.DB {
+	if len(s.replicas) == 0 {
+		return s.db
+	}
+	n := s.next.Add(1)
+	return s.replicas[n%uint64(len(s.replicas))]
+}
+
+// Close releases all connection pools.
+func (s *Store) Close() error {
+	err := s.db.Close()
+	for _, r := range s.replicas {
+		if cerr := r.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// EnsureSchema applies the schema statements in order. Every statement is
+// written to be idempotent (IF NOT EXISTS) so this is safe to run on every
+// startup; it doubles as our lightweight migration mechanism.
+func (s *Store) EnsureSchema(ctx context.Context) error {
+	for _, stmt := range schemaStatements {
+		if _, err := s.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("apply schema statement: %w\n%s", err, stmt)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateExport records a new pending export task. Zero-valued job/project
+// IDs are stored as NULL, so each task carries only its own scope.
+func (s *Store) CreateExport(ctx context.Context, e *models.Export) error {
+	e.Status = models.ExportStatusPending
+	var jobID, projectID any
+	if e.JobID > 0 {
+		jobID = e.JobID
+	}
+	if e.ProjectID > 0 {
+		projectID = e.ProjectID
+	}
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO exports (job_id, project_id, format, status) VALUES ($1, $2, $3, $4)
+		 RETURNING id, created_at`,
+		jobID, projectID, e.Format, e.Status,
+	).Scan(&e.ID, &e.CreatedAt)
+}
+
+// GetExport fetches one export task.
+func (s *Store) GetExport(ctx context.Context, id int64) (*models.Export, error) {
+	e := &models.Export{}
+	var completedAt sql.NullTime
+	var jobID, projectID sql.NullInt64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, job_id, project_id, format, status, object_key, error_message, created_at, completed_at
+		 FROM exports WHERE id = $1`, id,
+	).Scan(&e.ID, &jobID, &projectID, &e.Format, &e.Status, &e.ObjectKey, &e.ErrorMessage,
+		&e.CreatedAt, &completedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get export %d: %w", id, err)
+	}
+	e.JobID = jobID.Int64
+	e.ProjectID = projectID.Int64
+	if completedAt.Valid {
+		e.CompletedAt = &completedAt.Time
+	}
+	return e, nil
+}
+
+// MarkExportCompleted records where the finished file landed.
+func (s *Store) MarkExportCompleted(ctx context.Context, id int64, objectKey string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE exports SET status = $2, object_key = $3, completed_at = now() WHERE id = $1`,
+		id, models.ExportStatusCompleted, objectKey)
+	if err != nil {
+		return fmt.Errorf("mark export %d completed: %w", id, err)
+	}
+	return nil
+}
+
+// MarkExportFailed records a failed export with its reason.
+func (s *Store) MarkExportFailed(ctx context.Context, id int64, reason string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE exports SET status = $2, error_message = $3, completed_at = now() WHERE id = $1`,
+		id, models.ExportStatusFailed, reason)
+	if err != nil {
+		return fmt.Errorf("mark export %d failed: %w", id, err)
+	}
+	return nil
+}
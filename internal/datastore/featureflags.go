@@ -0,0 +1,73 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// UpsertFeatureFlag sets a flag, creating it on first use. ProjectID 0 is
+// the global scope.
+func (s *Store) UpsertFeatureFlag(ctx context.Context, f *models.FeatureFlag) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO feature_flags (name, project_id, enabled)
+		 VALUES ($1, $2, $3)
+		 ON CONFLICT (name, project_id)
+		 DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = now()
+		 RETURNING id, updated_at`,
+		f.Name, f.ProjectID, f.Enabled,
+	).Scan(&f.ID, &f.UpdatedAt)
+}
+
+// ListFeatureFlags returns every flag, global and project-scoped.
+func (s *Store) ListFeatureFlags(ctx context.Context) ([]*models.FeatureFlag, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, name, project_id, enabled, updated_at
+		 FROM feature_flags ORDER BY name, project_id`)
+	if err != nil {
+		return nil, fmt.Errorf("list feature flags: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.FeatureFlag
+	for rows.Next() {
+		f := &models.FeatureFlag{}
+		if err := rows.Scan(&f.ID, &f.Name, &f.ProjectID, &f.Enabled, &f.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+// DeleteFeatureFlag removes a flag, reverting its feature to the default.
+func (s *Store) DeleteFeatureFlag(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM feature_flags WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete feature flag %d: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// FeatureEnabled resolves a flag for a project: the project-scoped flag
+// wins, then the global one, then def when neither is set.
+func (s *Store) FeatureEnabled(ctx context.Context, name string, projectID int64, def bool) (bool, error) {
+	var enabled bool
+	err := s.db.QueryRowContext(ctx,
+		`SELECT enabled FROM feature_flags
+		 WHERE name = $1 AND project_id IN (0, $2)
+		 ORDER BY project_id DESC LIMIT 1`,
+		name, projectID).Scan(&enabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return def, nil
+	}
+	if err != nil {
+		return def, fmt.Errorf("feature flag %s: %w", name, err)
+	}
+	return enabled, nil
+}
@@ -0,0 +1,47 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const gtRevisionColumns = `id, asr_test_case_id, previous_text, new_text,
+	source_result_id, editor, created_at`
+
+// CreateGroundTruthRevision records one ground-truth edit.
+func (s *Store) CreateGroundTruthRevision(ctx context.Context, rev *models.GroundTruthRevision) error {
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO ground_truth_revisions
+			(asr_test_case_id, previous_text, new_text, source_result_id, editor)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, created_at`,
+		rev.ASRTestCaseID, rev.PreviousText, rev.NewText, rev.SourceResultID, rev.Editor,
+	).Scan(&rev.ID, &rev.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create ground truth revision: %w", err)
+	}
+	return nil
+}
+
+// ListGroundTruthRevisions returns a test case's edit history, newest first.
+func (s *Store) ListGroundTruthRevisions(ctx context.Context, testCaseID int64) ([]*models.GroundTruthRevision, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+gtRevisionColumns+` FROM ground_truth_revisions
+		 WHERE asr_test_case_id = $1 ORDER BY id DESC`, testCaseID)
+	if err != nil {
+		return nil, fmt.Errorf("list ground truth revisions for test case %d: %w", testCaseID, err)
+	}
+	defer rows.Close()
+	var out []*models.GroundTruthRevision
+	for rows.Next() {
+		rev := &models.GroundTruthRevision{}
+		if err := rows.Scan(&rev.ID, &rev.ASRTestCaseID, &rev.PreviousText, &rev.NewText,
+			&rev.SourceResultID, &rev.Editor, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, rev)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,49 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// AppendJobLog records one execution log line for a job.
+func (s *Store) AppendJobLog(ctx context.Context, jobID int64, level, message string) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO job_logs (job_id, level, message) VALUES ($1, $2, $3)`,
+		jobID, level, message)
+	if err != nil {
+		return fmt.Errorf("append log for job %d: %w", jobID, err)
+	}
+	return nil
+}
+
+// ListJobLogs returns a job's execution log in order, optionally filtered to
+// one level. limit 0 means no limit.
+func (s *Store) ListJobLogs(ctx context.Context, jobID int64, level string, limit int) ([]*models.JobLog, error) {
+	query := `SELECT id, job_id, level, message, created_at FROM job_logs
+		 WHERE job_id = $1`
+	args := []any{jobID}
+	if level != "" {
+		query += ` AND level = $2`
+		args = append(args, level)
+	}
+	query += ` ORDER BY id`
+	if limit > 0 {
+		query += fmt.Sprintf(` LIMIT %d`, limit)
+	}
+	rows, err := s.reader().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list logs for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	var out []*models.JobLog
+	for rows.Next() {
+		l := &models.JobLog{}
+		if err := rows.Scan(&l.ID, &l.JobID, &l.Level, &l.Message, &l.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, l)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,318 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const evaluationJobColumns = `id, project_id, name, api_type, status, language,
+	test_case_ids, dataset_snapshot_id, vendor_config_ids, depends_on_job_ids, parameters, report,
+	error_message, progress_total, created_at, started_at, completed_at, last_heartbeat_at`
+
+func scanEvaluationJob(row interface{ Scan(...any) error }) (*models.EvaluationJob, error) {
+	job := &models.EvaluationJob{}
+	var started, completed, heartbeat sql.NullTime
+	var snapshotID sql.NullInt64
+	err := row.Scan(&job.ID, &job.ProjectID, &job.Name, &job.APIType, &job.Status,
+		&job.Language, pq.Array(&job.TestCaseIDs), &snapshotID, pq.Array(&job.VendorConfigIDs),
+		pq.Array(&job.DependsOnJobIDs), &job.Parameters, &job.Report, &job.ErrorMessage,
+		&job.ProgressTotal, &job.CreatedAt, &started, &completed, &heartbeat)
+	if err != nil {
+		return nil, err
+	}
+	if snapshotID.Valid {
+		job.DatasetSnapshotID = &snapshotID.Int64
+	}
+	if started.Valid {
+		job.StartedAt = &started.Time
+	}
+	if completed.Valid {
+		job.CompletedAt = &completed.Time
+	}
+	if heartbeat.Valid {
+		job.LastHeartbeatAt = &heartbeat.Time
+	}
+	return job, nil
+}
+
+// CreateEvaluationJob inserts a job in PENDING state.
+func (s *Store) CreateEvaluationJob(ctx context.Context, job *models.EvaluationJob) error {
+	if len(job.Parameters) == 0 {
+		job.Parameters = []byte("{}")
+	}
+	if job.DependsOnJobIDs == nil {
+		job.DependsOnJobIDs = []int64{}
+	}
+	job.Status = models.JobStatusPending
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO evaluation_jobs
+			(project_id, name, api_type, status, language, test_case_ids, dataset_snapshot_id,
+			 vendor_config_ids, depends_on_job_ids, parameters)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING id, created_at`,
+		job.ProjectID, job.Name, job.APIType, job.Status, job.Language,
+		pq.Array(job.TestCaseIDs), job.DatasetSnapshotID, pq.Array(job.VendorConfigIDs),
+		pq.Array(job.DependsOnJobIDs), []byte(job.Parameters),
+	).Scan(&job.ID, &job.CreatedAt)
+}
+
+// GetEvaluationJob fetches one job by ID.
+func (s *Store) GetEvaluationJob(ctx context.Context, id int64) (*models.EvaluationJob, error) {
+	job, err := scanEvaluationJob(s.db.QueryRowContext(ctx,
+		`SELECT `+evaluationJobColumns+` FROM evaluation_jobs WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get evaluation job %d: %w", id, err)
+	}
+	return job, nil
+}
+
+// ListEvaluationJobs returns a project's jobs, newest first.
+func (s *Store) ListEvaluationJobs(ctx context.Context, projectID int64) ([]*models.EvaluationJob, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+evaluationJobColumns+` FROM evaluation_jobs
+		 WHERE project_id = $1 ORDER BY id DESC`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list evaluation jobs: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.EvaluationJob
+	for rows.Next() {
+		job, err := scanEvaluationJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, job)
+	}
+	return out, rows.Err()
+}
+
+// JobStatuses returns the status of each of the given jobs. Unknown IDs are
+// simply absent from the map.
+func (s *Store) JobStatuses(ctx context.Context, ids []int64) (map[int64]string, error) {
+	statuses := make(map[int64]string, len(ids))
+	if len(ids) == 0 {
+		return statuses, nil
+	}
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, status FROM evaluation_jobs WHERE id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("load job statuses: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var status string
+		if err := rows.Scan(&id, &status); err != nil {
+			return nil, err
+		}
+		statuses[id] = status
+	}
+	return statuses, rows.Err()
+}
+
+// ListPendingDependentJobs returns PENDING jobs that declare upstream
+// dependencies, i.e. the jobs the dependency watcher must resolve.
+func (s *Store) ListPendingDependentJobs(ctx context.Context) ([]*models.EvaluationJob, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+evaluationJobColumns+` FROM evaluation_jobs
+		 WHERE status = $1 AND cardinality(depends_on_job_ids) > 0
+		 ORDER BY id`, models.JobStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("list pending dependent jobs: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.EvaluationJob
+	for rows.Next() {
+		job, err := scanEvaluationJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, job)
+	}
+	return out, rows.Err()
+}
+
+// SetJobReport stores a mode-specific report blob on a job, e.g. the
+// percentile summary a load test produces.
+func (s *Store) SetJobReport(ctx context.Context, id int64, report any) error {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("encode job report: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs SET report = $2 WHERE id = $1`, id, encoded)
+	if err != nil {
+		return fmt.Errorf("set report for job %d: %w", id, err)
+	}
+	return nil
+}
+
+// SetJobManifest stores a job's reproducibility manifest, written by the
+// engine when the run starts.
+func (s *Store) SetJobManifest(ctx context.Context, id int64, manifest any) error {
+	encoded, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("encode job manifest: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs SET manifest = $2 WHERE id = $1`, id, encoded)
+	if err != nil {
+		return fmt.Errorf("set manifest for job %d: %w", id, err)
+	}
+	return nil
+}
+
+// GetJobManifest returns a job's stored reproducibility manifest as raw JSON.
+// ErrNotFound covers both an unknown job and a job that has none, i.e. one
+// that predates manifests or has not started yet.
+func (s *Store) GetJobManifest(ctx context.Context, id int64) (json.RawMessage, error) {
+	var manifest []byte
+	err := s.reader().QueryRowContext(ctx,
+		`SELECT manifest FROM evaluation_jobs WHERE id = $1`, id).Scan(&manifest)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get manifest for job %d: %w", id, err)
+	}
+	if len(manifest) == 0 {
+		return nil, ErrNotFound
+	}
+	return manifest, nil
+}
+
+// MarkJobRunning transitions a job to RUNNING and stamps started_at plus an
+// initial heartbeat.
+func (s *Store) MarkJobRunning(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs
+		 SET status = $2, started_at = now(), last_heartbeat_at = now()
+		 WHERE id = $1`,
+		id, models.JobStatusRunning)
+	return err
+}
+
+// SetJobProgressTotal records how many test case × vendor pairs a job will
+// execute in total, once the engine has expanded the execution plan.
+func (s *Store) SetJobProgressTotal(ctx context.Context, id, total int64) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs SET progress_total = $2 WHERE id = $1`, id, total)
+	return err
+}
+
+// JobProgress counts a job's persisted results overall and the errored
+// subset. Together with progress_total this drives the UI progress bar;
+// deriving the counts from result rows keeps them exact across retries and
+// resumes.
+func (s *Store) JobProgress(ctx context.Context, jobID int64) (done, failed int64, err error) {
+	err = s.reader().QueryRowContext(ctx,
+		`SELECT COUNT(*), COUNT(*) FILTER (WHERE error_code <> '')
+		 FROM asr_results WHERE job_id = $1`, jobID).Scan(&done, &failed)
+	if err != nil {
+		return 0, 0, fmt.Errorf("progress for job %d: %w", jobID, err)
+	}
+	return done, failed, nil
+}
+
+// TouchJobHeartbeat stamps last_heartbeat_at, signalling that a worker is
+// still actively executing the job.
+func (s *Store) TouchJobHeartbeat(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs SET last_heartbeat_at = now() WHERE id = $1`, id)
+	return err
+}
+
+// MarkJobCompleted transitions a job to COMPLETED and stamps completed_at.
+func (s *Store) MarkJobCompleted(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs SET status = $2, completed_at = now() WHERE id = $1`,
+		id, models.JobStatusCompleted)
+	return err
+}
+
+// SetJobStatus overwrites a job's status and error message directly, without
+// touching timestamps. Used by restore tooling and admin recovery paths;
+// normal execution goes through the Mark* transitions.
+func (s *Store) SetJobStatus(ctx context.Context, id int64, status, errorMessage string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs SET status = $2, error_message = $3 WHERE id = $1`,
+		id, status, errorMessage)
+	return err
+}
+
+// MarkJobFailed transitions a job to FAILED with a human-readable reason.
+func (s *Store) MarkJobFailed(ctx context.Context, id int64, reason string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE evaluation_jobs SET status = $2, error_message = $3, completed_at = now()
+		 WHERE id = $1`,
+		id, models.JobStatusFailed, reason)
+	return err
+}
+
+// ListStaleRunningJobs returns RUNNING jobs whose last heartbeat (falling
+// back to started_at for jobs from before heartbeats existed) is older than
+// the cutoff and that have no outstanding runner tasks — jobs whose worker
+// most likely died. Jobs waiting on remote agents are excluded; agents own
+// their pace.
+func (s *Store) ListStaleRunningJobs(ctx context.Context, cutoff time.Time) ([]*models.EvaluationJob, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+evaluationJobColumns+` FROM evaluation_jobs j
+		 WHERE j.status = 'RUNNING' AND COALESCE(j.last_heartbeat_at, j.started_at) < $1
+		   AND NOT EXISTS (
+			SELECT 1 FROM runner_tasks t
+			WHERE t.job_id = j.id AND t.status IN ('PENDING', 'LEASED'))
+		 ORDER BY j.id`, cutoff)
+	if err != nil {
+		return nil, fmt.Errorf("list stale running jobs: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.EvaluationJob
+	for rows.Next() {
+		job, err := scanEvaluationJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, job)
+	}
+	return out, rows.Err()
+}
+
+// CompletedPair identifies one test case × vendor × region combination that
+// already has a result row.
+type CompletedPair struct {
+	ASRTestCaseID  int64
+	VendorConfigID int64
+	Region         string
+}
+
+// CompletedPairs returns the set of pairs a job has already produced results
+// for, so a recovered job resumes instead of re-calling vendors.
+func (s *Store) CompletedPairs(ctx context.Context, jobID int64) (map[CompletedPair]bool, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT DISTINCT asr_test_case_id, vendor_config_id, region
+		 FROM asr_results WHERE job_id = $1`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("completed pairs for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	out := map[CompletedPair]bool{}
+	for rows.Next() {
+		var p CompletedPair
+		if err := rows.Scan(&p.ASRTestCaseID, &p.VendorConfigID, &p.Region); err != nil {
+			return nil, err
+		}
+		out[p] = true
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,69 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateNotification stores a notification for the administrator.
+func (s *Store) CreateNotification(ctx context.Context, n *models.Notification) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO notifications (project_id, kind, message)
+		 VALUES ($1, $2, $3) RETURNING id, created_at`,
+		n.ProjectID, n.Kind, n.Message,
+	).Scan(&n.ID, &n.CreatedAt)
+}
+
+// HasRecentNotification reports whether an unread notification with the same
+// kind and message already exists for the project. Used to de-duplicate
+// repeated warnings such as budget thresholds.
+func (s *Store) HasRecentNotification(ctx context.Context, projectID int64, kind, message string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM notifications
+		 WHERE project_id = $1 AND kind = $2 AND message = $3 AND NOT read)`,
+		projectID, kind, message).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("check notification: %w", err)
+	}
+	return exists, nil
+}
+
+// ListNotifications returns a project's notifications, newest first.
+func (s *Store) ListNotifications(ctx context.Context, projectID int64, unreadOnly bool) ([]*models.Notification, error) {
+	query := `SELECT id, project_id, kind, message, read, created_at
+		 FROM notifications WHERE project_id = $1`
+	if unreadOnly {
+		query += ` AND NOT read`
+	}
+	query += ` ORDER BY id DESC LIMIT 200`
+	rows, err := s.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.Notification
+	for rows.Next() {
+		n := &models.Notification{}
+		if err := rows.Scan(&n.ID, &n.ProjectID, &n.Kind, &n.Message, &n.Read, &n.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, n)
+	}
+	return out, rows.Err()
+}
+
+// MarkNotificationRead flags one notification as read.
+func (s *Store) MarkNotificationRead(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `UPDATE notifications SET read = true WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("mark notification read: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
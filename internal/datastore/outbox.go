@@ -0,0 +1,53 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ObjectCleanup is one pending object-store release. Rows are written in the
+// same transaction as the deletion that orphaned the object, so MinIO
+// cleanup can happen asynchronously without ever being lost.
+type ObjectCleanup struct {
+	ID         int64
+	ObjectKey  string
+	Attempts   int64
+	LastError  string
+	EnqueuedAt time.Time
+}
+
+// ListObjectCleanups returns up to limit pending cleanups, oldest first.
+func (s *Store) ListObjectCleanups(ctx context.Context, limit int) ([]*ObjectCleanup, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, object_key, attempts, last_error, enqueued_at
+		 FROM object_cleanup_outbox ORDER BY id LIMIT $1`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list object cleanups: %w", err)
+	}
+	defer rows.Close()
+	var out []*ObjectCleanup
+	for rows.Next() {
+		e := &ObjectCleanup{}
+		if err := rows.Scan(&e.ID, &e.ObjectKey, &e.Attempts, &e.LastError, &e.EnqueuedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
+
+// DeleteObjectCleanup removes a completed outbox entry.
+func (s *Store) DeleteObjectCleanup(ctx context.Context, id int64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM object_cleanup_outbox WHERE id = $1`, id)
+	return err
+}
+
+// MarkObjectCleanupFailed records a failed attempt; the entry stays queued
+// and the sweeper retries it on a later pass.
+func (s *Store) MarkObjectCleanupFailed(ctx context.Context, id int64, cause string) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE object_cleanup_outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`,
+		id, cause)
+	return err
+}
@@ -0,0 +1,38 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// GetUserPreferences returns a user's preference blob, or an empty object
+// for users that have never saved any.
+func (s *Store) GetUserPreferences(ctx context.Context, username string) (json.RawMessage, error) {
+	var prefs json.RawMessage
+	err := s.reader().QueryRowContext(ctx,
+		`SELECT preferences FROM user_preferences WHERE username = $1`, username,
+	).Scan(&prefs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return json.RawMessage("{}"), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get preferences for %s: %w", username, err)
+	}
+	return prefs, nil
+}
+
+// UpsertUserPreferences replaces a user's preference blob.
+func (s *Store) UpsertUserPreferences(ctx context.Context, username string, prefs json.RawMessage) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO user_preferences (username, preferences)
+		 VALUES ($1, $2)
+		 ON CONFLICT (username) DO UPDATE SET preferences = $2, updated_at = now()`,
+		username, []byte(prefs))
+	if err != nil {
+		return fmt.Errorf("save preferences for %s: %w", username, err)
+	}
+	return nil
+}
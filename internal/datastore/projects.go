@@ -0,0 +1,86 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// ErrNotFound is returned by Get* methods when no row matches.
+var ErrNotFound = errors.New("not found")
+
+// CreateProject inserts a project and returns it with the assigned ID.
+func (s *Store) CreateProject(ctx context.Context, p *models.Project) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO projects (name, description) VALUES ($1, $2)
+		 RETURNING id, time_zone, created_at`,
+		p.Name, p.Description,
+	).Scan(&p.ID, &p.TimeZone, &p.CreatedAt)
+}
+
+// GetProject fetches one project by ID.
+func (s *Store) GetProject(ctx context.Context, id int64) (*models.Project, error) {
+	p := &models.Project{}
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id, name, description, storage_quota_bytes, time_zone, created_at
+		 FROM projects WHERE id = $1`, id,
+	).Scan(&p.ID, &p.Name, &p.Description, &p.StorageQuotaBytes, &p.TimeZone, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get project %d: %w", id, err)
+	}
+	return p, nil
+}
+
+// ListProjects returns all projects ordered by creation time.
+func (s *Store) ListProjects(ctx context.Context) ([]*models.Project, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, name, description, storage_quota_bytes, time_zone, created_at
+		 FROM projects ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.Project
+	for rows.Next() {
+		p := &models.Project{}
+		if err := rows.Scan(&p.ID, &p.Name, &p.Description, &p.StorageQuotaBytes, &p.TimeZone, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+// SetProjectStorageQuota updates a project's soft storage quota; 0 removes
+// it.
+func (s *Store) SetProjectStorageQuota(ctx context.Context, id, quotaBytes int64) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE projects SET storage_quota_bytes = $2 WHERE id = $1`, id, quotaBytes)
+	if err != nil {
+		return fmt.Errorf("set storage quota for project %d: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SetProjectTimeZone updates the IANA time zone schedules and report
+// timestamps use for a project. The caller validates the zone name.
+func (s *Store) SetProjectTimeZone(ctx context.Context, id int64, zone string) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE projects SET time_zone = $2 WHERE id = $1`, id, zone)
+	if err != nil {
+		return fmt.Errorf("set time zone for project %d: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const qaReviewColumns = `id, job_id, result_id, vendor_config_id, reviewer,
+	status, score, notes, created_at, completed_at`
+
+func scanQAReview(row interface{ Scan(...any) error }) (*models.QAReview, error) {
+	review := &models.QAReview{}
+	var score sql.NullFloat64
+	var completed sql.NullTime
+	err := row.Scan(&review.ID, &review.JobID, &review.ResultID, &review.VendorConfigID,
+		&review.Reviewer, &review.Status, &score, &review.Notes,
+		&review.CreatedAt, &completed)
+	if err != nil {
+		return nil, err
+	}
+	if score.Valid {
+		review.Score = &score.Float64
+	}
+	if completed.Valid {
+		review.CompletedAt = &completed.Time
+	}
+	return review, nil
+}
+
+// CreateQAReview assigns one sampled result to a reviewer.
+func (s *Store) CreateQAReview(ctx context.Context, review *models.QAReview) error {
+	review.Status = models.QAReviewPending
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO qa_reviews (job_id, result_id, vendor_config_id, reviewer, status)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING id, created_at`,
+		review.JobID, review.ResultID, review.VendorConfigID, review.Reviewer, review.Status,
+	).Scan(&review.ID, &review.CreatedAt)
+}
+
+// ListQAReviewsByJob returns a job's review assignments in creation order.
+func (s *Store) ListQAReviewsByJob(ctx context.Context, jobID int64) ([]*models.QAReview, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+qaReviewColumns+` FROM qa_reviews WHERE job_id = $1 ORDER BY id`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list qa reviews for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	var out []*models.QAReview
+	for rows.Next() {
+		review, err := scanQAReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, review)
+	}
+	return out, rows.Err()
+}
+
+// CompleteQAReview records a reviewer's verdict. Completing an already
+// completed review overwrites the previous verdict.
+func (s *Store) CompleteQAReview(ctx context.Context, id int64, score float64, notes string) (*models.QAReview, error) {
+	review, err := scanQAReview(s.db.QueryRowContext(ctx,
+		`UPDATE qa_reviews
+		 SET status = $2, score = $3, notes = $4, completed_at = now()
+		 WHERE id = $1
+		 RETURNING `+qaReviewColumns,
+		id, models.QAReviewCompleted, score, notes))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("complete qa review %d: %w", id, err)
+	}
+	return review, nil
+}
@@ -0,0 +1,555 @@
+package datastore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const asrResultColumns = `id, job_id, asr_test_case_id, vendor_config_id,
+	recognized_text, post_processed_text, wer, cer, confidence, latency_ms, vendor_latency_ms,
+	audio_bytes, response_bytes,
+	region, revision, segment_scores, extra_metrics, raw_vendor_response, raw_vendor_response_gz,
+	error_code, error_message, created_at`
+
+func scanASRResult(row interface{ Scan(...any) error }) (*models.ASRResult, error) {
+	r := &models.ASRResult{}
+	var segmentScores, extraMetrics, rawGz []byte
+	err := row.Scan(&r.ID, &r.JobID, &r.ASRTestCaseID, &r.VendorConfigID,
+		&r.RecognizedText, &r.PostProcessedText, &r.WER, &r.CER, &r.Confidence, &r.LatencyMS,
+		&r.VendorLatencyMS, &r.AudioBytes, &r.ResponseBytes,
+		&r.Region, &r.Revision, &segmentScores, &extraMetrics, &r.RawVendorResponse,
+		&rawGz, &r.ErrorCode, &r.ErrorMessage, &r.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(segmentScores) > 0 {
+		if err := json.Unmarshal(segmentScores, &r.SegmentScores); err != nil {
+			return nil, fmt.Errorf("decode segment scores for result %d: %w", r.ID, err)
+		}
+	}
+	if len(extraMetrics) > 0 {
+		if err := json.Unmarshal(extraMetrics, &r.ExtraMetrics); err != nil {
+			return nil, fmt.Errorf("decode extra metrics for result %d: %w", r.ID, err)
+		}
+	}
+	// Transparently inflate compressed blobs so callers never see the
+	// storage representation.
+	if len(r.RawVendorResponse) == 0 && len(rawGz) > 0 {
+		raw, err := gunzipBytes(rawGz)
+		if err != nil {
+			return nil, fmt.Errorf("decompress raw response for result %d: %w", r.ID, err)
+		}
+		r.RawVendorResponse = raw
+	}
+	return r, nil
+}
+
+// InsertASRResult stores one evaluation result. Results are unique per
+// (job, test case, vendor config, region, revision); a retry that races a
+// previous attempt overwrites that row instead of creating an ambiguous
+// duplicate that would skew averages. Depending on the compression setting
+// the raw vendor response is written either as JSONB or as a gzipped bytea;
+// reads accept both.
+func (s *Store) InsertASRResult(ctx context.Context, r *models.ASRResult) error {
+	var raw, rawGz, segmentScores, extraMetrics any
+	if len(r.RawVendorResponse) > 0 {
+		if s.compressRaw {
+			gz, err := gzipBytes(r.RawVendorResponse)
+			if err != nil {
+				return fmt.Errorf("compress raw response: %w", err)
+			}
+			rawGz = gz
+		} else {
+			raw = []byte(r.RawVendorResponse)
+		}
+	}
+	if len(r.SegmentScores) > 0 {
+		b, err := json.Marshal(r.SegmentScores)
+		if err != nil {
+			return fmt.Errorf("encode segment scores: %w", err)
+		}
+		segmentScores = b
+	}
+	if len(r.ExtraMetrics) > 0 {
+		b, err := json.Marshal(r.ExtraMetrics)
+		if err != nil {
+			return fmt.Errorf("encode extra metrics: %w", err)
+		}
+		extraMetrics = b
+	}
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO asr_results
+			(job_id, asr_test_case_id, vendor_config_id, recognized_text, post_processed_text,
+			 wer, cer, confidence, latency_ms, vendor_latency_ms, audio_bytes, response_bytes,
+			 region, revision, segment_scores,
+			 extra_metrics, raw_vendor_response, raw_vendor_response_gz, error_code, error_message)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		 ON CONFLICT (job_id, asr_test_case_id, vendor_config_id, region, revision)
+		 DO UPDATE SET
+			recognized_text = EXCLUDED.recognized_text,
+			post_processed_text = EXCLUDED.post_processed_text,
+			wer = EXCLUDED.wer,
+			cer = EXCLUDED.cer,
+			confidence = EXCLUDED.confidence,
+			latency_ms = EXCLUDED.latency_ms,
+			vendor_latency_ms = EXCLUDED.vendor_latency_ms,
+			audio_bytes = EXCLUDED.audio_bytes,
+			response_bytes = EXCLUDED.response_bytes,
+			segment_scores = EXCLUDED.segment_scores,
+			extra_metrics = EXCLUDED.extra_metrics,
+			raw_vendor_response = EXCLUDED.raw_vendor_response,
+			raw_vendor_response_gz = EXCLUDED.raw_vendor_response_gz,
+			error_code = EXCLUDED.error_code,
+			error_message = EXCLUDED.error_message
+		 RETURNING id, created_at`,
+		r.JobID, r.ASRTestCaseID, r.VendorConfigID, r.RecognizedText, r.PostProcessedText,
+		r.WER, r.CER, r.Confidence, r.LatencyMS, r.VendorLatencyMS, r.AudioBytes, r.ResponseBytes,
+		r.Region, r.Revision,
+		segmentScores, extraMetrics, raw, rawGz, r.ErrorCode, r.ErrorMessage,
+	).Scan(&r.ID, &r.CreatedAt)
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func gunzipBytes(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// GetASRResult fetches one result row by id.
+func (s *Store) GetASRResult(ctx context.Context, id int64) (*models.ASRResult, error) {
+	r, err := scanASRResult(s.reader().QueryRowContext(ctx,
+		`SELECT `+asrResultColumns+` FROM asr_results WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get asr result %d: %w", id, err)
+	}
+	return r, nil
+}
+
+// ListASRResultsByJob returns every result row for a job. This is a heavy
+// read and is served from a replica when one is configured.
+func (s *Store) ListASRResultsByJob(ctx context.Context, jobID int64) ([]*models.ASRResult, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+asrResultColumns+` FROM asr_results WHERE job_id = $1 ORDER BY id`,
+		jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list asr results for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	var out []*models.ASRResult
+	for rows.Next() {
+		r, err := scanASRResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// ResultQuery narrows a job's result listing to the problematic subset an
+// analyst cares about. Zero-valued fields are ignored.
+type ResultQuery struct {
+	VendorConfigID int64   // only this vendor config
+	ErrorCode      string  // exact error code match, e.g. TIMEOUT
+	MinWER         float64 // wer strictly above this
+	MinLatencyMS   int64   // latency_ms strictly above this
+}
+
+// QueryASRResultsByJob returns a job's results matching the query, in id
+// order. Served from a replica when one is configured.
+func (s *Store) QueryASRResultsByJob(ctx context.Context, jobID int64, q ResultQuery) ([]*models.ASRResult, error) {
+	where := "job_id = $1"
+	args := []any{jobID}
+	cond := func(clause string, value any) {
+		args = append(args, value)
+		where += fmt.Sprintf(" AND "+clause, len(args))
+	}
+	if q.VendorConfigID > 0 {
+		cond("vendor_config_id = $%d", q.VendorConfigID)
+	}
+	if q.ErrorCode != "" {
+		cond("error_code = $%d", q.ErrorCode)
+	}
+	if q.MinWER > 0 {
+		cond("wer > $%d", q.MinWER)
+	}
+	if q.MinLatencyMS > 0 {
+		cond("latency_ms > $%d", q.MinLatencyMS)
+	}
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+asrResultColumns+` FROM asr_results WHERE `+where+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query asr results for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	var out []*models.ASRResult
+	for rows.Next() {
+		r, err := scanASRResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// ListASRResultsByJobPage returns up to limit result rows of a job with id
+// greater than afterID, in id order. Streaming exports page through a job's
+// results with it so no more than one page is held in memory at a time.
+// Served from a replica when one is configured.
+func (s *Store) ListASRResultsByJobPage(ctx context.Context, jobID, afterID int64, limit int) ([]*models.ASRResult, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+asrResultColumns+` FROM asr_results
+		 WHERE job_id = $1 AND id > $2 ORDER BY id LIMIT $3`,
+		jobID, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("page asr results for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	var out []*models.ASRResult
+	for rows.Next() {
+		r, err := scanASRResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// ListASRResultsByTestCase returns every historical result for one test case
+// across all jobs and vendors, oldest first, so regressions on a clip can be
+// traced over time. Served from a replica when one is configured.
+func (s *Store) ListASRResultsByTestCase(ctx context.Context, testCaseID int64) ([]*models.ASRResult, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+asrResultColumns+` FROM asr_results
+		 WHERE asr_test_case_id = $1 ORDER BY id`, testCaseID)
+	if err != nil {
+		return nil, fmt.Errorf("list asr results for test case %d: %w", testCaseID, err)
+	}
+	defer rows.Close()
+	var out []*models.ASRResult
+	for rows.Next() {
+		r, err := scanASRResult(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// DefaultWERCap is the WER above which a result counts as a catastrophic
+// outlier — hallucinated repetitions routinely score 5x-8x the reference
+// length. The plain mean keeps such results; the capped and trimmed means
+// bound their influence.
+const DefaultWERCap = 1.0
+
+// VendorSummary aggregates one vendor's results inside a job. The vendor
+// latency and network overhead means cover only results where the vendor
+// reported a processing duration; a vendor config maps to one endpoint or
+// region, so comparing summaries across configs shows regional overhead.
+// MeanWER is the untouched mean; CappedMeanWER clamps each result to the
+// cap, TrimmedMeanWER drops results above it, and MedianWER is robust to
+// outliers by construction.
+type VendorSummary struct {
+	VendorConfigID        int64   `json:"vendor_config_id"`
+	Region                string  `json:"region,omitempty"`
+	ResultCount           int64   `json:"result_count"`
+	ErrorCount            int64   `json:"error_count"`
+	MeanWER               float64 `json:"mean_wer"`
+	CappedMeanWER         float64 `json:"capped_mean_wer"`
+	TrimmedMeanWER        float64 `json:"trimmed_mean_wer"`
+	MedianWER             float64 `json:"median_wer"`
+	OutlierCount          int64   `json:"outlier_count"`
+	MeanCER               float64 `json:"mean_cer"`
+	MeanLatencyMS         float64 `json:"mean_latency_ms"`
+	MeanVendorLatencyMS   float64 `json:"mean_vendor_latency_ms"`
+	MeanNetworkOverheadMS float64 `json:"mean_network_overhead_ms"`
+}
+
+// SummarizeJob computes per-vendor aggregates for a job directly in SQL.
+// Errored results are counted but excluded from the metric means; werCap
+// bounds the capped and trimmed WER means. Served from a replica when one
+// is configured.
+func (s *Store) SummarizeJob(ctx context.Context, jobID int64, werCap float64) ([]*VendorSummary, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT vendor_config_id, region,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE error_code <> ''),
+			COALESCE(AVG(wer) FILTER (WHERE error_code = ''), 0),
+			COALESCE(AVG(LEAST(wer, $2)) FILTER (WHERE error_code = ''), 0),
+			COALESCE(AVG(wer) FILTER (WHERE error_code = '' AND wer <= $2), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY wer) FILTER (WHERE error_code = ''), 0),
+			COUNT(*) FILTER (WHERE error_code = '' AND wer > $2),
+			COALESCE(AVG(cer) FILTER (WHERE error_code = ''), 0),
+			COALESCE(AVG(latency_ms) FILTER (WHERE error_code = ''), 0),
+			COALESCE(AVG(vendor_latency_ms) FILTER (WHERE error_code = '' AND vendor_latency_ms > 0), 0),
+			COALESCE(AVG(latency_ms - vendor_latency_ms) FILTER (WHERE error_code = '' AND vendor_latency_ms > 0), 0)
+		 FROM asr_results WHERE job_id = $1
+		 GROUP BY vendor_config_id, region ORDER BY vendor_config_id, region`, jobID, werCap)
+	if err != nil {
+		return nil, fmt.Errorf("summarize job %d: %w", jobID, err)
+	}
+	return scanVendorSummaries(rows)
+}
+
+// SummarizeJobTag computes the same per-vendor aggregates restricted to the
+// job's results whose test case carries the given tag. Used for slices like
+// the code-switched breakdown.
+func (s *Store) SummarizeJobTag(ctx context.Context, jobID int64, tag string, werCap float64) ([]*VendorSummary, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT r.vendor_config_id, r.region,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE r.error_code <> ''),
+			COALESCE(AVG(r.wer) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(LEAST(r.wer, $3)) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.wer) FILTER (WHERE r.error_code = '' AND r.wer <= $3), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY r.wer) FILTER (WHERE r.error_code = ''), 0),
+			COUNT(*) FILTER (WHERE r.error_code = '' AND r.wer > $3),
+			COALESCE(AVG(r.cer) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.latency_ms) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.vendor_latency_ms) FILTER (WHERE r.error_code = '' AND r.vendor_latency_ms > 0), 0),
+			COALESCE(AVG(r.latency_ms - r.vendor_latency_ms) FILTER (WHERE r.error_code = '' AND r.vendor_latency_ms > 0), 0)
+		 FROM asr_results r
+		 JOIN asr_test_cases t ON t.id = r.asr_test_case_id
+		 WHERE r.job_id = $1 AND $2 = ANY(t.tags)
+		 GROUP BY r.vendor_config_id, r.region ORDER BY r.vendor_config_id, r.region`, jobID, tag, werCap)
+	if err != nil {
+		return nil, fmt.Errorf("summarize job %d tag %q: %w", jobID, tag, err)
+	}
+	return scanVendorSummaries(rows)
+}
+
+// AttributeSlice is the per-vendor breakdown over the job's results whose
+// test case speaker carries one value of an attribute (e.g. accent "scottish").
+type AttributeSlice struct {
+	Value   string           `json:"value"`
+	Vendors []*VendorSummary `json:"vendors"`
+}
+
+// SummarizeJobSpeakerAttr computes per-vendor aggregates grouped by one
+// speaker attribute (a SpeakerInfo JSON key such as "gender" or "accent").
+// Results whose test case records no value for the attribute are skipped, so
+// partially annotated datasets still get a breakdown over the annotated part.
+func (s *Store) SummarizeJobSpeakerAttr(ctx context.Context, jobID int64, attr string, werCap float64) ([]*AttributeSlice, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT t.speaker->>$2, r.vendor_config_id, r.region,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE r.error_code <> ''),
+			COALESCE(AVG(r.wer) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(LEAST(r.wer, $3)) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.wer) FILTER (WHERE r.error_code = '' AND r.wer <= $3), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY r.wer) FILTER (WHERE r.error_code = ''), 0),
+			COUNT(*) FILTER (WHERE r.error_code = '' AND r.wer > $3),
+			COALESCE(AVG(r.cer) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.latency_ms) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.vendor_latency_ms) FILTER (WHERE r.error_code = '' AND r.vendor_latency_ms > 0), 0),
+			COALESCE(AVG(r.latency_ms - r.vendor_latency_ms) FILTER (WHERE r.error_code = '' AND r.vendor_latency_ms > 0), 0)
+		 FROM asr_results r
+		 JOIN asr_test_cases t ON t.id = r.asr_test_case_id
+		 WHERE r.job_id = $1 AND COALESCE(t.speaker->>$2, '') <> ''
+		 GROUP BY t.speaker->>$2, r.vendor_config_id, r.region
+		 ORDER BY t.speaker->>$2, r.vendor_config_id, r.region`, jobID, attr, werCap)
+	if err != nil {
+		return nil, fmt.Errorf("summarize job %d by speaker %s: %w", jobID, attr, err)
+	}
+	return scanAttributeSlices(rows)
+}
+
+// SummarizeJobDomain computes per-vendor aggregates grouped by the test
+// cases' content domain. Unclassified cases (empty domain) are skipped; the
+// overall summary still covers them.
+func (s *Store) SummarizeJobDomain(ctx context.Context, jobID int64, werCap float64) ([]*AttributeSlice, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT t.domain, r.vendor_config_id, r.region,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE r.error_code <> ''),
+			COALESCE(AVG(r.wer) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(LEAST(r.wer, $2)) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.wer) FILTER (WHERE r.error_code = '' AND r.wer <= $2), 0),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY r.wer) FILTER (WHERE r.error_code = ''), 0),
+			COUNT(*) FILTER (WHERE r.error_code = '' AND r.wer > $2),
+			COALESCE(AVG(r.cer) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.latency_ms) FILTER (WHERE r.error_code = ''), 0),
+			COALESCE(AVG(r.vendor_latency_ms) FILTER (WHERE r.error_code = '' AND r.vendor_latency_ms > 0), 0),
+			COALESCE(AVG(r.latency_ms - r.vendor_latency_ms) FILTER (WHERE r.error_code = '' AND r.vendor_latency_ms > 0), 0)
+		 FROM asr_results r
+		 JOIN asr_test_cases t ON t.id = r.asr_test_case_id
+		 WHERE r.job_id = $1 AND t.domain <> ''
+		 GROUP BY t.domain, r.vendor_config_id, r.region
+		 ORDER BY t.domain, r.vendor_config_id, r.region`, jobID, werCap)
+	if err != nil {
+		return nil, fmt.Errorf("summarize job %d by domain: %w", jobID, err)
+	}
+	return scanAttributeSlices(rows)
+}
+
+func scanAttributeSlices(rows *sql.Rows) ([]*AttributeSlice, error) {
+	defer rows.Close()
+	var out []*AttributeSlice
+	for rows.Next() {
+		var value string
+		vs := &VendorSummary{}
+		if err := rows.Scan(&value, &vs.VendorConfigID, &vs.Region, &vs.ResultCount, &vs.ErrorCount,
+			&vs.MeanWER, &vs.CappedMeanWER, &vs.TrimmedMeanWER, &vs.MedianWER, &vs.OutlierCount,
+			&vs.MeanCER, &vs.MeanLatencyMS,
+			&vs.MeanVendorLatencyMS, &vs.MeanNetworkOverheadMS); err != nil {
+			return nil, err
+		}
+		if len(out) == 0 || out[len(out)-1].Value != value {
+			out = append(out, &AttributeSlice{Value: value})
+		}
+		slice := out[len(out)-1]
+		slice.Vendors = append(slice.Vendors, vs)
+	}
+	return out, rows.Err()
+}
+
+// ExtraMetricMean is one vendor's average over a named extra metric inside
+// a job, covering only the results that recorded it.
+type ExtraMetricMean struct {
+	VendorConfigID int64
+	Region         string
+	Mean           float64
+	Count          int64
+}
+
+// MeanExtraMetricByVendor averages a named extra metric per vendor across a
+// job's successful results.
+func (s *Store) MeanExtraMetricByVendor(ctx context.Context, jobID int64, metric string) ([]*ExtraMetricMean, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT vendor_config_id, region,
+			AVG((extra_metrics->>$2)::double precision), COUNT(*)
+		 FROM asr_results
+		 WHERE job_id = $1 AND error_code = '' AND extra_metrics ? $2
+		 GROUP BY vendor_config_id, region ORDER BY vendor_config_id, region`, jobID, metric)
+	if err != nil {
+		return nil, fmt.Errorf("mean extra metric %q for job %d: %w", metric, jobID, err)
+	}
+	defer rows.Close()
+	var out []*ExtraMetricMean
+	for rows.Next() {
+		m := &ExtraMetricMean{}
+		if err := rows.Scan(&m.VendorConfigID, &m.Region, &m.Mean, &m.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func scanVendorSummaries(rows *sql.Rows) ([]*VendorSummary, error) {
+	defer rows.Close()
+	var out []*VendorSummary
+	for rows.Next() {
+		vs := &VendorSummary{}
+		if err := rows.Scan(&vs.VendorConfigID, &vs.Region, &vs.ResultCount, &vs.ErrorCount,
+			&vs.MeanWER, &vs.CappedMeanWER, &vs.TrimmedMeanWER, &vs.MedianWER, &vs.OutlierCount,
+			&vs.MeanCER, &vs.MeanLatencyMS,
+			&vs.MeanVendorLatencyMS, &vs.MeanNetworkOverheadMS); err != nil {
+			return nil, err
+		}
+		out = append(out, vs)
+	}
+	return out, rows.Err()
+}
+
+// WEROutlier identifies one result whose WER exceeded the cap, so reviewers
+// can inspect the catastrophic cases the trimmed means exclude.
+type WEROutlier struct {
+	ResultID       int64   `json:"result_id"`
+	ASRTestCaseID  int64   `json:"asr_test_case_id"`
+	VendorConfigID int64   `json:"vendor_config_id"`
+	Region         string  `json:"region,omitempty"`
+	WER            float64 `json:"wer"`
+}
+
+// ListWEROutliers returns a job's worst results above the cap, worst first.
+func (s *Store) ListWEROutliers(ctx context.Context, jobID int64, werCap float64, limit int) ([]*WEROutlier, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT id, asr_test_case_id, vendor_config_id, region, wer
+		 FROM asr_results
+		 WHERE job_id = $1 AND error_code = '' AND wer > $2
+		 ORDER BY wer DESC, id LIMIT $3`, jobID, werCap, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list WER outliers for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	var out []*WEROutlier
+	for rows.Next() {
+		o := &WEROutlier{}
+		if err := rows.Scan(&o.ResultID, &o.ASRTestCaseID, &o.VendorConfigID, &o.Region, &o.WER); err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// CountLanguageMismatches counts a job's results flagged by the language
+// sanity check (extra metric "language_mismatch").
+func (s *Store) CountLanguageMismatches(ctx context.Context, jobID int64) (int64, error) {
+	var n int64
+	err := s.reader().QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM asr_results
+		 WHERE job_id = $1 AND (extra_metrics->>'language_mismatch')::float > 0`,
+		jobID).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count language mismatches for job %d: %w", jobID, err)
+	}
+	return n, nil
+}
+
+// UpdateASRResultScores rewrites a result's derived metric fields after its
+// test case's ground truth changed; the recognition fields stay untouched.
+func (s *Store) UpdateASRResultScores(ctx context.Context, r *models.ASRResult) error {
+	segmentScores, err := jsonOrNull(r.SegmentScores)
+	if err != nil {
+		return fmt.Errorf("encode segment scores: %w", err)
+	}
+	var extraMetrics any
+	if len(r.ExtraMetrics) > 0 {
+		b, err := json.Marshal(r.ExtraMetrics)
+		if err != nil {
+			return fmt.Errorf("encode extra metrics: %w", err)
+		}
+		extraMetrics = b
+	}
+	_, err = s.db.ExecContext(ctx,
+		`UPDATE asr_results
+		 SET post_processed_text = $2, wer = $3, cer = $4,
+		     segment_scores = $5, extra_metrics = $6
+		 WHERE id = $1`,
+		r.ID, r.PostProcessedText, r.WER, r.CER, segmentScores, extraMetrics)
+	if err != nil {
+		return fmt.Errorf("update scores for result %d: %w", r.ID, err)
+	}
+	return nil
+}
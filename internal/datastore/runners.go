@@ -0,0 +1,155 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateRunnerAgent registers a remote runner. The caller generates the
+// token; it is stored verbatim and never listed again.
+func (s *Store) CreateRunnerAgent(ctx context.Context, a *models.RunnerAgent) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO runner_agents (name, pool, token) VALUES ($1, $2, $3)
+		 RETURNING id, created_at`,
+		a.Name, a.Pool, a.Token,
+	).Scan(&a.ID, &a.CreatedAt)
+}
+
+// GetRunnerAgentByToken authenticates an agent by its bearer token and
+// stamps last_seen_at as a heartbeat.
+func (s *Store) GetRunnerAgentByToken(ctx context.Context, token string) (*models.RunnerAgent, error) {
+	a := &models.RunnerAgent{}
+	var lastSeen sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		`UPDATE runner_agents SET last_seen_at = now() WHERE token = $1
+		 RETURNING id, name, pool, created_at, last_seen_at`,
+		token,
+	).Scan(&a.ID, &a.Name, &a.Pool, &a.CreatedAt, &lastSeen)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("authenticate runner agent: %w", err)
+	}
+	if lastSeen.Valid {
+		a.LastSeenAt = &lastSeen.Time
+	}
+	return a, nil
+}
+
+// ListRunnerAgents returns every registered agent, without tokens.
+func (s *Store) ListRunnerAgents(ctx context.Context) ([]*models.RunnerAgent, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id, name, pool, created_at, last_seen_at FROM runner_agents ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("list runner agents: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.RunnerAgent
+	for rows.Next() {
+		a := &models.RunnerAgent{}
+		var lastSeen sql.NullTime
+		if err := rows.Scan(&a.ID, &a.Name, &a.Pool, &a.CreatedAt, &lastSeen); err != nil {
+			return nil, err
+		}
+		if lastSeen.Valid {
+			a.LastSeenAt = &lastSeen.Time
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// CreateRunnerTask enqueues one pair for a runner pool.
+func (s *Store) CreateRunnerTask(ctx context.Context, t *models.RunnerTask) error {
+	t.Status = models.RunnerTaskPending
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO runner_tasks (job_id, asr_test_case_id, vendor_config_id, pool, status)
+		 VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`,
+		t.JobID, t.ASRTestCaseID, t.VendorConfigID, t.Pool, t.Status,
+	).Scan(&t.ID, &t.CreatedAt)
+}
+
+const runnerTaskColumns = `id, job_id, asr_test_case_id, vendor_config_id, pool,
+	status, leased_by, leased_at, completed_at, created_at`
+
+func scanRunnerTask(row interface{ Scan(...any) error }) (*models.RunnerTask, error) {
+	t := &models.RunnerTask{}
+	var leasedBy sql.NullInt64
+	var leasedAt, completedAt sql.NullTime
+	err := row.Scan(&t.ID, &t.JobID, &t.ASRTestCaseID, &t.VendorConfigID, &t.Pool,
+		&t.Status, &leasedBy, &leasedAt, &completedAt, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if leasedBy.Valid {
+		t.LeasedBy = &leasedBy.Int64
+	}
+	if leasedAt.Valid {
+		t.LeasedAt = &leasedAt.Time
+	}
+	if completedAt.Valid {
+		t.CompletedAt = &completedAt.Time
+	}
+	return t, nil
+}
+
+// LeaseRunnerTasks atomically assigns up to max pending tasks in the pool to
+// the agent. SKIP LOCKED keeps concurrent agents from double-leasing.
+func (s *Store) LeaseRunnerTasks(ctx context.Context, pool string, agentID int64, max int) ([]*models.RunnerTask, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`UPDATE runner_tasks SET status = $3, leased_by = $4, leased_at = now()
+		 WHERE id IN (
+			SELECT id FROM runner_tasks
+			WHERE pool = $1 AND status = $2
+			ORDER BY id LIMIT $5
+			FOR UPDATE SKIP LOCKED)
+		 RETURNING `+runnerTaskColumns,
+		pool, models.RunnerTaskPending, models.RunnerTaskLeased, agentID, max)
+	if err != nil {
+		return nil, fmt.Errorf("lease runner tasks: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.RunnerTask
+	for rows.Next() {
+		t, err := scanRunnerTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// CompleteRunnerTask marks a task DONE, verifying it is leased by the calling
+// agent, and returns it.
+func (s *Store) CompleteRunnerTask(ctx context.Context, id, agentID int64) (*models.RunnerTask, error) {
+	t, err := scanRunnerTask(s.db.QueryRowContext(ctx,
+		`UPDATE runner_tasks SET status = $3, completed_at = now()
+		 WHERE id = $1 AND leased_by = $2 AND status = $4
+		 RETURNING `+runnerTaskColumns,
+		id, agentID, models.RunnerTaskDone, models.RunnerTaskLeased))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("complete runner task %d: %w", id, err)
+	}
+	return t, nil
+}
+
+// CountOpenRunnerTasks reports how many of a job's tasks are not DONE yet.
+func (s *Store) CountOpenRunnerTasks(ctx context.Context, jobID int64) (int64, error) {
+	var n int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM runner_tasks WHERE job_id = $1 AND status <> $2`,
+		jobID, models.RunnerTaskDone).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count open runner tasks for job %d: %w", jobID, err)
+	}
+	return n, nil
+}
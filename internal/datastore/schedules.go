@@ -0,0 +1,95 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const jobScheduleColumns = `id, project_id, name, spec, job_template, enabled,
+	next_run_at, created_at`
+
+func scanJobSchedule(row interface{ Scan(...any) error }) (*models.JobSchedule, error) {
+	sc := &models.JobSchedule{}
+	err := row.Scan(&sc.ID, &sc.ProjectID, &sc.Name, &sc.Spec, &sc.JobTemplate,
+		&sc.Enabled, &sc.NextRunAt, &sc.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// CreateJobSchedule inserts a schedule and fills in the generated fields.
+func (s *Store) CreateJobSchedule(ctx context.Context, sc *models.JobSchedule) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO job_schedules (project_id, name, spec, job_template, enabled, next_run_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id, created_at`,
+		sc.ProjectID, sc.Name, sc.Spec, []byte(sc.JobTemplate), sc.Enabled, sc.NextRunAt,
+	).Scan(&sc.ID, &sc.CreatedAt)
+}
+
+// ListJobSchedules returns a project's schedules.
+func (s *Store) ListJobSchedules(ctx context.Context, projectID int64) ([]*models.JobSchedule, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+jobScheduleColumns+` FROM job_schedules
+		 WHERE project_id = $1 ORDER BY id`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list job schedules: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.JobSchedule
+	for rows.Next() {
+		sc, err := scanJobSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sc)
+	}
+	return out, rows.Err()
+}
+
+// ListDueJobSchedules returns enabled schedules whose next run is at or
+// before now.
+func (s *Store) ListDueJobSchedules(ctx context.Context, now time.Time) ([]*models.JobSchedule, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+jobScheduleColumns+` FROM job_schedules
+		 WHERE enabled AND next_run_at <= $1 ORDER BY next_run_at`, now)
+	if err != nil {
+		return nil, fmt.Errorf("list due job schedules: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.JobSchedule
+	for rows.Next() {
+		sc, err := scanJobSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, sc)
+	}
+	return out, rows.Err()
+}
+
+// SetJobScheduleNextRun advances a schedule to its next firing time.
+func (s *Store) SetJobScheduleNextRun(ctx context.Context, id int64, next time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`UPDATE job_schedules SET next_run_at = $2 WHERE id = $1`, id, next)
+	if err != nil {
+		return fmt.Errorf("set next run for schedule %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteJobSchedule removes a schedule.
+func (s *Store) DeleteJobSchedule(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM job_schedules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete job schedule %d: %w", id, err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package datastore
+
+// schemaStatements is applied in order by EnsureSchema. Append new
+// statements at the end; never edit earlier ones, since deployed databases
+// have already executed them.
+var schemaStatements = []string{
+	`CREATE TABLE IF NOT EXISTS projects (
+		id BIGSERIAL PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		description TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE IF NOT EXISTS vendor_configs (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		api_type TEXT NOT NULL,
+		api_key TEXT NOT NULL DEFAULT '',
+		api_secret TEXT NOT NULL DEFAULT '',
+		api_endpoint TEXT NOT NULL DEFAULT '',
+		other_configs JSONB NOT NULL DEFAULT '{}',
+		is_active BOOLEAN NOT NULL DEFAULT true,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		UNIQUE (project_id, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS asr_test_cases (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		language TEXT NOT NULL,
+		audio_object_key TEXT NOT NULL,
+		audio_format TEXT NOT NULL DEFAULT 'wav',
+		ground_truth TEXT NOT NULL,
+		tags TEXT[] NOT NULL DEFAULT '{}',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE IF NOT EXISTS evaluation_jobs (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		api_type TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'PENDING',
+		language TEXT NOT NULL DEFAULT '',
+		test_case_ids BIGINT[] NOT NULL DEFAULT '{}',
+		vendor_config_ids BIGINT[] NOT NULL DEFAULT '{}',
+		parameters JSONB NOT NULL DEFAULT '{}',
+		error_message TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		started_at TIMESTAMPTZ,
+		completed_at TIMESTAMPTZ
+	)`,
+	`CREATE TABLE IF NOT EXISTS asr_results (
+		id BIGSERIAL PRIMARY KEY,
+		job_id BIGINT NOT NULL REFERENCES evaluation_jobs(id),
+		asr_test_case_id BIGINT NOT NULL REFERENCES asr_test_cases(id),
+		vendor_config_id BIGINT NOT NULL REFERENCES vendor_configs(id),
+		recognized_text TEXT NOT NULL DEFAULT '',
+		wer DOUBLE PRECISION NOT NULL DEFAULT 0,
+		cer DOUBLE PRECISION NOT NULL DEFAULT 0,
+		latency_ms BIGINT NOT NULL DEFAULT 0,
+		raw_vendor_response JSONB,
+		error_code TEXT NOT NULL DEFAULT '',
+		error_message TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_asr_results_job ON asr_results (job_id)`,
+	`CREATE TABLE IF NOT EXISTS budgets (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		vendor_config_id BIGINT REFERENCES vendor_configs(id),
+		monthly_limit_usd DOUBLE PRECISION NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_scope
+		ON budgets (project_id, COALESCE(vendor_config_id, 0))`,
+	`CREATE TABLE IF NOT EXISTS spend_records (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		vendor_config_id BIGINT NOT NULL REFERENCES vendor_configs(id),
+		job_id BIGINT NOT NULL REFERENCES evaluation_jobs(id),
+		amount_usd DOUBLE PRECISION NOT NULL,
+		month TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_spend_records_month
+		ON spend_records (project_id, vendor_config_id, month)`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS raw_vendor_response_gz BYTEA`,
+	`CREATE TABLE IF NOT EXISTS storage_objects (
+		object_key TEXT PRIMARY KEY,
+		sha256 TEXT NOT NULL,
+		size_bytes BIGINT NOT NULL,
+		ref_count BIGINT NOT NULL DEFAULT 1,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE IF NOT EXISTS notifications (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		kind TEXT NOT NULL,
+		message TEXT NOT NULL,
+		read BOOLEAN NOT NULL DEFAULT false,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`ALTER TABLE asr_test_cases ADD COLUMN IF NOT EXISTS segments JSONB`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS segment_scores JSONB`,
+	`ALTER TABLE asr_test_cases ADD COLUMN IF NOT EXISTS word_alignments JSONB`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS vendor_latency_ms BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS region TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE evaluation_jobs ADD COLUMN IF NOT EXISTS report JSONB`,
+	`CREATE TABLE IF NOT EXISTS runner_agents (
+		id BIGSERIAL PRIMARY KEY,
+		name TEXT NOT NULL,
+		pool TEXT NOT NULL,
+		token TEXT NOT NULL UNIQUE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		last_seen_at TIMESTAMPTZ
+	)`,
+	`CREATE TABLE IF NOT EXISTS runner_tasks (
+		id BIGSERIAL PRIMARY KEY,
+		job_id BIGINT NOT NULL REFERENCES evaluation_jobs(id),
+		asr_test_case_id BIGINT NOT NULL REFERENCES asr_test_cases(id),
+		vendor_config_id BIGINT NOT NULL REFERENCES vendor_configs(id),
+		pool TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'PENDING',
+		leased_by BIGINT REFERENCES runner_agents(id),
+		leased_at TIMESTAMPTZ,
+		completed_at TIMESTAMPTZ,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_runner_tasks_lease ON runner_tasks (pool, status)`,
+	`CREATE TABLE IF NOT EXISTS exports (
+		id BIGSERIAL PRIMARY KEY,
+		job_id BIGINT NOT NULL REFERENCES evaluation_jobs(id),
+		format TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'PENDING',
+		object_key TEXT NOT NULL DEFAULT '',
+		error_message TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		completed_at TIMESTAMPTZ
+	)`,
+	`CREATE TABLE IF NOT EXISTS report_snapshots (
+		id BIGSERIAL PRIMARY KEY,
+		job_id BIGINT NOT NULL REFERENCES evaluation_jobs(id),
+		summary JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`ALTER TABLE evaluation_jobs ADD COLUMN IF NOT EXISTS depends_on_job_ids BIGINT[] NOT NULL DEFAULT '{}'`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS post_processed_text TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS extra_metrics JSONB`,
+	`ALTER TABLE asr_test_cases ADD COLUMN IF NOT EXISTS entities JSONB`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS confidence DOUBLE PRECISION NOT NULL DEFAULT 0`,
+	`CREATE TABLE IF NOT EXISTS qa_reviews (
+		id BIGSERIAL PRIMARY KEY,
+		job_id BIGINT NOT NULL REFERENCES evaluation_jobs(id),
+		result_id BIGINT NOT NULL REFERENCES asr_results(id),
+		vendor_config_id BIGINT NOT NULL REFERENCES vendor_configs(id),
+		reviewer TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'PENDING',
+		score DOUBLE PRECISION,
+		notes TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		completed_at TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_qa_reviews_job ON qa_reviews (job_id)`,
+	`CREATE TABLE IF NOT EXISTS user_preferences (
+		username TEXT PRIMARY KEY,
+		preferences JSONB NOT NULL DEFAULT '{}',
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`ALTER TABLE projects ADD COLUMN IF NOT EXISTS storage_quota_bytes BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE projects ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC'`,
+	`CREATE TABLE IF NOT EXISTS job_schedules (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		spec TEXT NOT NULL,
+		job_template JSONB NOT NULL,
+		enabled BOOLEAN NOT NULL DEFAULT true,
+		next_run_at TIMESTAMPTZ NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE IF NOT EXISTS feature_flags (
+		id BIGSERIAL PRIMARY KEY,
+		name TEXT NOT NULL,
+		project_id BIGINT NOT NULL DEFAULT 0,
+		enabled BOOLEAN NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		UNIQUE (name, project_id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS job_logs (
+		id BIGSERIAL PRIMARY KEY,
+		job_id BIGINT NOT NULL REFERENCES evaluation_jobs(id),
+		level TEXT NOT NULL,
+		message TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_job_logs_job ON job_logs (job_id)`,
+	`ALTER TABLE evaluation_jobs ADD COLUMN IF NOT EXISTS last_heartbeat_at TIMESTAMPTZ`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0`,
+	// Databases that predate the unique index may hold duplicate rows from
+	// pre-resume requeues. Turn those into explicit revisions instead of
+	// deleting them, so result IDs referenced elsewhere stay valid.
+	`UPDATE asr_results r SET revision = d.rn - 1
+	 FROM (SELECT id, row_number() OVER (
+			PARTITION BY job_id, asr_test_case_id, vendor_config_id, region
+			ORDER BY id) AS rn
+	       FROM asr_results) d
+	 WHERE r.id = d.id AND d.rn > 1 AND r.revision = 0`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS uniq_asr_results_pair
+	 ON asr_results (job_id, asr_test_case_id, vendor_config_id, region, revision)`,
+	`ALTER TABLE evaluation_jobs ADD COLUMN IF NOT EXISTS progress_total BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE asr_test_cases ADD COLUMN IF NOT EXISTS duration_ms BIGINT NOT NULL DEFAULT 0`,
+	`CREATE TABLE IF NOT EXISTS ground_truth_revisions (
+		id BIGSERIAL PRIMARY KEY,
+		asr_test_case_id BIGINT NOT NULL REFERENCES asr_test_cases(id),
+		previous_text TEXT NOT NULL,
+		new_text TEXT NOT NULL,
+		source_result_id BIGINT REFERENCES asr_results(id),
+		editor TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE IF NOT EXISTS object_cleanup_outbox (
+		id BIGSERIAL PRIMARY KEY,
+		object_key TEXT NOT NULL,
+		attempts BIGINT NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		enqueued_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`ALTER TABLE asr_test_cases ADD COLUMN IF NOT EXISTS split TEXT NOT NULL DEFAULT ''`,
+	`CREATE TABLE IF NOT EXISTS dataset_snapshots (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		cases JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		UNIQUE (project_id, name)
+	)`,
+	`ALTER TABLE evaluation_jobs ADD COLUMN IF NOT EXISTS dataset_snapshot_id BIGINT REFERENCES dataset_snapshots(id)`,
+	`ALTER TABLE vendor_configs ADD COLUMN IF NOT EXISTS adapter_key TEXT NOT NULL DEFAULT ''`,
+	// Dataset exports are scoped to a project, not a job.
+	`ALTER TABLE exports ALTER COLUMN job_id DROP NOT NULL`,
+	`ALTER TABLE exports ADD COLUMN IF NOT EXISTS project_id BIGINT REFERENCES projects(id)`,
+	`ALTER TABLE asr_test_cases ADD COLUMN IF NOT EXISTS speaker JSONB`,
+	`ALTER TABLE asr_test_cases ADD COLUMN IF NOT EXISTS domain TEXT NOT NULL DEFAULT ''`,
+	`CREATE TABLE IF NOT EXISTS score_formulas (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		components JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		UNIQUE (project_id, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS alert_rules (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		metric TEXT NOT NULL,
+		vendor_config_id BIGINT REFERENCES vendor_configs(id),
+		tag TEXT NOT NULL DEFAULT '',
+		comparison TEXT NOT NULL,
+		threshold DOUBLE PRECISION NOT NULL,
+		window_jobs BIGINT NOT NULL DEFAULT 1,
+		enabled BOOLEAN NOT NULL DEFAULT true,
+		firing BOOLEAN NOT NULL DEFAULT false,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS audio_bytes BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE asr_results ADD COLUMN IF NOT EXISTS response_bytes BIGINT NOT NULL DEFAULT 0`,
+	`CREATE TABLE IF NOT EXISTS test_suites (
+		id BIGSERIAL PRIMARY KEY,
+		project_id BIGINT NOT NULL REFERENCES projects(id),
+		name TEXT NOT NULL,
+		description TEXT NOT NULL DEFAULT '',
+		test_case_ids BIGINT[] NOT NULL DEFAULT '{}',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		UNIQUE (project_id, name)
+	)`,
+	`ALTER TABLE evaluation_jobs ADD COLUMN IF NOT EXISTS manifest JSONB`,
+}
@@ -0,0 +1,86 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const scoreFormulaColumns = `id, project_id, name, components, created_at`
+
+func scanScoreFormula(row interface{ Scan(...any) error }) (*models.ScoreFormula, error) {
+	f := &models.ScoreFormula{}
+	var components []byte
+	err := row.Scan(&f.ID, &f.ProjectID, &f.Name, &components, &f.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(components, &f.Components); err != nil {
+		return nil, fmt.Errorf("decode components for score formula %d: %w", f.ID, err)
+	}
+	return f, nil
+}
+
+// CreateScoreFormula inserts a formula and fills in the generated fields.
+func (s *Store) CreateScoreFormula(ctx context.Context, f *models.ScoreFormula) error {
+	components, err := json.Marshal(f.Components)
+	if err != nil {
+		return fmt.Errorf("encode components: %w", err)
+	}
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO score_formulas (project_id, name, components)
+		 VALUES ($1, $2, $3)
+		 RETURNING id, created_at`,
+		f.ProjectID, f.Name, components,
+	).Scan(&f.ID, &f.CreatedAt)
+}
+
+// GetScoreFormula fetches one formula by ID.
+func (s *Store) GetScoreFormula(ctx context.Context, id int64) (*models.ScoreFormula, error) {
+	f, err := scanScoreFormula(s.db.QueryRowContext(ctx,
+		`SELECT `+scoreFormulaColumns+` FROM score_formulas WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get score formula %d: %w", id, err)
+	}
+	return f, nil
+}
+
+// ListScoreFormulas returns a project's formulas.
+func (s *Store) ListScoreFormulas(ctx context.Context, projectID int64) ([]*models.ScoreFormula, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+scoreFormulaColumns+` FROM score_formulas
+		 WHERE project_id = $1 ORDER BY id`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list score formulas: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.ScoreFormula
+	for rows.Next() {
+		f, err := scanScoreFormula(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, rows.Err()
+}
+
+// DeleteScoreFormula removes a formula.
+func (s *Store) DeleteScoreFormula(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM score_formulas WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete score formula %d: %w", id, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
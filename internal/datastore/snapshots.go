@@ -0,0 +1,65 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CreateReportSnapshot freezes a job's summary. The summary is marshalled
+// once here and never recomputed; reads return the stored bytes verbatim.
+func (s *Store) CreateReportSnapshot(ctx context.Context, jobID int64, summary any) (*models.ReportSnapshot, error) {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("encode report snapshot: %w", err)
+	}
+	snap := &models.ReportSnapshot{JobID: jobID, Summary: data}
+	err = s.db.QueryRowContext(ctx,
+		`INSERT INTO report_snapshots (job_id, summary) VALUES ($1, $2)
+		 RETURNING id, created_at`,
+		jobID, data,
+	).Scan(&snap.ID, &snap.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("create report snapshot for job %d: %w", jobID, err)
+	}
+	return snap, nil
+}
+
+// GetReportSnapshot fetches one frozen snapshot.
+func (s *Store) GetReportSnapshot(ctx context.Context, id int64) (*models.ReportSnapshot, error) {
+	snap := &models.ReportSnapshot{}
+	err := s.reader().QueryRowContext(ctx,
+		`SELECT id, job_id, summary, created_at FROM report_snapshots WHERE id = $1`, id,
+	).Scan(&snap.ID, &snap.JobID, &snap.Summary, &snap.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get report snapshot %d: %w", id, err)
+	}
+	return snap, nil
+}
+
+// ListReportSnapshots returns a job's snapshots, newest first.
+func (s *Store) ListReportSnapshots(ctx context.Context, jobID int64) ([]*models.ReportSnapshot, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT id, job_id, summary, created_at FROM report_snapshots
+		 WHERE job_id = $1 ORDER BY id DESC`, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("list report snapshots for job %d: %w", jobID, err)
+	}
+	defer rows.Close()
+	var out []*models.ReportSnapshot
+	for rows.Next() {
+		snap := &models.ReportSnapshot{}
+		if err := rows.Scan(&snap.ID, &snap.JobID, &snap.Summary, &snap.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, snap)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,132 @@
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/lib/pq"
+)
+
+// SplitSpec names one dataset partition and the share of cases it receives
+// in a random assignment. Percents across a request must sum to 100 so every
+// case ends up in exactly one split.
+type SplitSpec struct {
+	Name    string  `json:"name"`
+	Percent float64 `json:"percent"`
+}
+
+// AssignSplitsRandom partitions every test case in a project into the named
+// splits. The shuffle is seeded, so re-running with the same seed and specs
+// reproduces the same assignment even after unrelated cases were deleted and
+// re-added with the same IDs. Returns the number of cases assigned per split.
+func (s *Store) AssignSplitsRandom(ctx context.Context, projectID, seed int64, specs []SplitSpec) (map[string]int64, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT id FROM asr_test_cases WHERE project_id = $1 ORDER BY id`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list test case ids for split assignment: %w", err)
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin split assignment: %w", err)
+	}
+	defer tx.Rollback()
+
+	counts := make(map[string]int64, len(specs))
+	start, cum := 0, 0.0
+	for i, spec := range specs {
+		cum += spec.Percent
+		end := int(cum / 100 * float64(len(ids)))
+		if i == len(specs)-1 {
+			// Rounding must not drop the tail of the shuffle.
+			end = len(ids)
+		}
+		chunk := ids[start:end]
+		start = end
+		if _, err := tx.ExecContext(ctx,
+			`UPDATE asr_test_cases SET split = $1, updated_at = now() WHERE id = ANY($2)`,
+			spec.Name, pq.Array(chunk)); err != nil {
+			return nil, fmt.Errorf("assign split %q: %w", spec.Name, err)
+		}
+		counts[spec.Name] = int64(len(chunk))
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit split assignment: %w", err)
+	}
+	return counts, nil
+}
+
+// AssignSplitManual places the given cases of a project into one named
+// split, leaving everything else untouched. An empty name clears the
+// assignment. Returns the number of cases updated; IDs outside the project
+// are ignored rather than reassigned.
+func (s *Store) AssignSplitManual(ctx context.Context, projectID int64, split string, ids []int64) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE asr_test_cases SET split = $1, updated_at = now()
+		 WHERE project_id = $2 AND id = ANY($3)`,
+		split, projectID, pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("assign split %q: %w", split, err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// CountTestCasesBySplit returns how many of a project's test cases sit in
+// each split, unassigned cases under the empty name.
+func (s *Store) CountTestCasesBySplit(ctx context.Context, projectID int64) (map[string]int64, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT split, COUNT(*) FROM asr_test_cases WHERE project_id = $1 GROUP BY split`,
+		projectID)
+	if err != nil {
+		return nil, fmt.Errorf("count test cases by split: %w", err)
+	}
+	defer rows.Close()
+	counts := make(map[string]int64)
+	for rows.Next() {
+		var split string
+		var n int64
+		if err := rows.Scan(&split, &n); err != nil {
+			return nil, err
+		}
+		counts[split] = n
+	}
+	return counts, rows.Err()
+}
+
+// ListTestCaseIDsBySplit returns the IDs of a project's test cases in one
+// split, in ID order.
+func (s *Store) ListTestCaseIDsBySplit(ctx context.Context, projectID int64, split string) ([]int64, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT id FROM asr_test_cases WHERE project_id = $1 AND split = $2 ORDER BY id`,
+		projectID, split)
+	if err != nil {
+		return nil, fmt.Errorf("list test case ids for split %q: %w", split, err)
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,151 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// StorageObject is one tracked content-addressed object.
+type StorageObject struct {
+	ObjectKey string `json:"object_key"`
+	SHA256    string `json:"sha256"`
+	SizeBytes int64  `json:"size_bytes"`
+	RefCount  int64  `json:"ref_count"`
+}
+
+// ListStorageObjects returns every tracked object, used for backup manifests
+// and storage accounting.
+func (s *Store) ListStorageObjects(ctx context.Context) ([]*StorageObject, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT object_key, sha256, size_bytes, ref_count FROM storage_objects ORDER BY object_key`)
+	if err != nil {
+		return nil, fmt.Errorf("list storage objects: %w", err)
+	}
+	defer rows.Close()
+	var out []*StorageObject
+	for rows.Next() {
+		o := &StorageObject{}
+		if err := rows.Scan(&o.ObjectKey, &o.SHA256, &o.SizeBytes, &o.RefCount); err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	return out, rows.Err()
+}
+
+// UpsertStorageObject writes a tracking row verbatim, including its
+// reference count. Used by restore; normal uploads go through AddObjectRef.
+func (s *Store) UpsertStorageObject(ctx context.Context, o *StorageObject) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO storage_objects (object_key, sha256, size_bytes, ref_count)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (object_key)
+		 DO UPDATE SET sha256 = EXCLUDED.sha256, size_bytes = EXCLUDED.size_bytes,
+			ref_count = EXCLUDED.ref_count`,
+		o.ObjectKey, o.SHA256, o.SizeBytes, o.RefCount)
+	if err != nil {
+		return fmt.Errorf("upsert storage object %q: %w", o.ObjectKey, err)
+	}
+	return nil
+}
+
+// AddObjectRef registers one reference to a content-addressed object,
+// creating the row on first use. It returns the reference count after the
+// increment; a return of 1 means the caller is responsible for uploading the
+// object bytes.
+func (s *Store) AddObjectRef(ctx context.Context, objectKey, sha256Hex string, sizeBytes int64) (int64, error) {
+	var refCount int64
+	err := s.db.QueryRowContext(ctx,
+		`INSERT INTO storage_objects (object_key, sha256, size_bytes, ref_count)
+		 VALUES ($1, $2, $3, 1)
+		 ON CONFLICT (object_key)
+		 DO UPDATE SET ref_count = storage_objects.ref_count + 1
+		 RETURNING ref_count`,
+		objectKey, sha256Hex, sizeBytes).Scan(&refCount)
+	if err != nil {
+		return 0, fmt.Errorf("add object ref %q: %w", objectKey, err)
+	}
+	return refCount, nil
+}
+
+// ReleaseObjectRef drops one reference to an object and returns the count of
+// remaining references. When the count reaches zero the row is removed and
+// the caller should delete the object bytes. Keys with no tracking row (from
+// before content addressing) report zero remaining references, so they too
+// get cleaned up.
+func (s *Store) ReleaseObjectRef(ctx context.Context, objectKey string) (int64, error) {
+	var refCount int64
+	err := s.db.QueryRowContext(ctx,
+		`UPDATE storage_objects SET ref_count = ref_count - 1
+		 WHERE object_key = $1 RETURNING ref_count`,
+		objectKey).Scan(&refCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("release object ref %q: %w", objectKey, err)
+	}
+	if refCount <= 0 {
+		if _, err := s.db.ExecContext(ctx,
+			`DELETE FROM storage_objects WHERE object_key = $1 AND ref_count <= 0`,
+			objectKey); err != nil {
+			return 0, fmt.Errorf("delete object row %q: %w", objectKey, err)
+		}
+		return 0, nil
+	}
+	return refCount, nil
+}
+
+// ProjectStorageUsage sums the sizes of the audio objects a project's test
+// cases reference. Content-addressed deduplication means a shared object is
+// counted once per project that references it.
+func (s *Store) ProjectStorageUsage(ctx context.Context, projectID int64) (bytes int64, objects int, err error) {
+	err = s.reader().QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(size_bytes), 0), COUNT(*) FROM storage_objects
+		 WHERE object_key IN (
+			SELECT DISTINCT audio_object_key FROM asr_test_cases WHERE project_id = $1
+		 )`, projectID).Scan(&bytes, &objects)
+	if err != nil {
+		return 0, 0, fmt.Errorf("storage usage for project %d: %w", projectID, err)
+	}
+	return bytes, objects, nil
+}
+
+// UnusedTestCase is a cleanup candidate: a test case no job has ever used,
+// with the size of the audio it pins.
+type UnusedTestCase struct {
+	ID             int64  `json:"id"`
+	Name           string `json:"name"`
+	AudioObjectKey string `json:"audio_object_key"`
+	SizeBytes      int64  `json:"size_bytes"`
+}
+
+// ListUnusedTestCases returns a project's test cases never referenced by any
+// job, largest audio first.
+func (s *Store) ListUnusedTestCases(ctx context.Context, projectID int64, limit int) ([]*UnusedTestCase, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT tc.id, tc.name, tc.audio_object_key, COALESCE(o.size_bytes, 0)
+		 FROM asr_test_cases tc
+		 LEFT JOIN storage_objects o ON o.object_key = tc.audio_object_key
+		 WHERE tc.project_id = $1 AND NOT EXISTS (
+			SELECT 1 FROM evaluation_jobs j
+			WHERE j.project_id = $1 AND tc.id = ANY(j.test_case_ids)
+		 )
+		 ORDER BY COALESCE(o.size_bytes, 0) DESC, tc.id
+		 LIMIT $2`, projectID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list unused test cases for project %d: %w", projectID, err)
+	}
+	defer rows.Close()
+	var out []*UnusedTestCase
+	for rows.Next() {
+		u := &UnusedTestCase{}
+		if err := rows.Scan(&u.ID, &u.Name, &u.AudioObjectKey, &u.SizeBytes); err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,485 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const asrTestCaseColumns = `id, project_id, name, language, audio_object_key,
+	audio_format, duration_ms, split, domain, speaker, ground_truth, segments, word_alignments, entities, tags,
+	created_at, updated_at`
+
+func scanASRTestCase(row interface{ Scan(...any) error }) (*models.ASRTestCase, error) {
+	tc := &models.ASRTestCase{}
+	var segments, wordAlignments, entities, speaker []byte
+	err := row.Scan(&tc.ID, &tc.ProjectID, &tc.Name, &tc.Language,
+		&tc.AudioObjectKey, &tc.AudioFormat, &tc.DurationMS, &tc.Split, &tc.Domain, &speaker, &tc.GroundTruth, &segments,
+		&wordAlignments, &entities, pq.Array(&tc.Tags), &tc.CreatedAt, &tc.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(speaker) > 0 {
+		if err := json.Unmarshal(speaker, &tc.Speaker); err != nil {
+			return nil, fmt.Errorf("decode speaker for test case %d: %w", tc.ID, err)
+		}
+	}
+	if len(entities) > 0 {
+		if err := json.Unmarshal(entities, &tc.Entities); err != nil {
+			return nil, fmt.Errorf("decode entities for test case %d: %w", tc.ID, err)
+		}
+	}
+	if len(segments) > 0 {
+		if err := json.Unmarshal(segments, &tc.Segments); err != nil {
+			return nil, fmt.Errorf("decode segments for test case %d: %w", tc.ID, err)
+		}
+	}
+	if len(wordAlignments) > 0 {
+		if err := json.Unmarshal(wordAlignments, &tc.WordAlignments); err != nil {
+			return nil, fmt.Errorf("decode word alignments for test case %d: %w", tc.ID, err)
+		}
+	}
+	return tc, nil
+}
+
+// jsonOrNull marshals a slice for a nullable JSONB column; empty slices
+// become SQL NULL.
+func jsonOrNull[T any](items []T) (any, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// CreateASRTestCase inserts a test case and fills in the generated fields.
+func (s *Store) CreateASRTestCase(ctx context.Context, tc *models.ASRTestCase) error {
+	return insertASRTestCase(ctx, s.db, tc)
+}
+
+// CreateASRTestCases inserts a batch of test cases in one transaction, so a
+// bulk import either lands whole or not at all.
+func (s *Store) CreateASRTestCases(ctx context.Context, cases []*models.ASRTestCase) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin bulk test case insert: %w", err)
+	}
+	defer tx.Rollback()
+	for _, tc := range cases {
+		if err := insertASRTestCase(ctx, tx, tc); err != nil {
+			return fmt.Errorf("insert test case %q: %w", tc.Name, err)
+		}
+	}
+	return tx.Commit()
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx, so single and
+// transactional inserts share one statement.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func insertASRTestCase(ctx context.Context, q rowQuerier, tc *models.ASRTestCase) error {
+	segments, err := jsonOrNull(tc.Segments)
+	if err != nil {
+		return fmt.Errorf("encode segments: %w", err)
+	}
+	wordAlignments, err := jsonOrNull(tc.WordAlignments)
+	if err != nil {
+		return fmt.Errorf("encode word alignments: %w", err)
+	}
+	entities, err := jsonOrNull(tc.Entities)
+	if err != nil {
+		return fmt.Errorf("encode entities: %w", err)
+	}
+	speaker, err := speakerOrNull(tc.Speaker)
+	if err != nil {
+		return fmt.Errorf("encode speaker: %w", err)
+	}
+	return q.QueryRowContext(ctx,
+		`INSERT INTO asr_test_cases
+			(project_id, name, language, audio_object_key, audio_format, duration_ms,
+			 split, domain, speaker, ground_truth, segments, word_alignments, entities, tags)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		 RETURNING id, created_at, updated_at`,
+		tc.ProjectID, tc.Name, tc.Language, tc.AudioObjectKey, tc.AudioFormat, tc.DurationMS,
+		tc.Split, tc.Domain, speaker, tc.GroundTruth, segments, wordAlignments, entities, pq.Array(tc.Tags),
+	).Scan(&tc.ID, &tc.CreatedAt, &tc.UpdatedAt)
+}
+
+// speakerOrNull marshals a speaker for the nullable JSONB column; nil
+// becomes SQL NULL.
+func speakerOrNull(info *models.SpeakerInfo) (any, error) {
+	if info == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(info)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// SetASRTestCaseSpeaker replaces a test case's speaker attributes; nil
+// clears them.
+func (s *Store) SetASRTestCaseSpeaker(ctx context.Context, id int64, info *models.SpeakerInfo) error {
+	speaker, err := speakerOrNull(info)
+	if err != nil {
+		return fmt.Errorf("encode speaker: %w", err)
+	}
+	return s.updateTestCaseColumn(ctx, id, "speaker", speaker)
+}
+
+// SetASRTestCaseAlignment stores forced-alignment output on a test case.
+func (s *Store) SetASRTestCaseAlignment(ctx context.Context, id int64, alignments []models.WordAlignment) error {
+	wordAlignments, err := jsonOrNull(alignments)
+	if err != nil {
+		return fmt.Errorf("encode word alignments: %w", err)
+	}
+	return s.updateTestCaseColumn(ctx, id, "word_alignments", wordAlignments)
+}
+
+// SetASRTestCaseSegments replaces a test case's ground-truth segments, e.g.
+// with segments derived from a forced alignment.
+func (s *Store) SetASRTestCaseSegments(ctx context.Context, id int64, segments []models.Segment) error {
+	encoded, err := jsonOrNull(segments)
+	if err != nil {
+		return fmt.Errorf("encode segments: %w", err)
+	}
+	return s.updateTestCaseColumn(ctx, id, "segments", encoded)
+}
+
+func (s *Store) updateTestCaseColumn(ctx context.Context, id int64, column string, value any) error {
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE asr_test_cases SET `+column+` = $2, updated_at = now() WHERE id = $1`,
+		id, value)
+	if err != nil {
+		return fmt.Errorf("update asr test case %d %s: %w", id, column, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetASRTestCase fetches one test case by ID.
+func (s *Store) GetASRTestCase(ctx context.Context, id int64) (*models.ASRTestCase, error) {
+	tc, err := scanASRTestCase(s.db.QueryRowContext(ctx,
+		`SELECT `+asrTestCaseColumns+` FROM asr_test_cases WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get asr test case %d: %w", id, err)
+	}
+	return tc, nil
+}
+
+// GetASRTestCases fetches several test cases by ID and errors if any are
+// missing, mirroring GetVendorConfigs.
+func (s *Store) GetASRTestCases(ctx context.Context, ids []int64) ([]*models.ASRTestCase, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+asrTestCaseColumns+` FROM asr_test_cases WHERE id = ANY($1)`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("get asr test cases: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.ASRTestCase
+	for rows.Next() {
+		tc, err := scanASRTestCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(out) != len(ids) {
+		return nil, fmt.Errorf("asr test cases: requested %d, found %d", len(ids), len(out))
+	}
+	return out, nil
+}
+
+// ListASRTestCases returns all test cases in a project, optionally filtered
+// by language.
+func (s *Store) ListASRTestCases(ctx context.Context, projectID int64, language string) ([]*models.ASRTestCase, error) {
+	query := `SELECT ` + asrTestCaseColumns + ` FROM asr_test_cases WHERE project_id = $1`
+	args := []any{projectID}
+	if language != "" {
+		query += ` AND language = $2`
+		args = append(args, language)
+	}
+	query += ` ORDER BY id`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list asr test cases: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.ASRTestCase
+	for rows.Next() {
+		tc, err := scanASRTestCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, tc)
+	}
+	return out, rows.Err()
+}
+
+// DeleteASRTestCase removes a test case row. The caller is responsible for
+// cleaning up the associated audio object.
+func (s *Store) DeleteASRTestCase(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM asr_test_cases WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete asr test case %d: %w", id, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// TestCaseIDByAudioObjectKey resolves the test case owning an audio object
+// key, so object-level endpoints can check a key is real platform audio
+// before acting on it.
+func (s *Store) TestCaseIDByAudioObjectKey(ctx context.Context, key string) (int64, error) {
+	var id int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT id FROM asr_test_cases WHERE audio_object_key = $1 ORDER BY id LIMIT 1`,
+		key).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("resolve audio object key: %w", err)
+	}
+	return id, nil
+}
+
+// SumTestCaseAudioMS totals the extracted audio duration of the given test
+// cases. Cases whose duration could not be parsed contribute zero, so the
+// total is a lower bound.
+func (s *Store) SumTestCaseAudioMS(ctx context.Context, ids []int64) (int64, error) {
+	var total int64
+	err := s.reader().QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(duration_ms), 0) FROM asr_test_cases WHERE id = ANY($1)`,
+		pq.Array(ids)).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("sum test case audio duration: %w", err)
+	}
+	return total, nil
+}
+
+// SumJobAudioMS totals the audio duration of the test cases a job
+// references.
+func (s *Store) SumJobAudioMS(ctx context.Context, jobID int64) (int64, error) {
+	var total int64
+	err := s.reader().QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(t.duration_ms), 0) FROM asr_test_cases t
+		 WHERE t.id = ANY(SELECT unnest(test_case_ids) FROM evaluation_jobs WHERE id = $1)`,
+		jobID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("sum audio duration for job %d: %w", jobID, err)
+	}
+	return total, nil
+}
+
+// SumProjectAudioMS totals the audio duration of a project's test cases.
+func (s *Store) SumProjectAudioMS(ctx context.Context, projectID int64) (int64, error) {
+	var total int64
+	err := s.reader().QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(duration_ms), 0) FROM asr_test_cases WHERE project_id = $1`,
+		projectID).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("sum audio duration for project %d: %w", projectID, err)
+	}
+	return total, nil
+}
+
+// SetASRTestCaseGroundTruth replaces a test case's ground truth. Callers
+// record a GroundTruthRevision alongside so the edit stays attributable.
+func (s *Store) SetASRTestCaseGroundTruth(ctx context.Context, id int64, text string) error {
+	return s.updateTestCaseColumn(ctx, id, "ground_truth", text)
+}
+
+// TestCaseFilter selects the test cases a bulk edit applies to. Zero-valued
+// fields are ignored; at least one must be set.
+type TestCaseFilter struct {
+	IDs       []int64  // explicit case IDs
+	ProjectID int64    // every case in this project
+	Tags      []string // cases carrying every listed tag
+}
+
+// TestCaseUpdate is the set of edits a bulk operation applies. Zero-valued
+// fields are skipped; at least one must be set.
+type TestCaseUpdate struct {
+	AddTags       []string
+	RemoveTags    []string
+	SetLanguage   string
+	SetDomain     string
+	MoveToProject int64
+}
+
+// BulkUpdateTestCases applies the update to every case matching the filter
+// inside one transaction, so a failure midway leaves the dataset untouched.
+// With dryRun it only counts the matches and changes nothing. Returns the
+// number of matched cases either way.
+func (s *Store) BulkUpdateTestCases(ctx context.Context, f TestCaseFilter, u TestCaseUpdate, dryRun bool) (int64, error) {
+	where := "TRUE"
+	var args []any
+	cond := func(clause string, value any) {
+		args = append(args, value)
+		where += fmt.Sprintf(" AND "+clause, len(args))
+	}
+	if len(f.IDs) > 0 {
+		cond("id = ANY($%d)", pq.Array(f.IDs))
+	}
+	if f.ProjectID > 0 {
+		cond("project_id = $%d", f.ProjectID)
+	}
+	if len(f.Tags) > 0 {
+		cond("tags @> $%d", pq.Array(f.Tags))
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin bulk test case update: %w", err)
+	}
+	defer tx.Rollback()
+
+	var matched int64
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM asr_test_cases WHERE `+where, args...).Scan(&matched); err != nil {
+		return 0, fmt.Errorf("count bulk update matches: %w", err)
+	}
+	if dryRun || matched == 0 {
+		return matched, tx.Commit()
+	}
+
+	apply := func(set string, value any) error {
+		stmtArgs := append(append([]any{}, args...), value)
+		set = fmt.Sprintf(set, len(stmtArgs))
+		_, err := tx.ExecContext(ctx,
+			`UPDATE asr_test_cases SET `+set+`, updated_at = now() WHERE `+where, stmtArgs...)
+		return err
+	}
+	if len(u.AddTags) > 0 {
+		if err := apply(
+			`tags = (SELECT array_agg(DISTINCT t ORDER BY t) FROM unnest(tags || $%d::text[]) t)`,
+			pq.Array(u.AddTags)); err != nil {
+			return 0, fmt.Errorf("bulk add tags: %w", err)
+		}
+	}
+	if len(u.RemoveTags) > 0 {
+		if err := apply(
+			`tags = ARRAY(SELECT t FROM unnest(tags) t WHERE NOT t = ANY($%d::text[]))`,
+			pq.Array(u.RemoveTags)); err != nil {
+			return 0, fmt.Errorf("bulk remove tags: %w", err)
+		}
+	}
+	if u.SetLanguage != "" {
+		if err := apply(`language = $%d`, u.SetLanguage); err != nil {
+			return 0, fmt.Errorf("bulk set language: %w", err)
+		}
+	}
+	if u.SetDomain != "" {
+		if err := apply(`domain = $%d`, u.SetDomain); err != nil {
+			return 0, fmt.Errorf("bulk set domain: %w", err)
+		}
+	}
+	if u.MoveToProject > 0 {
+		if err := apply(`project_id = $%d`, u.MoveToProject); err != nil {
+			return 0, fmt.Errorf("bulk move project: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit bulk test case update: %w", err)
+	}
+	return matched, nil
+}
+
+// BulkDeleteReport summarizes one bulk deletion pass.
+type BulkDeleteReport struct {
+	Matched   int64 `json:"matched"`
+	Protected int64 `json:"protected"`
+	Deleted   int64 `json:"deleted"`
+}
+
+// BulkDeleteTestCases deletes every case matching the filter except
+// protected ones: cases referenced by a job created after protectCutoff or
+// holding any result rows. The row deletes and the object-store cleanup
+// outbox entries commit in one transaction; the sweeper releases the audio
+// objects afterwards. With dryRun nothing is changed and the report shows
+// what a real run would do.
+func (s *Store) BulkDeleteTestCases(ctx context.Context, f TestCaseFilter, protectCutoff time.Time, dryRun bool) (*BulkDeleteReport, error) {
+	where := "TRUE"
+	var args []any
+	cond := func(clause string, value any) {
+		args = append(args, value)
+		where += fmt.Sprintf(" AND "+clause, len(args))
+	}
+	if len(f.IDs) > 0 {
+		cond("id = ANY($%d)", pq.Array(f.IDs))
+	}
+	if f.ProjectID > 0 {
+		cond("project_id = $%d", f.ProjectID)
+	}
+	if len(f.Tags) > 0 {
+		cond("tags @> $%d", pq.Array(f.Tags))
+	}
+	args = append(args, protectCutoff)
+	protected := fmt.Sprintf(`(
+		id IN (SELECT unnest(test_case_ids) FROM evaluation_jobs WHERE created_at > $%d)
+		OR EXISTS (SELECT 1 FROM asr_results r WHERE r.asr_test_case_id = asr_test_cases.id))`,
+		len(args))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin bulk test case delete: %w", err)
+	}
+	defer tx.Rollback()
+
+	report := &BulkDeleteReport{}
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*), COUNT(*) FILTER (WHERE `+protected+`)
+		 FROM asr_test_cases WHERE `+where, args...).Scan(&report.Matched, &report.Protected); err != nil {
+		return nil, fmt.Errorf("count bulk delete matches: %w", err)
+	}
+	if dryRun {
+		return report, tx.Commit()
+	}
+
+	// One outbox row per deleted case, not per distinct key: audio is
+	// content-addressed and reference-counted, so each case must release
+	// exactly one reference.
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO object_cleanup_outbox (object_key)
+		 SELECT audio_object_key FROM asr_test_cases WHERE `+where+` AND NOT `+protected,
+		args...); err != nil {
+		return nil, fmt.Errorf("enqueue object cleanups: %w", err)
+	}
+	res, err := tx.ExecContext(ctx,
+		`DELETE FROM asr_test_cases WHERE `+where+` AND NOT `+protected, args...)
+	if err != nil {
+		return nil, fmt.Errorf("bulk delete test cases: %w", err)
+	}
+	report.Deleted, _ = res.RowsAffected()
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit bulk test case delete: %w", err)
+	}
+	return report, nil
+}
@@ -0,0 +1,98 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const testSuiteColumns = `id, project_id, name, description, test_case_ids, created_at, updated_at`
+
+func scanTestSuite(row interface{ Scan(...any) error }) (*models.TestSuite, error) {
+	ts := &models.TestSuite{}
+	err := row.Scan(&ts.ID, &ts.ProjectID, &ts.Name, &ts.Description,
+		pq.Array(&ts.TestCaseIDs), &ts.CreatedAt, &ts.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// CreateTestSuite inserts a suite and fills in the generated fields.
+func (s *Store) CreateTestSuite(ctx context.Context, ts *models.TestSuite) error {
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO test_suites (project_id, name, description, test_case_ids)
+		 VALUES ($1, $2, $3, $4)
+		 RETURNING id, created_at, updated_at`,
+		ts.ProjectID, ts.Name, ts.Description, pq.Array(ts.TestCaseIDs),
+	).Scan(&ts.ID, &ts.CreatedAt, &ts.UpdatedAt)
+}
+
+// GetTestSuite fetches one suite by id.
+func (s *Store) GetTestSuite(ctx context.Context, id int64) (*models.TestSuite, error) {
+	ts, err := scanTestSuite(s.db.QueryRowContext(ctx,
+		`SELECT `+testSuiteColumns+` FROM test_suites WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get test suite %d: %w", id, err)
+	}
+	return ts, nil
+}
+
+// ListTestSuites returns a project's suites.
+func (s *Store) ListTestSuites(ctx context.Context, projectID int64) ([]*models.TestSuite, error) {
+	rows, err := s.reader().QueryContext(ctx,
+		`SELECT `+testSuiteColumns+` FROM test_suites WHERE project_id = $1 ORDER BY id`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list test suites: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.TestSuite
+	for rows.Next() {
+		ts, err := scanTestSuite(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ts)
+	}
+	return out, rows.Err()
+}
+
+// UpdateTestSuite replaces a suite's name, description and membership.
+func (s *Store) UpdateTestSuite(ctx context.Context, ts *models.TestSuite) error {
+	err := s.db.QueryRowContext(ctx,
+		`UPDATE test_suites
+		 SET name = $2, description = $3, test_case_ids = $4, updated_at = now()
+		 WHERE id = $1
+		 RETURNING updated_at`,
+		ts.ID, ts.Name, ts.Description, pq.Array(ts.TestCaseIDs),
+	).Scan(&ts.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update test suite %d: %w", ts.ID, err)
+	}
+	return nil
+}
+
+// DeleteTestSuite removes a suite. Jobs that ran against it keep their
+// materialized test_case_ids.
+func (s *Store) DeleteTestSuite(ctx context.Context, id int64) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM test_suites WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete test suite %d: %w", id, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
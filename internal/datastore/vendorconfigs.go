@@ -0,0 +1,124 @@
+package datastore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+const vendorConfigColumns = `id, project_id, name, adapter_key, api_type, api_key, api_secret,
+	api_endpoint, other_configs, is_active, created_at, updated_at`
+
+func scanVendorConfig(row interface{ Scan(...any) error }) (*models.VendorConfig, error) {
+	vc := &models.VendorConfig{}
+	err := row.Scan(&vc.ID, &vc.ProjectID, &vc.Name, &vc.AdapterKey, &vc.APIType, &vc.APIKey,
+		&vc.APISecret, &vc.APIEndpoint, &vc.OtherConfigs, &vc.IsActive,
+		&vc.CreatedAt, &vc.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return vc, nil
+}
+
+// CreateVendorConfig inserts a vendor config and fills in the generated
+// fields on the passed struct.
+func (s *Store) CreateVendorConfig(ctx context.Context, vc *models.VendorConfig) error {
+	if len(vc.OtherConfigs) == 0 {
+		vc.OtherConfigs = []byte("{}")
+	}
+	return s.db.QueryRowContext(ctx,
+		`INSERT INTO vendor_configs
+			(project_id, name, adapter_key, api_type, api_key, api_secret, api_endpoint, other_configs, is_active)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 RETURNING id, created_at, updated_at`,
+		vc.ProjectID, vc.Name, vc.AdapterKey, vc.APIType, vc.APIKey, vc.APISecret,
+		vc.APIEndpoint, []byte(vc.OtherConfigs), vc.IsActive,
+	).Scan(&vc.ID, &vc.CreatedAt, &vc.UpdatedAt)
+}
+
+// UpdateVendorConfig overwrites the mutable fields of an existing config.
+func (s *Store) UpdateVendorConfig(ctx context.Context, vc *models.VendorConfig) error {
+	if len(vc.OtherConfigs) == 0 {
+		vc.OtherConfigs = []byte("{}")
+	}
+	res, err := s.db.ExecContext(ctx,
+		`UPDATE vendor_configs SET name = $2, adapter_key = $3, api_key = $4, api_secret = $5,
+			api_endpoint = $6, other_configs = $7, is_active = $8, updated_at = now()
+		 WHERE id = $1`,
+		vc.ID, vc.Name, vc.AdapterKey, vc.APIKey, vc.APISecret, vc.APIEndpoint,
+		[]byte(vc.OtherConfigs), vc.IsActive)
+	if err != nil {
+		return fmt.Errorf("update vendor config %d: %w", vc.ID, err)
+	}
+	n, _ := res.RowsAffected()
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GetVendorConfig fetches one vendor config by ID.
+func (s *Store) GetVendorConfig(ctx context.Context, id int64) (*models.VendorConfig, error) {
+	vc, err := scanVendorConfig(s.db.QueryRowContext(ctx,
+		`SELECT `+vendorConfigColumns+` FROM vendor_configs WHERE id = $1`, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get vendor config %d: %w", id, err)
+	}
+	return vc, nil
+}
+
+// GetVendorConfigs fetches several configs at once, preserving no particular
+// order. Missing IDs are reported as an error so jobs never run with a
+// partial vendor set.
+func (s *Store) GetVendorConfigs(ctx context.Context, ids []int64) ([]*models.VendorConfig, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+vendorConfigColumns+` FROM vendor_configs WHERE id = ANY($1)`,
+		pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("get vendor configs: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.VendorConfig
+	for rows.Next() {
+		vc, err := scanVendorConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(out) != len(ids) {
+		return nil, fmt.Errorf("vendor configs: requested %d, found %d", len(ids), len(out))
+	}
+	return out, nil
+}
+
+// ListVendorConfigs returns all configs for a project.
+func (s *Store) ListVendorConfigs(ctx context.Context, projectID int64) ([]*models.VendorConfig, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT `+vendorConfigColumns+` FROM vendor_configs
+		 WHERE project_id = $1 ORDER BY id`, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list vendor configs: %w", err)
+	}
+	defer rows.Close()
+	var out []*models.VendorConfig
+	for rows.Next() {
+		vc, err := scanVendorConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, vc)
+	}
+	return out, rows.Err()
+}
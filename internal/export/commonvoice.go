@@ -0,0 +1,87 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// WriteCommonVoice writes a dataset as a Common Voice-style ZIP archive: a
+// clips/ directory with the audio files and a clips.tsv listing one row per
+// clip, so curated platform datasets drop straight into external training
+// pipelines. The audio callback fetches each clip's bytes; the writer pulls
+// them one case at a time, so the archive streams in bounded memory.
+func WriteCommonVoice(w io.Writer, cases []*models.ASRTestCase, audio func(tc *models.ASRTestCase) ([]byte, error)) error {
+	archive := zip.NewWriter(w)
+
+	tsvFile, err := archive.Create("clips.tsv")
+	if err != nil {
+		return err
+	}
+	tsv := csv.NewWriter(tsvFile)
+	tsv.Comma = '\t'
+	if err := tsv.Write([]string{"client_id", "path", "sentence", "locale", "duration_ms"}); err != nil {
+		return err
+	}
+	for _, tc := range cases {
+		if err := tsv.Write([]string{
+			speakerOf(tc),
+			clipPath(tc),
+			tc.GroundTruth,
+			tc.Language,
+			strconv.FormatInt(tc.DurationMS, 10),
+		}); err != nil {
+			return err
+		}
+	}
+	tsv.Flush()
+	if err := tsv.Error(); err != nil {
+		return err
+	}
+
+	for _, tc := range cases {
+		data, err := audio(tc)
+		if err != nil {
+			return fmt.Errorf("fetch audio for case %d: %w", tc.ID, err)
+		}
+		clip, err := archive.Create(clipPath(tc))
+		if err != nil {
+			return err
+		}
+		if _, err := clip.Write(data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// clipPath names a case's audio file inside the archive. The case ID prefix
+// keeps paths unique even when case names collide.
+func clipPath(tc *models.ASRTestCase) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9',
+			r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, tc.Name)
+	return fmt.Sprintf("clips/%d_%s.%s", tc.ID, name, tc.AudioFormat)
+}
+
+// speakerOf extracts the case's speaker tag, matching the Kaldi importer's
+// convention; Common Voice calls the same notion client_id.
+func speakerOf(tc *models.ASRTestCase) string {
+	for _, tag := range tc.Tags {
+		if speaker, ok := strings.CutPrefix(tag, models.SpeakerTagPrefix); ok {
+			return speaker
+		}
+	}
+	return ""
+}
@@ -0,0 +1,228 @@
+// Package graphql implements the small read-only subset of the GraphQL
+// query language the platform's analytics endpoint needs: named root fields
+// with scalar arguments and nested selection sets. Keeping the executor
+// in-house follows the platform's no-new-dependencies stance; mutations,
+// variables, fragments and directives are deliberately unsupported.
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Selection is one requested field: its name, optional arguments and
+// optional sub-selections.
+type Selection struct {
+	Name       string
+	Args       map[string]any
+	Selections []Selection
+}
+
+// Parse reads a query document of the form
+//
+//	{ field(arg: 1) { sub { leaf } other } }
+//
+// with an optional leading "query" keyword, and returns the top-level
+// selections.
+func Parse(query string) ([]Selection, error) {
+	p := &parser{input: query}
+	p.skipSpace()
+	if p.consumeWord("query") {
+		p.skipSpace()
+		// An optional operation name may follow the keyword.
+		if p.pos < len(p.input) && p.input[p.pos] != '{' {
+			p.readName()
+			p.skipSpace()
+		}
+	}
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected trailing input at offset %d", p.pos)
+	}
+	return selections, nil
+}
+
+type parser struct {
+	input string
+	pos   int
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if !p.consume('{') {
+		return nil, fmt.Errorf("expected '{' at offset %d", p.pos)
+	}
+	var selections []Selection
+	for {
+		p.skipSpace()
+		if p.consume('}') {
+			if len(selections) == 0 {
+				return nil, fmt.Errorf("empty selection set at offset %d", p.pos)
+			}
+			return selections, nil
+		}
+		name := p.readName()
+		if name == "" {
+			return nil, fmt.Errorf("expected field name at offset %d", p.pos)
+		}
+		sel := Selection{Name: name}
+		p.skipSpace()
+		if p.consume('(') {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			sel.Args = args
+			p.skipSpace()
+		}
+		if p.peek() == '{' {
+			sub, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			sel.Selections = sub
+		}
+		selections = append(selections, sel)
+	}
+}
+
+func (p *parser) parseArgs() (map[string]any, error) {
+	args := map[string]any{}
+	for {
+		p.skipSpace()
+		if p.consume(')') {
+			return args, nil
+		}
+		name := p.readName()
+		if name == "" {
+			return nil, fmt.Errorf("expected argument name at offset %d", p.pos)
+		}
+		p.skipSpace()
+		if !p.consume(':') {
+			return nil, fmt.Errorf("expected ':' after argument %q", name)
+		}
+		p.skipSpace()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+		p.skipSpace()
+		p.consume(',')
+	}
+}
+
+// parseValue reads a scalar literal: int, float, string, boolean.
+func (p *parser) parseValue() (any, error) {
+	if p.peek() == '"' {
+		p.pos++
+		start := p.pos
+		for p.pos < len(p.input) && p.input[p.pos] != '"' {
+			p.pos++
+		}
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("unterminated string at offset %d", start)
+		}
+		s := p.input[start:p.pos]
+		p.pos++
+		return s, nil
+	}
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune(" \t\r\n,)", rune(p.input[p.pos])) {
+		p.pos++
+	}
+	word := p.input[start:p.pos]
+	switch word {
+	case "":
+		return nil, fmt.Errorf("expected value at offset %d", start)
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if n, err := strconv.ParseInt(word, 10, 64); err == nil {
+		return n, nil
+	}
+	if f, err := strconv.ParseFloat(word, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported value %q at offset %d", word, start)
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) consume(c byte) bool {
+	if p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) consumeWord(word string) bool {
+	if strings.HasPrefix(p.input[p.pos:], word) {
+		end := p.pos + len(word)
+		if end == len(p.input) || !isNameRune(rune(p.input[end])) {
+			p.pos = end
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) readName() string {
+	start := p.pos
+	for p.pos < len(p.input) && isNameRune(rune(p.input[p.pos])) {
+		p.pos++
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.input) {
+		c := rune(p.input[p.pos])
+		if unicode.IsSpace(c) || c == ',' {
+			p.pos++
+			continue
+		}
+		if c == '#' { // comment to end of line
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+func isNameRune(c rune) bool {
+	return c == '_' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// IntArg reads an integer argument, tolerating the zero value when absent.
+func IntArg(args map[string]any, name string) int64 {
+	switch v := args[name].(type) {
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// StringArg reads a string argument, empty when absent.
+func StringArg(args map[string]any, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []Selection
+	}{
+		{
+			"single field",
+			`{ projects }`,
+			[]Selection{{Name: "projects"}},
+		},
+		{
+			"scalar argument types",
+			`{ jobs(project_id: 7, status: "DONE", detailed: true, threshold: 0.5) }`,
+			[]Selection{{Name: "jobs", Args: map[string]any{
+				"project_id": int64(7),
+				"status":     "DONE",
+				"detailed":   true,
+				"threshold":  0.5,
+			}}},
+		},
+		{
+			"nested selections",
+			`{ jobs { results { id wer } name } }`,
+			[]Selection{{Name: "jobs", Selections: []Selection{
+				{Name: "results", Selections: []Selection{{Name: "id"}, {Name: "wer"}}},
+				{Name: "name"},
+			}}},
+		},
+		{
+			"query keyword with operation name",
+			`query JobList { jobs }`,
+			[]Selection{{Name: "jobs"}},
+		},
+		{
+			"commas and comments are whitespace",
+			"{ a, b # trailing comment\n c }",
+			[]Selection{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		},
+		{
+			"arguments then sub-selection",
+			`{ job(id: 3) { status } }`,
+			[]Selection{{Name: "job", Args: map[string]any{"id": int64(3)},
+				Selections: []Selection{{Name: "status"}}}},
+		},
+		{
+			"negative and exponent numbers",
+			`{ f(a: -2, b: 1e3) }`,
+			[]Selection{{Name: "f", Args: map[string]any{"a": int64(-2), "b": 1000.0}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.query)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.query, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %#v, want %#v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr string
+	}{
+		{"missing opening brace", `jobs`, "expected '{'"},
+		{"empty selection set", `{}`, "empty selection set"},
+		{"empty nested selection set", `{ a {} }`, "empty selection set"},
+		{"trailing input", `{ a } b`, "unexpected trailing input"},
+		{"unterminated string", `{ a(b: "x`, "unterminated string"},
+		{"missing colon", `{ a(b 1) }`, "expected ':'"},
+		{"missing value", `{ a(b: ) }`, "expected value"},
+		{"unsupported value", `{ a(b: [1]) }`, "unsupported value"},
+		{"unterminated selection set", `{ a`, "expected field name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			if err == nil {
+				t.Fatalf("Parse(%q) succeeded, want error containing %q", tt.query, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%q) error = %q, want it to contain %q", tt.query, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArgHelpers(t *testing.T) {
+	args := map[string]any{"n": int64(3), "f": 2.9, "s": "x"}
+	if got := IntArg(args, "n"); got != 3 {
+		t.Errorf("IntArg int64 = %d, want 3", got)
+	}
+	if got := IntArg(args, "f"); got != 2 {
+		t.Errorf("IntArg float64 = %d, want 2", got)
+	}
+	if got := IntArg(args, "missing"); got != 0 {
+		t.Errorf("IntArg missing = %d, want 0", got)
+	}
+	if got := StringArg(args, "s"); got != "x" {
+		t.Errorf("StringArg = %q, want \"x\"", got)
+	}
+	if got := StringArg(args, "missing"); got != "" {
+		t.Errorf("StringArg missing = %q, want empty", got)
+	}
+}
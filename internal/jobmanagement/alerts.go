@@ -0,0 +1,91 @@
+package jobmanagement
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// alertPollInterval is how often the sweep re-evaluates every enabled rule,
+// catching results written outside the in-process workers (runner agents,
+// Kubernetes shards).
+const alertPollInterval = 5 * time.Minute
+
+// alertWatcher periodically evaluates all enabled alert rules. Rules are
+// also evaluated right after each in-process job completes, so the sweep is
+// a safety net rather than the primary latency path.
+func (s *Service) alertWatcher(ctx context.Context) {
+	ticker := time.NewTicker(alertPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rules, err := s.store.ListEnabledAlertRules(ctx)
+			if err != nil {
+				log.Printf("alert sweep: %v", err)
+				continue
+			}
+			for _, rule := range rules {
+				s.evaluateAlertRule(ctx, rule)
+			}
+		}
+	}
+}
+
+// evaluateProjectAlerts runs a project's enabled rules, called after one of
+// its jobs completes.
+func (s *Service) evaluateProjectAlerts(ctx context.Context, projectID int64) {
+	rules, err := s.store.ListEnabledAlertRulesByProject(ctx, projectID)
+	if err != nil {
+		log.Printf("alerts for project %d: %v", projectID, err)
+		return
+	}
+	for _, rule := range rules {
+		s.evaluateAlertRule(ctx, rule)
+	}
+}
+
+// evaluateAlertRule computes the rule's metric and emits fire/resolve
+// notifications on state transitions only, so a breached rule notifies once
+// and stays silent until it recovers.
+func (s *Service) evaluateAlertRule(ctx context.Context, rule *models.AlertRule) {
+	value, samples, err := s.store.EvaluateAlertMetric(ctx, rule)
+	if err != nil {
+		log.Printf("alert rule %d: %v", rule.ID, err)
+		return
+	}
+	if samples == 0 {
+		return
+	}
+	var triggered bool
+	switch rule.Comparison {
+	case ">":
+		triggered = value > rule.Threshold
+	case "<":
+		triggered = value < rule.Threshold
+	default:
+		log.Printf("alert rule %d: unknown comparison %q", rule.ID, rule.Comparison)
+		return
+	}
+	switch {
+	case triggered && !rule.Firing:
+		msg := fmt.Sprintf("alert rule %q: %s %.4f %s %.4f over the last %d completed job(s)",
+			rule.Name, rule.Metric, value, rule.Comparison, rule.Threshold, rule.WindowJobs)
+		s.notify(ctx, rule.ProjectID, "alert", msg)
+		if err := s.store.SetAlertRuleFiring(ctx, rule.ID, true); err != nil {
+			log.Printf("alert rule %d: %v", rule.ID, err)
+		}
+	case !triggered && rule.Firing:
+		msg := fmt.Sprintf("alert rule %q resolved: %s is %.4f, no longer %s %.4f",
+			rule.Name, rule.Metric, value, rule.Comparison, rule.Threshold)
+		s.notify(ctx, rule.ProjectID, "alert_resolved", msg)
+		if err := s.store.SetAlertRuleFiring(ctx, rule.ID, false); err != nil {
+			log.Printf("alert rule %d: %v", rule.ID, err)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package jobmanagement
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/pricing"
+)
+
+// budgetWarnThreshold is the fraction of a monthly budget at which a warning
+// notification is emitted.
+const budgetWarnThreshold = 0.8
+
+// BudgetViolation describes one budget a submission would exceed.
+type BudgetViolation struct {
+	BudgetID        int64   `json:"budget_id"`
+	VendorConfigID  *int64  `json:"vendor_config_id,omitempty"`
+	MonthlyLimitUSD float64 `json:"monthly_limit_usd"`
+	SpentUSD        float64 `json:"spent_usd"`
+	EstimatedUSD    float64 `json:"estimated_usd"`
+}
+
+// ErrBudgetExceeded is returned from SubmitASRJob when one or more budgets
+// would be exceeded and no override was requested. The API layer serializes
+// the violations so the caller can decide whether to override.
+type ErrBudgetExceeded struct {
+	Violations []BudgetViolation
+}
+
+func (e *ErrBudgetExceeded) Error() string {
+	scopes := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		if v.VendorConfigID != nil {
+			scopes = append(scopes, fmt.Sprintf("vendor %d", *v.VendorConfigID))
+		} else {
+			scopes = append(scopes, "project")
+		}
+	}
+	return "monthly budget exceeded for: " + strings.Join(scopes, ", ")
+}
+
+// checkBudgets estimates the job's spend per vendor and compares projected
+// monthly totals against configured budgets. Exceeded budgets block the
+// submission (subject to enforcement config and the override flag); crossing
+// the warning threshold emits a de-duplicated notification.
+func (s *Service) checkBudgets(ctx context.Context, job *models.EvaluationJob, override bool) error {
+	budgets, err := s.store.ListBudgets(ctx, job.ProjectID)
+	if err != nil {
+		return fmt.Errorf("load budgets: %w", err)
+	}
+	if len(budgets) == 0 {
+		return nil
+	}
+
+	vendorConfigs, err := s.store.GetVendorConfigs(ctx, job.VendorConfigIDs)
+	if err != nil {
+		return fmt.Errorf("load vendor configs: %w", err)
+	}
+	estimateByVendor := make(map[int64]float64, len(vendorConfigs))
+	totalEstimate := 0.0
+	for _, vc := range vendorConfigs {
+		est := pricing.EstimateJobSpend(vc, len(job.TestCaseIDs))
+		estimateByVendor[vc.ID] = est
+		totalEstimate += est
+	}
+
+	month := time.Now().UTC().Format("2006-01")
+	var violations []BudgetViolation
+	for _, b := range budgets {
+		estimate := totalEstimate
+		if b.VendorConfigID != nil {
+			est, targeted := estimateByVendor[*b.VendorConfigID]
+			if !targeted {
+				continue // budget for a vendor this job does not use
+			}
+			estimate = est
+		}
+		spent, err := s.store.MonthSpend(ctx, job.ProjectID, b.VendorConfigID, month)
+		if err != nil {
+			return err
+		}
+		projected := spent + estimate
+		if projected > b.MonthlyLimitUSD {
+			violations = append(violations, BudgetViolation{
+				BudgetID:        b.ID,
+				VendorConfigID:  b.VendorConfigID,
+				MonthlyLimitUSD: b.MonthlyLimitUSD,
+				SpentUSD:        spent,
+				EstimatedUSD:    estimate,
+			})
+		} else if projected >= budgetWarnThreshold*b.MonthlyLimitUSD {
+			s.warnBudget(ctx, job.ProjectID, b, spent, estimate, month)
+		}
+	}
+
+	if len(violations) > 0 {
+		for _, v := range violations {
+			s.notifyExceeded(ctx, job.ProjectID, v, month)
+		}
+		if s.cfg.BudgetEnforcement && !override {
+			return &ErrBudgetExceeded{Violations: violations}
+		}
+	}
+	return nil
+}
+
+func (s *Service) warnBudget(ctx context.Context, projectID int64, b *models.Budget, spent, estimate float64, month string) {
+	msg := fmt.Sprintf("budget %d: projected spend $%.2f is over 80%% of the $%.2f limit for %s",
+		b.ID, spent+estimate, b.MonthlyLimitUSD, month)
+	s.notify(ctx, projectID, "budget_warning", msg)
+}
+
+func (s *Service) notifyExceeded(ctx context.Context, projectID int64, v BudgetViolation, month string) {
+	msg := fmt.Sprintf("budget %d: projected spend $%.2f exceeds the $%.2f limit for %s",
+		v.BudgetID, v.SpentUSD+v.EstimatedUSD, v.MonthlyLimitUSD, month)
+	s.notify(ctx, projectID, "budget_exceeded", msg)
+}
+
+func (s *Service) notify(ctx context.Context, projectID int64, kind, msg string) {
+	exists, err := s.store.HasRecentNotification(ctx, projectID, kind, msg)
+	if err != nil || exists {
+		return
+	}
+	_ = s.store.CreateNotification(ctx, &models.Notification{
+		ProjectID: projectID,
+		Kind:      kind,
+		Message:   msg,
+	})
+}
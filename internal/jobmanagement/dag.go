@@ -0,0 +1,81 @@
+package jobmanagement
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// dependencyPollInterval is how often pending dependent jobs are re-checked
+// against their upstreams.
+const dependencyPollInterval = 5 * time.Second
+
+// dependencyWatcher releases jobs whose upstream dependencies have settled:
+// a job queues once every upstream is COMPLETED, and fails immediately when
+// any upstream fails or is cancelled, so failures propagate down the chain.
+func (s *Service) dependencyWatcher(ctx context.Context) {
+	ticker := time.NewTicker(dependencyPollInterval)
+	defer ticker.Stop()
+	// Queued jobs stay PENDING until a worker picks them up; remember what
+	// this watcher already released so slow queues don't double-enqueue.
+	released := map[int64]struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.releaseDependentJobs(ctx, released); err != nil {
+				log.Printf("dependency watcher: %v", err)
+			}
+		}
+	}
+}
+
+// releaseDependentJobs runs one watcher pass over the pending dependent jobs.
+func (s *Service) releaseDependentJobs(ctx context.Context, released map[int64]struct{}) error {
+	jobs, err := s.store.ListPendingDependentJobs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if _, ok := released[job.ID]; ok {
+			continue
+		}
+		statuses, err := s.store.JobStatuses(ctx, job.DependsOnJobIDs)
+		if err != nil {
+			return err
+		}
+		ready := true
+		for _, upstream := range job.DependsOnJobIDs {
+			switch statuses[upstream] {
+			case models.JobStatusCompleted:
+				// satisfied
+			case models.JobStatusFailed, models.JobStatusCancelled:
+				reason := fmt.Sprintf("upstream job %d %s", upstream, statuses[upstream])
+				if err := s.store.MarkJobFailed(ctx, job.ID, reason); err != nil {
+					log.Printf("job %d: propagate upstream failure: %v", job.ID, err)
+				}
+				ready = false
+			default:
+				ready = false
+			}
+			if !ready {
+				break
+			}
+		}
+		if !ready {
+			continue
+		}
+		select {
+		case s.queue <- job.ID:
+			released[job.ID] = struct{}{}
+			log.Printf("job %d: dependencies satisfied, queued", job.ID)
+		default:
+			// Queue saturated; the next watcher pass retries.
+		}
+	}
+	return nil
+}
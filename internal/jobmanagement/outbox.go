@@ -0,0 +1,60 @@
+package jobmanagement
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/objectstore"
+)
+
+// outboxSweepInterval is how often the sweeper drains the object cleanup
+// outbox; outboxSweepBatch bounds one pass so a huge bulk delete cannot
+// monopolize the loop.
+const (
+	outboxSweepInterval = time.Minute
+	outboxSweepBatch    = 100
+)
+
+// SetContentStore hands the service the content store the outbox sweeper
+// releases objects through. Without it the sweeper stays disabled and
+// cleanups simply queue up.
+func (s *Service) SetContentStore(content *objectstore.ContentStore) {
+	s.content = content
+}
+
+// outboxSweeper periodically releases object references queued by
+// transactional deletes. Failures stay queued and retry on a later pass, so
+// a MinIO outage never loses a cleanup.
+func (s *Service) outboxSweeper(ctx context.Context) {
+	ticker := time.NewTicker(outboxSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweepOutbox(ctx)
+		}
+	}
+}
+
+func (s *Service) sweepOutbox(ctx context.Context) {
+	entries, err := s.store.ListObjectCleanups(ctx, outboxSweepBatch)
+	if err != nil {
+		log.Printf("outbox sweep: %v", err)
+		return
+	}
+	for _, e := range entries {
+		if err := s.content.Release(ctx, e.ObjectKey); err != nil {
+			log.Printf("outbox sweep: release %s: %v", e.ObjectKey, err)
+			if err := s.store.MarkObjectCleanupFailed(ctx, e.ID, err.Error()); err != nil {
+				log.Printf("outbox sweep: mark failed: %v", err)
+			}
+			continue
+		}
+		if err := s.store.DeleteObjectCleanup(ctx, e.ID); err != nil {
+			log.Printf("outbox sweep: dequeue %d: %v", e.ID, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package jobmanagement
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// stuckJobMaxAge is how long a RUNNING job may go without a heartbeat before
+// recovery considers its worker dead. Live workers beat every few seconds,
+// so minutes of silence means the process is gone, however long the
+// benchmark itself runs.
+const stuckJobMaxAge = 10 * time.Minute
+
+// RecoverStuckJobs finds RUNNING jobs whose heartbeat is older than maxAge —
+// their worker most likely crashed — and requeues them; the engine resumes
+// from the pairs that already have results. Jobs that cannot be requeued are marked FAILED so
+// they stop looking alive. It returns the IDs of the jobs it requeued.
+func (s *Service) RecoverStuckJobs(ctx context.Context, maxAge time.Duration) ([]int64, error) {
+	if maxAge <= 0 {
+		maxAge = stuckJobMaxAge
+	}
+	stale, err := s.store.ListStaleRunningJobs(ctx, time.Now().Add(-maxAge))
+	if err != nil {
+		return nil, fmt.Errorf("find stuck jobs: %w", err)
+	}
+	var recovered []int64
+	for _, job := range stale {
+		select {
+		case s.queue <- job.ID:
+			recovered = append(recovered, job.ID)
+			if err := s.store.AppendJobLog(ctx, job.ID, models.LogLevelWarn,
+				fmt.Sprintf("requeued after %s without progress; resuming from completed pairs",
+					maxAge)); err != nil {
+				log.Printf("job %d: append log: %v", job.ID, err)
+			}
+		default:
+			reason := fmt.Sprintf("stuck in RUNNING for over %s and the queue is full", maxAge)
+			if err := s.store.MarkJobFailed(ctx, job.ID, reason); err != nil {
+				log.Printf("job %d: mark failed: %v", job.ID, err)
+			}
+		}
+	}
+	return recovered, nil
+}
+
+// recoverOnStartup runs one recovery pass shortly after boot, picking up
+// jobs orphaned by the previous process.
+func (s *Service) recoverOnStartup(ctx context.Context) {
+	recovered, err := s.RecoverStuckJobs(ctx, stuckJobMaxAge)
+	if err != nil {
+		log.Printf("startup job recovery: %v", err)
+		return
+	}
+	if len(recovered) > 0 {
+		log.Printf("startup job recovery: requeued %d stuck jobs", len(recovered))
+	}
+}
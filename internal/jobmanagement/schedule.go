@@ -0,0 +1,152 @@
+package jobmanagement
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// schedulePollInterval is how often the scheduler looks for due schedules.
+const schedulePollInterval = time.Minute
+
+// ScheduleSpec is a parsed schedule cadence: a daily or weekly wall-clock
+// firing time. Wall-clock times are interpreted in the project's time zone,
+// so a "daily 06:30" schedule keeps firing at 06:30 local across DST
+// transitions instead of drifting by an hour.
+type ScheduleSpec struct {
+	Weekday *time.Weekday // nil for daily schedules
+	Hour    int
+	Minute  int
+}
+
+// ParseScheduleSpec parses "daily HH:MM" or "weekly <dow> HH:MM" (dow being
+// a lowercase three-letter day, e.g. "mon").
+func ParseScheduleSpec(spec string) (*ScheduleSpec, error) {
+	fields := strings.Fields(strings.ToLower(spec))
+	out := &ScheduleSpec{}
+	var clock string
+	switch {
+	case len(fields) == 2 && fields[0] == "daily":
+		clock = fields[1]
+	case len(fields) == 3 && fields[0] == "weekly":
+		wd, ok := weekdays[fields[1]]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", fields[1])
+		}
+		out.Weekday = &wd
+		clock = fields[2]
+	default:
+		return nil, fmt.Errorf("schedule spec %q: want \"daily HH:MM\" or \"weekly <dow> HH:MM\"", spec)
+	}
+	if _, err := fmt.Sscanf(clock, "%d:%d", &out.Hour, &out.Minute); err != nil {
+		return nil, fmt.Errorf("schedule spec %q: bad time %q", spec, clock)
+	}
+	if out.Hour < 0 || out.Hour > 23 || out.Minute < 0 || out.Minute > 59 {
+		return nil, fmt.Errorf("schedule spec %q: time out of range", spec)
+	}
+	return out, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday,
+	"wed": time.Wednesday, "thu": time.Thursday, "fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// NextRunAfter computes the first firing strictly after t in the given
+// location. Building the candidate with time.Date in the target location
+// normalizes across DST gaps and overlaps, so the wall-clock time holds.
+func (sp *ScheduleSpec) NextRunAfter(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), sp.Hour, sp.Minute, 0, 0, loc)
+	for !next.After(t) || (sp.Weekday != nil && next.Weekday() != *sp.Weekday) {
+		next = time.Date(next.Year(), next.Month(), next.Day()+1, sp.Hour, sp.Minute, 0, 0, loc)
+	}
+	return next
+}
+
+// scheduleWatcher fires due job schedules: it instantiates the stored job
+// template, submits it and advances next_run_at in the project's time zone.
+func (s *Service) scheduleWatcher(ctx context.Context) {
+	ticker := time.NewTicker(schedulePollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.fireDueSchedules(ctx)
+		}
+	}
+}
+
+func (s *Service) fireDueSchedules(ctx context.Context) {
+	due, err := s.store.ListDueJobSchedules(ctx, time.Now())
+	if err != nil {
+		log.Printf("scheduler: list due schedules: %v", err)
+		return
+	}
+	for _, sc := range due {
+		spec, err := ParseScheduleSpec(sc.Spec)
+		if err != nil {
+			log.Printf("scheduler: schedule %d: %v", sc.ID, err)
+			continue
+		}
+		loc := s.projectLocation(ctx, sc.ProjectID)
+		// Advance first so a submission failure cannot make the schedule
+		// fire in a tight loop every poll.
+		next := spec.NextRunAfter(time.Now(), loc)
+		if err := s.store.SetJobScheduleNextRun(ctx, sc.ID, next); err != nil {
+			log.Printf("scheduler: schedule %d: %v", sc.ID, err)
+			continue
+		}
+		if err := s.fireSchedule(ctx, sc, loc); err != nil {
+			log.Printf("scheduler: schedule %d: %v", sc.ID, err)
+		}
+	}
+}
+
+// projectLocation loads a project's time zone, falling back to UTC when the
+// project is gone or its zone no longer loads.
+func (s *Service) projectLocation(ctx context.Context, projectID int64) *time.Location {
+	project, err := s.store.GetProject(ctx, projectID)
+	if err != nil {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(project.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (s *Service) fireSchedule(ctx context.Context, sc *models.JobSchedule, loc *time.Location) error {
+	var tpl struct {
+		Name            string          `json:"name"`
+		Language        string          `json:"language"`
+		TestCaseIDs     []int64         `json:"test_case_ids"`
+		VendorConfigIDs []int64         `json:"vendor_config_ids"`
+		Parameters      json.RawMessage `json:"parameters"`
+	}
+	if err := json.Unmarshal(sc.JobTemplate, &tpl); err != nil {
+		return fmt.Errorf("job template: %w", err)
+	}
+	name := tpl.Name
+	if name == "" {
+		name = sc.Name
+	}
+	job := &models.EvaluationJob{
+		ProjectID:       sc.ProjectID,
+		Name:            fmt.Sprintf("%s %s", name, time.Now().In(loc).Format("2006-01-02 15:04")),
+		Language:        tpl.Language,
+		TestCaseIDs:     tpl.TestCaseIDs,
+		VendorConfigIDs: tpl.VendorConfigIDs,
+		Parameters:      tpl.Parameters,
+	}
+	return s.SubmitASRJob(ctx, job, false)
+}
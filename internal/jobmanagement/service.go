@@ -0,0 +1,119 @@
+// Package jobmanagement owns the evaluation job lifecycle: submission
+// checks, queuing and dispatch to the core engine. The API layer never talks
+// to the engine directly.
+package jobmanagement
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Jcateye/AITestPlatform/internal/config"
+	"github.com/Jcateye/AITestPlatform/internal/coreengine"
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/objectstore"
+)
+
+// Service accepts job submissions and dispatches them to engine workers via
+// an in-process queue. A message broker can replace the channel later
+// without changing the submission API.
+type Service struct {
+	cfg     *config.Config
+	store   *datastore.Store
+	engine  *coreengine.Engine
+	queue   chan int64
+	content *objectstore.ContentStore // nil unless SetContentStore was called
+}
+
+// NewService builds the job management service.
+func NewService(cfg *config.Config, store *datastore.Store, engine *coreengine.Engine) *Service {
+	return &Service{
+		cfg:    cfg,
+		store:  store,
+		engine: engine,
+		queue:  make(chan int64, 1024),
+	}
+}
+
+// Start launches the configured number of engine workers. Workers exit when
+// ctx is cancelled.
+func (s *Service) Start(ctx context.Context) {
+	workers := s.cfg.EngineWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		go s.worker(ctx)
+	}
+	go s.dependencyWatcher(ctx)
+	go s.scheduleWatcher(ctx)
+	go s.alertWatcher(ctx)
+	go s.recoverOnStartup(ctx)
+	if s.content != nil {
+		go s.outboxSweeper(ctx)
+	}
+}
+
+func (s *Service) worker(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobID := <-s.queue:
+			if err := s.engine.RunASREvaluation(ctx, jobID); err != nil {
+				log.Printf("job %d failed: %v", jobID, err)
+				continue
+			}
+			if job, err := s.store.GetEvaluationJob(ctx, jobID); err == nil {
+				s.evaluateProjectAlerts(ctx, job.ProjectID)
+			}
+		}
+	}
+}
+
+// SubmitASRJob validates a job, runs the budget pre-check, persists it and
+// queues it for execution. overrideBudget lets the administrator knowingly
+// push past an exceeded budget; warnings are emitted either way.
+func (s *Service) SubmitASRJob(ctx context.Context, job *models.EvaluationJob, overrideBudget bool) error {
+	if len(job.TestCaseIDs) == 0 {
+		return fmt.Errorf("job needs at least one test case")
+	}
+	if len(job.VendorConfigIDs) == 0 {
+		return fmt.Errorf("job needs at least one vendor config")
+	}
+	job.APIType = models.APITypeASR
+
+	if err := s.checkBudgets(ctx, job, overrideBudget); err != nil {
+		return err
+	}
+
+	if len(job.DependsOnJobIDs) > 0 {
+		statuses, err := s.store.JobStatuses(ctx, job.DependsOnJobIDs)
+		if err != nil {
+			return fmt.Errorf("check job dependencies: %w", err)
+		}
+		for _, upstream := range job.DependsOnJobIDs {
+			if _, ok := statuses[upstream]; !ok {
+				return fmt.Errorf("job depends on unknown job %d", upstream)
+			}
+		}
+	}
+
+	if err := s.store.CreateEvaluationJob(ctx, job); err != nil {
+		return fmt.Errorf("create job: %w", err)
+	}
+	if len(job.DependsOnJobIDs) > 0 {
+		// Dependent jobs stay PENDING; the dependency watcher queues them
+		// once every upstream completes.
+		return nil
+	}
+	select {
+	case s.queue <- job.ID:
+	default:
+		// Queue saturated; the job stays PENDING and surfaces in the job
+		// list, where it can be re-submitted once load drops.
+		log.Printf("job queue full, job %d left pending", job.ID)
+	}
+	return nil
+}
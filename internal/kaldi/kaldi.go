@@ -0,0 +1,94 @@
+// Package kaldi parses Kaldi/ESPnet-style dataset descriptions: a wav.scp
+// mapping utterance IDs to audio locations, a text file mapping them to
+// transcripts, and an optional utt2spk mapping them to speakers. Many
+// internal corpora exist only in this layout, so the importer accepts the
+// files verbatim instead of requiring a conversion step.
+package kaldi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Utterance is one corpus entry assembled from the parallel Kaldi files.
+type Utterance struct {
+	ID      string
+	Audio   string // path or URL from wav.scp
+	Text    string
+	Speaker string // empty without utt2spk
+}
+
+// Parse assembles utterances from the three files. Every utterance must
+// appear in both wav.scp and text; utt2spk may be empty. wav.scp command
+// pipes ("... |"), which Kaldi executes as shell commands, are rejected.
+func Parse(wavSCP, text, utt2spk string) ([]Utterance, error) {
+	audio, order, err := parseTable(wavSCP, "wav.scp")
+	if err != nil {
+		return nil, err
+	}
+	transcripts, _, err := parseTable(text, "text")
+	if err != nil {
+		return nil, err
+	}
+	speakers := map[string]string{}
+	if utt2spk != "" {
+		speakers, _, err = parseTable(utt2spk, "utt2spk")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]Utterance, 0, len(order))
+	for _, id := range order {
+		location := audio[id]
+		if strings.HasSuffix(strings.TrimSpace(location), "|") {
+			return nil, fmt.Errorf("wav.scp entry %q is a command pipe, which is not supported", id)
+		}
+		transcript, ok := transcripts[id]
+		if !ok {
+			return nil, fmt.Errorf("utterance %q has no text entry", id)
+		}
+		out = append(out, Utterance{
+			ID:      id,
+			Audio:   location,
+			Text:    transcript,
+			Speaker: speakers[id],
+		})
+	}
+	for id := range transcripts {
+		if _, ok := audio[id]; !ok {
+			return nil, fmt.Errorf("text entry %q has no wav.scp entry", id)
+		}
+	}
+	return out, nil
+}
+
+// parseTable reads the Kaldi two-column format: an utterance ID followed by
+// the rest of the line, one entry per line. Returns the entries plus the IDs
+// in file order.
+func parseTable(content, name string) (map[string]string, []string, error) {
+	entries := map[string]string{}
+	var order []string
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		id, rest, found := strings.Cut(line, " ")
+		if !found {
+			id, rest, found = strings.Cut(line, "\t")
+		}
+		if !found || id == "" {
+			return nil, nil, fmt.Errorf("%s line %d: expected \"<utterance-id> <value>\"", name, i+1)
+		}
+		if _, dup := entries[id]; dup {
+			return nil, nil, fmt.Errorf("%s: duplicate utterance id %q", name, id)
+		}
+		entries[id] = strings.TrimSpace(rest)
+		order = append(order, id)
+	}
+	if len(entries) == 0 {
+		return nil, nil, fmt.Errorf("%s is empty", name)
+	}
+	return entries, order, nil
+}
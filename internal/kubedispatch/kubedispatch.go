@@ -0,0 +1,137 @@
+// Package kubedispatch launches evaluation worker pods as Kubernetes Jobs.
+// It talks to the API server over plain REST with the pod's service account,
+// keeping the dependency footprint to the standard library; the platform
+// only needs to create Jobs and read their status, not the full client-go
+// surface.
+package kubedispatch
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// In-cluster service account credential paths mounted by Kubernetes.
+const (
+	serviceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	serviceAccountCAFile    = "/var/run/secrets/kubernetes.io/serviceaccount/ca.crt"
+)
+
+// Options configures the dispatcher. APIURL and Token default to the
+// in-cluster service account when empty.
+type Options struct {
+	// APIURL is the Kubernetes API server base URL. Empty means in-cluster
+	// (derived from KUBERNETES_SERVICE_HOST/PORT).
+	APIURL string
+	// Namespace the worker Jobs are created in.
+	Namespace string
+	// WorkerImage is the container image of this codebase; pods run its
+	// runner binary.
+	WorkerImage string
+	// CallbackURL is the platform API base URL workers lease tasks from
+	// and push results to.
+	CallbackURL string
+}
+
+// Client creates and inspects worker Jobs through the Kubernetes REST API.
+type Client struct {
+	opts   Options
+	token  string
+	client *http.Client
+}
+
+// New builds a Client. With an empty APIURL it loads the in-cluster service
+// account token and CA; outside a cluster that fails, which is the desired
+// behaviour since the backend cannot dispatch pods anyway.
+func New(opts Options) (*Client, error) {
+	c := &Client{opts: opts, client: &http.Client{Timeout: 30 * time.Second}}
+	if opts.APIURL == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, fmt.Errorf("not running in a Kubernetes cluster and no API URL configured")
+		}
+		c.opts.APIURL = "https://" + host + ":" + port
+		token, err := os.ReadFile(serviceAccountTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("read service account token: %w", err)
+		}
+		c.token = string(bytes.TrimSpace(token))
+		caCert, err := os.ReadFile(serviceAccountCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read service account CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("parse service account CA")
+		}
+		c.client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+	}
+	if c.opts.Namespace == "" {
+		c.opts.Namespace = "default"
+	}
+	return c, nil
+}
+
+// CreateWorkerJob creates one Kubernetes Job running a runner worker. The
+// worker authenticates with its own agent token, drains the given pool and
+// exits, so the Job completes once the pool is empty.
+func (c *Client) CreateWorkerJob(ctx context.Context, name, token, pool string) error {
+	manifest := map[string]any{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata": map[string]any{
+			"name":   name,
+			"labels": map[string]string{"app": "aitp-runner"},
+		},
+		"spec": map[string]any{
+			"backoffLimit":            2,
+			"ttlSecondsAfterFinished": 3600,
+			"template": map[string]any{
+				"spec": map[string]any{
+					"restartPolicy": "Never",
+					"containers": []map[string]any{{
+						"name":    "runner",
+						"image":   c.opts.WorkerImage,
+						"command": []string{"/runner"},
+						"env": []map[string]string{
+							{"name": "AITP_RUNNER_SERVER_URL", "value": c.opts.CallbackURL},
+							{"name": "AITP_RUNNER_TOKEN", "value": token},
+							{"name": "AITP_RUNNER_POOL", "value": pool},
+							{"name": "AITP_RUNNER_EXIT_WHEN_DRAINED", "value": "true"},
+						},
+					}},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("marshal job manifest: %w", err)
+	}
+	url := fmt.Sprintf("%s/apis/batch/v1/namespaces/%s/jobs", c.opts.APIURL, c.opts.Namespace)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("create kubernetes job: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		out, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("kubernetes returned %d creating job %s: %.200s", resp.StatusCode, name, out)
+	}
+	return nil
+}
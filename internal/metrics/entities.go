@@ -0,0 +1,40 @@
+package metrics
+
+import "strings"
+
+// EntityRecall is the fraction of annotated entity texts that appear in the
+// hypothesis, after the same normalization as WER. Spaced scripts require
+// the entity to match on token boundaries; for unspaced scripts (Chinese
+// names, addresses) a plain substring match is used.
+func EntityRecall(entities []string, hypothesis string) float64 {
+	if len(entities) == 0 {
+		return 0
+	}
+	normHyp := NormalizeText(hypothesis)
+	paddedHyp := " " + normHyp + " "
+	found := 0
+	for _, entity := range entities {
+		normEnt := NormalizeText(entity)
+		if normEnt == "" {
+			found++ // annotation is pure punctuation; nothing to miss
+			continue
+		}
+		if strings.Contains(normEnt, " ") || isASCII(normEnt) {
+			if strings.Contains(paddedHyp, " "+normEnt+" ") {
+				found++
+			}
+		} else if strings.Contains(normHyp, normEnt) {
+			found++
+		}
+	}
+	return float64(found) / float64(len(entities))
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 127 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package metrics
+
+// ErrorRateDetail breaks an edit-distance based error rate into its
+// components, supporting the insertion/deletion/substitution analysis view.
+type ErrorRateDetail struct {
+	Substitutions int     `json:"substitutions"`
+	Deletions     int     `json:"deletions"`
+	Insertions    int     `json:"insertions"`
+	RefLength     int     `json:"ref_length"`
+	Rate          float64 `json:"rate"`
+}
+
+// WER computes the word error rate between a reference and a hypothesis.
+// Inputs are normalized internally; see NormalizeText.
+func WER(reference, hypothesis string) ErrorRateDetail {
+	ref := tokenize(NormalizeText(reference))
+	hyp := tokenize(NormalizeText(hypothesis))
+	return alignErrorRate(ref, hyp)
+}
+
+// CER computes the character error rate, the standard metric for
+// character-based languages such as Chinese. Whitespace is ignored.
+func CER(reference, hypothesis string) ErrorRateDetail {
+	ref := runesOf(NormalizeText(reference))
+	hyp := runesOf(NormalizeText(hypothesis))
+	return alignErrorRate(ref, hyp)
+}
+
+func runesOf(s string) []string {
+	var out []string
+	for _, r := range s {
+		if r == ' ' {
+			continue
+		}
+		out = append(out, string(r))
+	}
+	return out
+}
+
+// alignErrorRate runs a standard Levenshtein alignment over token sequences
+// and backtracks to count substitutions, deletions and insertions.
+func alignErrorRate(ref, hyp []string) ErrorRateDetail {
+	d := ErrorRateDetail{RefLength: len(ref)}
+	if len(ref) == 0 {
+		d.Insertions = len(hyp)
+		if len(hyp) > 0 {
+			d.Rate = 1
+		}
+		return d
+	}
+
+	// dist[i][j] is the edit distance between ref[:i] and hyp[:j].
+	dist := make([][]int, len(ref)+1)
+	for i := range dist {
+		dist[i] = make([]int, len(hyp)+1)
+		dist[i][0] = i
+	}
+	for j := 0; j <= len(hyp); j++ {
+		dist[0][j] = j
+	}
+	for i := 1; i <= len(ref); i++ {
+		for j := 1; j <= len(hyp); j++ {
+			if ref[i-1] == hyp[j-1] {
+				dist[i][j] = dist[i-1][j-1]
+				continue
+			}
+			sub := dist[i-1][j-1] + 1
+			del := dist[i-1][j] + 1
+			ins := dist[i][j-1] + 1
+			dist[i][j] = min3(sub, del, ins)
+		}
+	}
+
+	// Backtrack to attribute each edit.
+	i, j := len(ref), len(hyp)
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && ref[i-1] == hyp[j-1] && dist[i][j] == dist[i-1][j-1]:
+			i--
+			j--
+		case i > 0 && j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			d.Substitutions++
+			i--
+			j--
+		case i > 0 && dist[i][j] == dist[i-1][j]+1:
+			d.Deletions++
+			i--
+		default:
+			d.Insertions++
+			j--
+		}
+	}
+
+	d.Rate = float64(d.Substitutions+d.Deletions+d.Insertions) / float64(len(ref))
+	return d
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,199 @@
+package metrics
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DeITN rewrites written-form numbers, currency amounts and percentages into
+// their spoken form, so vendors applying inverse text normalization
+// client-side are not penalized against spoken-form ground truth (or vice
+// versa). Rules exist for English and Chinese, matched on the language tag
+// prefix; other languages return the text unchanged. Both the reference and
+// the hypothesis must go through the same rewrite.
+func DeITN(language, text string) string {
+	switch {
+	case strings.HasPrefix(language, "en"):
+		return deITNEnglish(text)
+	case strings.HasPrefix(language, "zh"):
+		return deITNChinese(text)
+	default:
+		return text
+	}
+}
+
+var (
+	currencyRe = regexp.MustCompile(`([$€£¥])\s*(\d+(?:\.\d+)?)`)
+	percentRe  = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+	numberRe   = regexp.MustCompile(`\d+(?:\.\d+)?`)
+	zhYuanRe   = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*元`)
+)
+
+var currencyWordsEn = map[string]string{
+	"$": "dollars", "€": "euros", "£": "pounds", "¥": "yuan",
+}
+
+func deITNEnglish(text string) string {
+	out := currencyRe.ReplaceAllStringFunc(text, func(m string) string {
+		parts := currencyRe.FindStringSubmatch(m)
+		return numberToEnglish(parts[2]) + " " + currencyWordsEn[parts[1]]
+	})
+	out = percentRe.ReplaceAllStringFunc(out, func(m string) string {
+		return numberToEnglish(percentRe.FindStringSubmatch(m)[1]) + " percent"
+	})
+	return numberRe.ReplaceAllStringFunc(out, numberToEnglish)
+}
+
+func deITNChinese(text string) string {
+	out := currencyRe.ReplaceAllStringFunc(text, func(m string) string {
+		return numberToChinese(currencyRe.FindStringSubmatch(m)[2]) + "元"
+	})
+	out = zhYuanRe.ReplaceAllStringFunc(out, func(m string) string {
+		return numberToChinese(zhYuanRe.FindStringSubmatch(m)[1]) + "元"
+	})
+	out = percentRe.ReplaceAllStringFunc(out, func(m string) string {
+		return "百分之" + numberToChinese(percentRe.FindStringSubmatch(m)[1])
+	})
+	return numberRe.ReplaceAllStringFunc(out, numberToChinese)
+}
+
+var englishUnits = []string{"zero", "one", "two", "three", "four", "five",
+	"six", "seven", "eight", "nine", "ten", "eleven", "twelve", "thirteen",
+	"fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+
+var englishTens = []string{"", "", "twenty", "thirty", "forty", "fifty",
+	"sixty", "seventy", "eighty", "ninety"}
+
+// numberToEnglish spells a decimal string in English words. Decimals are
+// read digit by digit after "point".
+func numberToEnglish(number string) string {
+	intPart, fracPart, _ := strings.Cut(number, ".")
+	n := int64(0)
+	for _, r := range intPart {
+		n = n*10 + int64(r-'0')
+	}
+	words := intToEnglish(n)
+	if fracPart != "" {
+		digits := make([]string, 0, len(fracPart)+1)
+		digits = append(digits, "point")
+		for _, r := range fracPart {
+			digits = append(digits, englishUnits[r-'0'])
+		}
+		words += " " + strings.Join(digits, " ")
+	}
+	return words
+}
+
+func intToEnglish(n int64) string {
+	switch {
+	case n < 20:
+		return englishUnits[n]
+	case n < 100:
+		s := englishTens[n/10]
+		if n%10 != 0 {
+			s += " " + englishUnits[n%10]
+		}
+		return s
+	case n < 1000:
+		s := englishUnits[n/100] + " hundred"
+		if n%100 != 0 {
+			s += " " + intToEnglish(n%100)
+		}
+		return s
+	}
+	for _, scale := range []struct {
+		value int64
+		name  string
+	}{{1e9, "billion"}, {1e6, "million"}, {1e3, "thousand"}} {
+		if n >= scale.value {
+			s := intToEnglish(n/scale.value) + " " + scale.name
+			if n%scale.value != 0 {
+				s += " " + intToEnglish(n%scale.value)
+			}
+			return s
+		}
+	}
+	return englishUnits[0]
+}
+
+var chineseDigits = []rune("零一二三四五六七八九")
+
+// numberToChinese spells a decimal string in Chinese numerals. Decimals are
+// read digit by digit after 点.
+func numberToChinese(number string) string {
+	intPart, fracPart, _ := strings.Cut(number, ".")
+	n := int64(0)
+	for _, r := range intPart {
+		n = n*10 + int64(r-'0')
+	}
+	words := intToChinese(n)
+	if fracPart != "" {
+		var b strings.Builder
+		b.WriteString(words)
+		b.WriteRune('点')
+		for _, r := range fracPart {
+			b.WriteRune(chineseDigits[r-'0'])
+		}
+		return b.String()
+	}
+	return words
+}
+
+func intToChinese(n int64) string {
+	if n < 10 {
+		return string(chineseDigits[n])
+	}
+	if n >= 1e8 {
+		s := intToChinese(n/1e8) + "亿"
+		if rem := n % 1e8; rem != 0 {
+			if rem < 1e7 {
+				s += "零"
+			}
+			s += intToChinese(rem)
+		}
+		return s
+	}
+	if n >= 1e4 {
+		s := intToChinese(n/1e4) + "万"
+		if rem := n % 1e4; rem != 0 {
+			if rem < 1e3 {
+				s += "零"
+			}
+			s += intToChinese(rem)
+		}
+		return s
+	}
+	units := []struct {
+		value int64
+		name  rune
+	}{{1000, '千'}, {100, '百'}, {10, '十'}}
+	var b strings.Builder
+	pendingZero := false
+	started := false
+	for _, u := range units {
+		d := (n / u.value) % 10
+		if d == 0 {
+			if started {
+				pendingZero = true
+			}
+			continue
+		}
+		if pendingZero {
+			b.WriteRune('零')
+			pendingZero = false
+		}
+		// 10–19 reads 十X, not 一十X.
+		if !(u.value == 10 && d == 1 && !started) {
+			b.WriteRune(chineseDigits[d])
+		}
+		b.WriteRune(u.name)
+		started = true
+	}
+	if d := n % 10; d != 0 {
+		if pendingZero {
+			b.WriteRune('零')
+		}
+		b.WriteRune(chineseDigits[d])
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import "testing"
+
+func TestDeITNEnglish(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain digit", "I have 3 cats", "I have three cats"},
+		{"teens", "15 minutes", "fifteen minutes"},
+		{"tens with unit", "42 answers", "forty two answers"},
+		{"round hundred", "100 times", "one hundred times"},
+		{"hundred with remainder", "105 days", "one hundred five days"},
+		{"thousands", "1234 items", "one thousand two hundred thirty four items"},
+		{"million", "2000000 users", "two million users"},
+		{"decimal read digit by digit", "3.5 stars", "three point five stars"},
+		{"currency", "$5 fee", "five dollars fee"},
+		{"currency decimal", "pay €2.50", "pay two point five zero euros"},
+		{"percent", "up 20%", "up twenty percent"},
+		{"zero", "0 errors", "zero errors"},
+		{"no numbers unchanged", "no numbers here", "no numbers here"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeITN("en-US", tt.in); got != tt.want {
+				t.Errorf("DeITN(en-US, %q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeITNChinese(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"single digit", "3个", "三个"},
+		{"ten reads bare shi", "10天", "十天"},
+		{"teens", "14岁", "十四岁"},
+		{"tens", "25元", "二十五元"},
+		{"inner ten keeps yi", "110米", "一百一十米"},
+		{"zero insertion hundred", "105人", "一百零五人"},
+		{"zero insertion thousand", "1005人", "一千零五人"},
+		{"wan with zero remainder", "100050", "十万零五十"},
+		{"yi scale", "100000005", "一亿零五"},
+		{"decimal", "3.5分", "三点五分"},
+		{"yuan", "50元", "五十元"},
+		{"dollar maps to yuan", "$20", "二十元"},
+		{"percent", "涨了20%", "涨了百分之二十"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DeITN("zh-CN", tt.in); got != tt.want {
+				t.Errorf("DeITN(zh-CN, %q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeITNOtherLanguagesUnchanged(t *testing.T) {
+	in := "123 rue de la Paix, 50%"
+	if got := DeITN("fr-FR", in); got != in {
+		t.Errorf("DeITN(fr-FR, %q) = %q, want unchanged", in, got)
+	}
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Script-level language detection. Full language identification is out of
+// scope; what the sanity check needs is to catch a vendor answering in the
+// wrong script entirely — Mandarin audio transcribed as pinyin, or an
+// engine_type default returning English for Cantonese — which script
+// counting detects reliably.
+
+// DetectScript classifies the dominant script of a text: "han", "latin",
+// "kana", "hangul", "cyrillic", "arabic" or "" when no script reaches a
+// clear majority of the letters.
+func DetectScript(text string) string {
+	counts := map[string]int{}
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			counts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			counts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			counts["hangul"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			counts["cyrillic"]++
+		case unicode.Is(unicode.Arabic, r):
+			counts["arabic"]++
+		case r <= unicode.MaxLatin1 || unicode.Is(unicode.Latin, r):
+			counts["latin"]++
+		}
+	}
+	if letters < 4 {
+		return "" // too little signal to call
+	}
+	for script, n := range counts {
+		if n*2 > letters {
+			return script
+		}
+	}
+	return ""
+}
+
+// expectedScript maps a language tag's primary subtag onto the script its
+// transcripts are written in.
+func expectedScript(language string) string {
+	primary, _, _ := strings.Cut(strings.ToLower(language), "-")
+	switch primary {
+	case "zh", "yue":
+		return "han"
+	case "ja":
+		return "kana" // treated leniently below: Japanese mixes kana and Han
+	case "ko":
+		return "hangul"
+	case "ru", "uk", "bg":
+		return "cyrillic"
+	case "ar", "fa", "ur":
+		return "arabic"
+	case "en", "fr", "de", "es", "it", "pt", "nl", "id", "vi", "tr", "pl":
+		return "latin"
+	default:
+		return ""
+	}
+}
+
+// LanguageMatches reports whether a transcript's script is plausible for the
+// expected language. It only returns false on a confident mismatch: unknown
+// languages, ambiguous text and the Japanese kana/Han mix all pass.
+func LanguageMatches(language, text string) bool {
+	want := expectedScript(language)
+	if want == "" {
+		return true
+	}
+	got := DetectScript(text)
+	if got == "" {
+		return true
+	}
+	if want == "kana" && (got == "kana" || got == "han") {
+		return true
+	}
+	return got == want
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import "regexp"
+
+var numericTokenRe = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+
+// NumericTokens extracts the numeric tokens of a text in order: digit runs,
+// including decimal and thousands separators ("3.5", "1,200").
+func NumericTokens(s string) []string {
+	return numericTokenRe.FindAllString(s, -1)
+}
+
+// NumericAccuracy is the fraction of reference numeric tokens the hypothesis
+// reproduces digit-exactly and in order. A single wrong digit fails the
+// whole token, which is the point for account and phone numbers. The second
+// return is false when the reference contains no numbers at all.
+func NumericAccuracy(reference, hypothesis string) (float64, bool) {
+	ref := NumericTokens(reference)
+	if len(ref) == 0 {
+		return 0, false
+	}
+	hyp := NumericTokens(hypothesis)
+	return float64(longestCommonSubsequence(ref, hyp)) / float64(len(ref)), true
+}
+
+// longestCommonSubsequence counts the tokens an order-preserving alignment
+// of the two sequences has in common.
+func longestCommonSubsequence(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				curr[j] = prev[j-1] + 1
+			} else if prev[j] >= curr[j-1] {
+				curr[j] = prev[j]
+			} else {
+				curr[j] = curr[j-1]
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNumericTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"plain digits in order", "call 555 then 1234", []string{"555", "1234"}},
+		{"decimal stays one token", "rose 3.5 percent", []string{"3.5"}},
+		{"thousands separator stays one token", "paid 1,200 dollars", []string{"1,200"}},
+		{"digits inside words", "room A101", []string{"101"}},
+		{"no numbers", "no numbers here", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NumericTokens(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NumericTokens(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNumericAccuracy(t *testing.T) {
+	tests := []struct {
+		name     string
+		ref, hyp string
+		want     float64
+		wantOK   bool
+	}{
+		{"exact", "extension 555 1234", "extension 555 1234", 1, true},
+		// A single wrong digit fails the whole token.
+		{"wrong digit fails token", "extension 555 1234", "extension 556 1234", 0.5, true},
+		{"missing token", "555 and 1234", "just 1234", 0.5, true},
+		// The alignment is order-preserving: swapped tokens only match once.
+		{"order matters", "12 then 34", "34 then 12", 0.5, true},
+		{"hypothesis insertions do not hurt", "the 42", "7 then 42 and 9", 1, true},
+		{"no reference numbers", "no numbers", "has 42", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NumericAccuracy(tt.ref, tt.hyp)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NumericAccuracy(%q, %q) = (%v, %t), want (%v, %t)",
+					tt.ref, tt.hyp, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
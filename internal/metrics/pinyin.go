@@ -0,0 +1,167 @@
+package metrics
+
+import "strings"
+
+// PER computes the pinyin error rate for Mandarin text: both sides are
+// transliterated to pinyin syllables and aligned like CER. A homophone pick
+// (different character, same pronunciation) then stops counting as an error,
+// which separates it from a true misrecognition; comparing against CER shows
+// how much of the error is homophones. With tones disabled the comparison
+// also forgives tone-only differences.
+//
+// Characters outside the embedded table fall back to the character itself,
+// so rare hanzi, Latin text and digits still compare exactly.
+func PER(reference, hypothesis string, tones bool) ErrorRateDetail {
+	ref := pinyinOf(NormalizeText(reference), tones)
+	hyp := pinyinOf(NormalizeText(hypothesis), tones)
+	return alignErrorRate(ref, hyp)
+}
+
+func pinyinOf(s string, tones bool) []string {
+	var out []string
+	for _, r := range s {
+		if r == ' ' {
+			continue
+		}
+		syl, ok := pinyinTable[r]
+		if !ok {
+			out = append(out, string(r))
+			continue
+		}
+		if !tones {
+			syl = strings.TrimRight(syl, "12345")
+		}
+		out = append(out, syl)
+	}
+	return out
+}
+
+// pinyinTable maps the most frequent simplified characters to their most
+// common Mandarin reading, with the tone as a trailing digit (5 = neutral).
+// Polyphones carry their statistically dominant reading; the table trades
+// per-character perfection for zero dependencies, which is fine for an
+// aggregate rate. Extend it as corpora surface gaps.
+var pinyinTable = map[rune]string{
+	'的': "de5", '一': "yi1", '是': "shi4", '不': "bu4", '了': "le5",
+	'在': "zai4", '人': "ren2", '有': "you3", '我': "wo3", '他': "ta1",
+	'这': "zhe4", '个': "ge4", '们': "men5", '中': "zhong1", '来': "lai2",
+	'上': "shang4", '大': "da4", '为': "wei2", '和': "he2", '国': "guo2",
+	'地': "di4", '到': "dao4", '以': "yi3", '说': "shuo1", '时': "shi2",
+	'要': "yao4", '就': "jiu4", '出': "chu1", '会': "hui4", '可': "ke3",
+	'也': "ye3", '你': "ni3", '对': "dui4", '生': "sheng1", '能': "neng2",
+	'而': "er2", '子': "zi3", '那': "na4", '得': "de2", '于': "yu2",
+	'着': "zhe5", '下': "xia4", '自': "zi4", '之': "zhi1", '年': "nian2",
+	'过': "guo4", '发': "fa1", '后': "hou4", '作': "zuo4", '里': "li3",
+	'用': "yong4", '道': "dao4", '行': "xing2", '所': "suo3", '然': "ran2",
+	'家': "jia1", '种': "zhong3", '事': "shi4", '成': "cheng2", '方': "fang1",
+	'多': "duo1", '经': "jing1", '么': "me5", '去': "qu4", '法': "fa3",
+	'学': "xue2", '如': "ru2", '都': "dou1", '同': "tong2", '现': "xian4",
+	'当': "dang1", '没': "mei2", '动': "dong4", '面': "mian4", '起': "qi3",
+	'看': "kan4", '定': "ding4", '天': "tian1", '分': "fen1", '还': "hai2",
+	'进': "jin4", '好': "hao3", '小': "xiao3", '部': "bu4", '其': "qi2",
+	'些': "xie1", '主': "zhu3", '样': "yang4", '理': "li3", '心': "xin1",
+	'她': "ta1", '本': "ben3", '前': "qian2", '开': "kai1", '但': "dan4",
+	'因': "yin1", '只': "zhi3", '从': "cong2", '想': "xiang3", '实': "shi2",
+	'日': "ri4", '军': "jun1", '者': "zhe3", '意': "yi4", '无': "wu2",
+	'力': "li4", '它': "ta1", '与': "yu3", '长': "chang2", '把': "ba3",
+	'机': "ji1", '十': "shi2", '民': "min2", '第': "di4", '公': "gong1",
+	'此': "ci3", '已': "yi3", '工': "gong1", '使': "shi3", '情': "qing2",
+	'明': "ming2", '性': "xing4", '知': "zhi1", '全': "quan2", '三': "san1",
+	'又': "you4", '关': "guan1", '点': "dian3", '正': "zheng4", '业': "ye4",
+	'外': "wai4", '将': "jiang1", '两': "liang3", '高': "gao1", '间': "jian1",
+	'由': "you2", '问': "wen4", '很': "hen3", '最': "zui4", '重': "zhong4",
+	'并': "bing4", '物': "wu4", '手': "shou3", '应': "ying1", '战': "zhan4",
+	'向': "xiang4", '头': "tou2", '文': "wen2", '体': "ti3", '政': "zheng4",
+	'美': "mei3", '相': "xiang1", '见': "jian4", '被': "bei4", '利': "li4",
+	'什': "shen2", '二': "er4", '等': "deng3", '产': "chan3", '或': "huo4",
+	'新': "xin1", '己': "ji3", '制': "zhi4", '身': "shen1", '果': "guo3",
+	'加': "jia1", '西': "xi1", '斯': "si1", '月': "yue4", '话': "hua4",
+	'合': "he2", '回': "hui2", '特': "te4", '代': "dai4", '内': "nei4",
+	'信': "xin4", '表': "biao3", '化': "hua4", '老': "lao3", '给': "gei3",
+	'世': "shi4", '位': "wei4", '次': "ci4", '度': "du4", '门': "men2",
+	'任': "ren4", '常': "chang2", '先': "xian1", '海': "hai3", '通': "tong1",
+	'教': "jiao4", '儿': "er2", '原': "yuan2", '东': "dong1", '声': "sheng1",
+	'提': "ti2", '立': "li4", '及': "ji2", '比': "bi3", '员': "yuan2",
+	'解': "jie3", '水': "shui3", '名': "ming2", '真': "zhen1", '论': "lun4",
+	'处': "chu4", '走': "zou3", '义': "yi4", '各': "ge4", '入': "ru4",
+	'几': "ji3", '口': "kou3", '认': "ren4", '条': "tiao2", '平': "ping2",
+	'系': "xi4", '气': "qi4", '题': "ti2", '活': "huo2", '尔': "er3",
+	'更': "geng4", '别': "bie2", '打': "da3", '女': "nv3", '变': "bian4",
+	'四': "si4", '神': "shen2", '总': "zong3", '何': "he2", '电': "dian4",
+	'数': "shu4", '安': "an1", '少': "shao3", '报': "bao4", '才': "cai2",
+	'结': "jie2", '反': "fan3", '受': "shou4", '目': "mu4", '太': "tai4",
+	'量': "liang4", '再': "zai4", '感': "gan3", '建': "jian4", '务': "wu4",
+	'做': "zuo4", '接': "jie1", '必': "bi4", '场': "chang3", '件': "jian4",
+	'计': "ji4", '管': "guan3", '期': "qi1", '市': "shi4", '直': "zhi2",
+	'德': "de2", '资': "zi1", '命': "ming4", '山': "shan1", '金': "jin1",
+	'指': "zhi3", '克': "ke4", '许': "xu3", '统': "tong3", '区': "qu1",
+	'保': "bao3", '至': "zhi4", '队': "dui4", '形': "xing2", '社': "she4",
+	'便': "bian4", '空': "kong1", '决': "jue2", '治': "zhi4", '展': "zhan3",
+	'马': "ma3", '科': "ke1", '司': "si1", '五': "wu3", '基': "ji1",
+	'眼': "yan3", '书': "shu1", '非': "fei1", '则': "ze2", '听': "ting1",
+	'白': "bai2", '却': "que4", '界': "jie4", '达': "da2", '光': "guang1",
+	'放': "fang4", '强': "qiang2", '即': "ji2", '像': "xiang4", '难': "nan2",
+	'且': "qie3", '权': "quan2", '思': "si1", '王': "wang2", '象': "xiang4",
+	'完': "wan2", '设': "she4", '式': "shi4", '色': "se4", '路': "lu4",
+	'记': "ji4", '南': "nan2", '品': "pin3", '住': "zhu4", '告': "gao4",
+	'类': "lei4", '求': "qiu2", '据': "ju4", '程': "cheng2", '北': "bei3",
+	'边': "bian1", '死': "si3", '张': "zhang1", '该': "gai1", '交': "jiao1",
+	'规': "gui1", '万': "wan4", '取': "qu3", '拉': "la1", '格': "ge2",
+	'望': "wang4", '觉': "jue2", '术': "shu4", '领': "ling3", '共': "gong4",
+	'确': "que4", '传': "chuan2", '师': "shi1", '观': "guan1", '清': "qing1",
+	'今': "jin1", '切': "qie4", '院': "yuan4", '让': "rang4", '识': "shi2",
+	'候': "hou4", '带': "dai4", '导': "dao3", '争': "zheng1", '运': "yun4",
+	'笑': "xiao4", '飞': "fei1", '风': "feng1", '步': "bu4", '改': "gai3",
+	'收': "shou1", '根': "gen1", '干': "gan4", '造': "zao4", '言': "yan2",
+	'联': "lian2", '持': "chi2", '组': "zu3", '每': "mei3", '济': "ji4",
+	'车': "che1", '亲': "qin1", '极': "ji2", '林': "lin2", '服': "fu2",
+	'快': "kuai4", '办': "ban4", '议': "yi4", '往': "wang3", '元': "yuan2",
+	'英': "ying1", '士': "shi4", '证': "zheng4", '近': "jin4", '失': "shi1",
+	'转': "zhuan3", '夫': "fu1", '令': "ling4", '准': "zhun3", '布': "bu4",
+	'始': "shi3", '怎': "zen3", '呢': "ne5", '存': "cun2", '未': "wei4",
+	'远': "yuan3", '叫': "jiao4", '台': "tai2", '单': "dan1", '影': "ying3",
+	'具': "ju4", '罗': "luo2", '字': "zi4", '爱': "ai4", '击': "ji1",
+	'流': "liu2", '备': "bei4", '兵': "bing1", '连': "lian2", '调': "diao4",
+	'深': "shen1", '商': "shang1", '算': "suan4", '质': "zhi4", '团': "tuan2",
+	'集': "ji2", '百': "bai3", '需': "xu1", '价': "jia4", '花': "hua1",
+	'党': "dang3", '华': "hua2", '城': "cheng2", '石': "shi2", '级': "ji2",
+	'整': "zheng3", '府': "fu3", '离': "li2", '况': "kuang4", '亚': "ya4",
+	'请': "qing3", '技': "ji4", '际': "ji4", '约': "yue1", '示': "shi4",
+	'复': "fu4", '病': "bing4", '息': "xi1", '究': "jiu1", '线': "xian4",
+	'似': "si4", '官': "guan1", '火': "huo3", '断': "duan4", '精': "jing1",
+	'满': "man3", '支': "zhi1", '视': "shi4", '消': "xiao1", '越': "yue4",
+	'器': "qi4", '容': "rong2", '照': "zhao4", '须': "xu1", '九': "jiu3",
+	'增': "zeng1", '研': "yan2", '写': "xie3", '称': "cheng1", '企': "qi3",
+	'八': "ba1", '功': "gong1", '吗': "ma5", '包': "bao1", '片': "pian4",
+	'史': "shi3", '委': "wei3", '乎': "hu1", '查': "cha2", '轻': "qing1",
+	'易': "yi4", '早': "zao3", '曾': "ceng2", '除': "chu2", '农': "nong2",
+	'找': "zhao3", '装': "zhuang1", '广': "guang3", '显': "xian3", '吧': "ba5",
+	'阿': "a1", '李': "li3", '标': "biao1", '谈': "tan2", '吃': "chi1",
+	'图': "tu2", '念': "nian4", '六': "liu4", '引': "yin3", '历': "li4",
+	'首': "shou3", '医': "yi1", '局': "ju2", '突': "tu1", '专': "zhuan1",
+	'费': "fei4", '号': "hao4", '尽': "jin4", '另': "ling4", '周': "zhou1",
+	'较': "jiao4", '注': "zhu4", '语': "yu3", '仅': "jin3", '考': "kao3",
+	'落': "luo4", '青': "qing1", '随': "sui2", '选': "xuan3", '列': "lie4",
+	'武': "wu3", '红': "hong2", '响': "xiang3", '虽': "sui1", '推': "tui1",
+	'势': "shi4", '参': "can1", '希': "xi1", '古': "gu3", '众': "zhong4",
+	'构': "gou4", '房': "fang2", '半': "ban4", '节': "jie2", '土': "tu3",
+	'投': "tou2", '某': "mou3", '案': "an4", '黑': "hei1", '维': "wei2",
+	'革': "ge2", '划': "hua4", '敌': "di2", '致': "zhi4", '陈': "chen2",
+	'律': "lv4", '足': "zu2", '态': "tai4", '护': "hu4", '七': "qi1",
+	'兴': "xing1", '派': "pai4", '孩': "hai2", '验': "yan4", '责': "ze2",
+	'营': "ying2", '星': "xing1", '够': "gou4", '章': "zhang1", '音': "yin1",
+	'跟': "gen1", '志': "zhi4", '底': "di3", '站': "zhan4", '严': "yan2",
+	'巴': "ba1", '例': "li4", '防': "fang2", '族': "zu2", '供': "gong1",
+	'效': "xiao4", '续': "xu4", '施': "shi1", '留': "liu2", '讲': "jiang3",
+	'型': "xing2", '料': "liao4", '终': "zhong1", '答': "da2", '紧': "jin3",
+	'黄': "huang2", '绝': "jue2", '奇': "qi2", '察': "cha2", '母': "mu3",
+	'京': "jing1", '段': "duan4", '依': "yi1", '批': "pi1", '群': "qun2",
+	'项': "xiang4", '故': "gu4", '按': "an4", '河': "he2", '米': "mi3",
+	'围': "wei2", '江': "jiang1", '织': "zhi1", '害': "hai4", '斗': "dou4",
+	'双': "shuang1", '境': "jing4", '客': "ke4", '纪': "ji4", '采': "cai3",
+	'举': "ju3", '杀': "sha1", '攻': "gong1", '父': "fu4", '苏': "su1",
+	'密': "mi4", '低': "di1", '朝': "chao2", '友': "you3", '诉': "su4",
+	'止': "zhi3", '细': "xi4", '愿': "yuan4", '千': "qian1", '值': "zhi2",
+	'仍': "reng2", '男': "nan2", '钱': "qian2", '破': "po4", '网': "wang3",
+	'热': "re4", '助': "zhu4", '倒': "dao3", '育': "yu4", '属': "shu3",
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPinyinOf(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		tones bool
+		want  []string
+	}{
+		{"toned syllables", "他们", true, []string{"ta1", "men5"}},
+		{"toneless strips digits", "他们", false, []string{"ta", "men"}},
+		{"spaces skipped", "他 们", true, []string{"ta1", "men5"}},
+		{"unknown rune falls back", "a3", true, []string{"a", "3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pinyinOf(tt.in, tt.tones); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pinyinOf(%q, %t) = %v, want %v", tt.in, tt.tones, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPER(t *testing.T) {
+	tests := []struct {
+		name       string
+		ref, hyp   string
+		tones      bool
+		wantRate   float64
+		wantSubs   int
+		wantDels   int
+		wantRefLen int
+	}{
+		{"identical", "我们", "我们", true, 0, 0, 0, 2},
+		// 他 and 她 are both ta1: the homophone pick stops counting as an
+		// error, which is the metric's whole purpose.
+		{"homophone forgiven", "他说", "她说", true, 0, 0, 0, 2},
+		// 是 is shi4, 十 is shi2: a tone-only difference counts with tones
+		// on and is forgiven with tones off.
+		{"tone difference counted", "是", "十", true, 1, 1, 0, 1},
+		{"tone difference forgiven", "是", "十", false, 0, 0, 0, 1},
+		{"deletion", "我们", "我", true, 0.5, 0, 1, 2},
+		{"fallback runes compare exactly", "ok", "ok", true, 0, 0, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := PER(tt.ref, tt.hyp, tt.tones)
+			if got.Rate != tt.wantRate || got.Substitutions != tt.wantSubs ||
+				got.Deletions != tt.wantDels || got.RefLength != tt.wantRefLen {
+				t.Errorf("PER(%q, %q, %t) = %+v, want rate %v subs %d dels %d reflen %d",
+					tt.ref, tt.hyp, tt.tones, got, tt.wantRate, tt.wantSubs, tt.wantDels, tt.wantRefLen)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"regexp"
+	"strings"
+)
+
+// profanityMaskRe matches the masked tokens vendors emit in place of
+// profanity: asterisk runs ("****", "f***") and bracketed placeholders.
+var profanityMaskRe = regexp.MustCompile(`\p{L}?\*{2,}\p{L}*|\[(?:censored|profanity|beep)\]`)
+
+// profanityWordRe is a deliberately small lexicon covering the profanity our
+// test corpora contain, for English and Chinese. It exists only to make
+// masking behaviour comparable across vendors, not to be exhaustive.
+var profanityWordRe = regexp.MustCompile(`(?i)\b(fuck(?:ing)?|shit|bitch|asshole|damn)\b|他妈的|妈的|卧槽`)
+
+// CountProfanity counts unmasked profanity words in a text.
+func CountProfanity(s string) int {
+	return len(profanityWordRe.FindAllString(s, -1))
+}
+
+// CountProfanityMasks counts masked profanity tokens in a text.
+func CountProfanityMasks(s string) int {
+	return len(profanityMaskRe.FindAllString(s, -1))
+}
+
+// StripProfanity removes both masked tokens and lexicon words, so a vendor
+// that masks, a vendor that transcribes verbatim and a vendor that drops the
+// word entirely all score identically on the remaining text.
+func StripProfanity(s string) string {
+	out := profanityMaskRe.ReplaceAllString(s, " ")
+	out = profanityWordRe.ReplaceAllString(out, " ")
+	return strings.Join(strings.Fields(out), " ")
+}
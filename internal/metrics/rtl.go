@@ -0,0 +1,107 @@
+package metrics
+
+import "strings"
+
+// stripInvisible removes zero-width and bidirectional control characters:
+// BOMs, LRM/RLM marks, embedding/override/isolate controls and joiners.
+// Vendors emit them inconsistently for RTL languages, and a stray invisible
+// rune would otherwise count as a full character error.
+func stripInvisible(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0x200B && r <= 0x200F: // zero-width space/joiners, LRM, RLM
+			return -1
+		case r >= 0x202A && r <= 0x202E: // bidi embedding and override
+			return -1
+		case r >= 0x2060 && r <= 0x2069: // word joiner, bidi isolates
+			return -1
+		case r == 0x061C: // Arabic letter mark
+			return -1
+		case r == 0xFEFF: // BOM
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// composeArabic applies the NFC compositions that occur in Arabic text:
+// hamza or madda combining with its carrier letter. Vendors disagree on
+// composed versus decomposed forms, and without this an identical word
+// would score as two character errors.
+func composeArabic(s string) string {
+	if !strings.ContainsAny(s, "ٕٓٔ") {
+		return s
+	}
+	runes := []rune(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(runes); i++ {
+		if i+1 < len(runes) {
+			if c, ok := arabicCompose[[2]rune{runes[i], runes[i+1]}]; ok {
+				b.WriteRune(c)
+				i++
+				continue
+			}
+		}
+		b.WriteRune(runes[i])
+	}
+	return b.String()
+}
+
+var arabicCompose = map[[2]rune]rune{
+	{0x0627, 0x0653}: 0x0622, // alef + madda = آ
+	{0x0627, 0x0654}: 0x0623, // alef + hamza above = أ
+	{0x0627, 0x0655}: 0x0625, // alef + hamza below = إ
+	{0x0648, 0x0654}: 0x0624, // waw + hamza above = ؤ
+	{0x064A, 0x0654}: 0x0626, // yeh + hamza above = ئ
+}
+
+// StripDiacritics removes the optional pointing for languages that write it
+// inconsistently: Arabic-script tashkeel and tatweel (with the customary
+// alef/yeh/teh-marbuta folding) and Hebrew niqqud and cantillation. Matched
+// on the language tag prefix like DeITN; other languages return the text
+// unchanged. Both the reference and the hypothesis must go through the same
+// rewrite.
+func StripDiacritics(language, text string) string {
+	switch {
+	case strings.HasPrefix(language, "ar"), strings.HasPrefix(language, "fa"),
+		strings.HasPrefix(language, "ur"):
+		return stripArabicDiacritics(text)
+	case strings.HasPrefix(language, "he"), strings.HasPrefix(language, "yi"):
+		return stripHebrewPoints(text)
+	default:
+		return text
+	}
+}
+
+func stripArabicDiacritics(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0x064B && r <= 0x065F: // tashkeel and Quranic marks
+			return -1
+		case r == 0x0670: // superscript alef
+			return -1
+		case r == 0x0640: // tatweel
+			return -1
+		// Orthographic folding used by virtually every Arabic ASR
+		// normalization: hamzated alefs to bare alef, alef maqsura to
+		// yeh, teh marbuta to heh.
+		case r == 0x0622 || r == 0x0623 || r == 0x0625 || r == 0x0671:
+			return 0x0627 // ا
+		case r == 0x0649:
+			return 0x064A // ي
+		case r == 0x0629:
+			return 0x0647 // ه
+		}
+		return r
+	}, s)
+}
+
+func stripHebrewPoints(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 0x0591 && r <= 0x05C7 { // cantillation and niqqud
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import "testing"
+
+// The RTL fixtures are written with \u escapes rather than literal text:
+// several of the code points under test are invisible, and an editor or
+// tool normalizing the file would silently rewrite literal fixtures into
+// exactly the forms the functions are meant to produce.
+
+func TestStripInvisible(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"zero width space", "a\u200Bb", "ab"},
+		{"zero width joiners", "a\u200C\u200Db", "ab"},
+		{"rlm and lrm marks", "\u200Fback\u200E", "back"},
+		{"bidi override", "\u202Eabc\u202C", "abc"},
+		{"bidi isolates", "\u2066x\u2069", "x"},
+		{"word joiner", "a\u2060b", "ab"},
+		{"arabic letter mark", "\u061C\u0645", "\u0645"},
+		{"bom", "\uFEFFhello", "hello"},
+		{"plain text unchanged", "hello world", "hello world"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stripInvisible(tt.in); got != tt.want {
+				t.Errorf("stripInvisible(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComposeArabic(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"alef madda", "\u0627\u0653", "\u0622"},
+		{"alef hamza above", "\u0627\u0654", "\u0623"},
+		{"alef hamza below", "\u0627\u0655", "\u0625"},
+		{"waw hamza", "\u0648\u0654", "\u0624"},
+		{"yeh hamza", "\u064A\u0654", "\u0626"},
+		{"inside a word", "\u0633\u0627\u0654\u0644", "\u0633\u0623\u0644"},
+		{"lone combining mark kept", "\u0654", "\u0654"},
+		{"mark after other letter kept", "\u0628\u0653", "\u0628\u0653"},
+		{"no marks fast path", "\u0633\u0623\u0644", "\u0633\u0623\u0644"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := composeArabic(tt.in); got != tt.want {
+				t.Errorf("composeArabic(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripDiacritics(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		in       string
+		want     string
+	}{
+		{"arabic tashkeel", "ar", "\u0645\u064F\u062D\u064E\u0645\u0651\u064E\u062F", "\u0645\u062D\u0645\u062F"},
+		{"arabic tatweel", "ar", "\u0643\u062A\u0640\u0640\u0627\u0628", "\u0643\u062A\u0627\u0628"},
+		{"hamzated alefs fold to alef", "ar", "\u0623\u0625\u0622\u0671", "\u0627\u0627\u0627\u0627"},
+		{"alef maqsura folds to yeh", "ar", "\u0639\u0644\u0649", "\u0639\u0644\u064A"},
+		{"teh marbuta folds to heh", "ar", "\u0645\u062F\u0631\u0633\u0629", "\u0645\u062F\u0631\u0633\u0647"},
+		{"superscript alef", "ar", "\u0630\u0670\u0644\u0643", "\u0630\u0644\u0643"},
+		{"farsi uses arabic rules", "fa-IR", "\u06A9\u062A\u064E\u0627\u0628", "\u06A9\u062A\u0627\u0628"},
+		{"urdu uses arabic rules", "ur", "\u06A9\u062A\u0640\u0627\u0628", "\u06A9\u062A\u0627\u0628"},
+		{"hebrew niqqud", "he", "\u05E9\u05B8\u05C1\u05DC\u05D5\u05B9\u05DD", "\u05E9\u05DC\u05D5\u05DD"},
+		{"hebrew cantillation", "he", "\u05D5\u05B7\u05A5\u05D9\u05B9\u05BC\u05D0\u05DE\u05B6\u05E8", "\u05D5\u05D9\u05D0\u05DE\u05E8"},
+		{"unmatched language unchanged", "en", "\u0645\u064F\u062D\u064E\u0645\u0651\u064E\u062F", "\u0645\u064F\u062D\u064E\u0645\u0651\u064E\u062F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripDiacritics(tt.language, tt.in); got != tt.want {
+				t.Errorf("StripDiacritics(%s, %q) = %q, want %q", tt.language, tt.in, got, tt.want)
+			}
+		})
+	}
+}
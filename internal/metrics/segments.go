@@ -0,0 +1,100 @@
+package metrics
+
+import "strings"
+
+// SegmentAlignment is the share of a hypothesis attributed to one reference
+// segment, with its local error rate.
+type SegmentAlignment struct {
+	Hypothesis string
+	Detail     ErrorRateDetail
+}
+
+// AlignSegments distributes a full-recording hypothesis across timestamped
+// reference segments and scores each segment independently. The hypothesis
+// carries no timestamps of its own, so attribution comes from a global
+// Levenshtein alignment against the concatenated references: each hypothesis
+// token belongs to the segment of the reference token it aligned with
+// (insertions stick to the segment being consumed at that point). Each
+// segment is then re-scored locally, so per-segment counts are exact for the
+// chosen partition.
+func AlignSegments(refSegments []string, hypothesis string) []SegmentAlignment {
+	if len(refSegments) == 0 {
+		return nil
+	}
+
+	// Concatenated reference tokens, remembering which segment each is from.
+	var ref []string
+	var refSeg []int
+	for si, seg := range refSegments {
+		for _, tok := range tokenize(NormalizeText(seg)) {
+			ref = append(ref, tok)
+			refSeg = append(refSeg, si)
+		}
+	}
+	hyp := tokenize(NormalizeText(hypothesis))
+
+	hypSeg := attributeHypTokens(ref, refSeg, hyp)
+
+	out := make([]SegmentAlignment, len(refSegments))
+	hypBySeg := make([][]string, len(refSegments))
+	for j, tok := range hyp {
+		hypBySeg[hypSeg[j]] = append(hypBySeg[hypSeg[j]], tok)
+	}
+	for si, seg := range refSegments {
+		segRef := tokenize(NormalizeText(seg))
+		out[si] = SegmentAlignment{
+			Hypothesis: strings.Join(hypBySeg[si], " "),
+			Detail:     alignErrorRate(segRef, hypBySeg[si]),
+		}
+	}
+	return out
+}
+
+// attributeHypTokens assigns every hypothesis token to a segment index by
+// backtracking the global alignment.
+func attributeHypTokens(ref []string, refSeg []int, hyp []string) []int {
+	hypSeg := make([]int, len(hyp))
+	if len(ref) == 0 {
+		return hypSeg // all tokens land in segment 0
+	}
+
+	dist := make([][]int, len(ref)+1)
+	for i := range dist {
+		dist[i] = make([]int, len(hyp)+1)
+		dist[i][0] = i
+	}
+	for j := 0; j <= len(hyp); j++ {
+		dist[0][j] = j
+	}
+	for i := 1; i <= len(ref); i++ {
+		for j := 1; j <= len(hyp); j++ {
+			if ref[i-1] == hyp[j-1] {
+				dist[i][j] = dist[i-1][j-1]
+				continue
+			}
+			dist[i][j] = min3(dist[i-1][j-1]+1, dist[i-1][j]+1, dist[i][j-1]+1)
+		}
+	}
+
+	i, j := len(ref), len(hyp)
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && ref[i-1] == hyp[j-1] && dist[i][j] == dist[i-1][j-1]:
+			hypSeg[j-1] = refSeg[i-1]
+			i--
+			j--
+		case i > 0 && j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			hypSeg[j-1] = refSeg[i-1]
+			i--
+			j--
+		case i > 0 && dist[i][j] == dist[i-1][j]+1:
+			i--
+		default:
+			if i > 0 {
+				hypSeg[j-1] = refSeg[i-1]
+			}
+			j--
+		}
+	}
+	return hypSeg
+}
@@ -0,0 +1,40 @@
+package metrics
+
+import "testing"
+
+func TestFoldWidth(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"fullwidth letters", "Ｈｅｌｌｏ！", "Hello!"},
+		{"fullwidth digits", "１２３", "123"},
+		{"ideographic space", "a　b", "a b"},
+		{"halfwidth katakana", "ｶﾅ", "カナ"},
+		{"voiced mark composes", "ｶﾞ", "ガ"},
+		{"semi-voiced mark composes", "ﾊﾟ", "パ"},
+		{"u with dakuten", "ｳﾞ", "ヴ"},
+		{"mark after unvoiceable kana", "ｱﾞ", "ア゛"},
+		{"lone voiced mark", "ﾞ", "゛"},
+		{"halfwidth punctuation", "｡｢｣､･", "。「」、・"},
+		{"sokuon and prolonged sound", "ｯｰ", "ッー"},
+		{"ascii unchanged", "already plain", "already plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := foldWidth(tt.in); got != tt.want {
+				t.Errorf("foldWidth(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNormalizeTextFoldsWidth checks the fold is wired into the shared
+// normalization chain, which is what scoring actually calls.
+func TestNormalizeTextFoldsWidth(t *testing.T) {
+	got := NormalizeText("ＡＢＣ　１２３")
+	if want := "abc 123"; got != want {
+		t.Errorf("NormalizeText fullwidth = %q, want %q", got, want)
+	}
+}
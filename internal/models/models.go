@@ -0,0 +1,508 @@
+// Package models defines the shared domain types persisted by the datastore
+// and exchanged through the API. Keeping them in one place avoids import
+// cycles between the API server, job management and the core engine.
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// API types distinguish which AI component a vendor config or job targets.
+const (
+	APITypeASR = "ASR"
+	APITypeTTS = "TTS"
+	APITypeLLM = "LLM"
+)
+
+// Evaluation job lifecycle states.
+const (
+	JobStatusPending   = "PENDING"
+	JobStatusRunning   = "RUNNING"
+	JobStatusCompleted = "COMPLETED"
+	JobStatusFailed    = "FAILED"
+	JobStatusCancelled = "CANCELLED"
+)
+
+// Project is a lightweight workspace grouping vendor configs, test cases and
+// jobs. The platform has no multi-user auth; projects exist purely to keep
+// unrelated evaluation efforts (and their budgets) apart.
+type Project struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// StorageQuotaBytes is a soft cap on the project's audio storage;
+	// 0 means unlimited. Exceeding it flags the project, nothing is blocked.
+	StorageQuotaBytes int64 `json:"storage_quota_bytes,omitempty"`
+	// TimeZone is the IANA zone schedules and report timestamps for this
+	// project are expressed in.
+	TimeZone  string    `json:"time_zone"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// VendorConfig stores the connection details for one third-party AI service.
+// OtherConfigs carries vendor-specific settings (regions, app IDs, engine
+// selection and so on) as free-form JSON interpreted by the adapter.
+type VendorConfig struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Name      string `json:"name"`
+	// AdapterKey selects the provider implementation (e.g. "GoogleCloudASR"),
+	// leaving Name free for the user's own labelling. Empty falls back to
+	// Name, which configs predating the field used as the provider selector.
+	AdapterKey   string          `json:"adapter_key,omitempty"`
+	APIType      string          `json:"api_type"`
+	APIKey       string          `json:"api_key,omitempty"`
+	APISecret    string          `json:"api_secret,omitempty"`
+	APIEndpoint  string          `json:"api_endpoint,omitempty"`
+	OtherConfigs json.RawMessage `json:"other_configs,omitempty"`
+	IsActive     bool            `json:"is_active"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// WordAlignment maps one ground-truth word to its audio offsets in
+// milliseconds, as produced by the forced-alignment service.
+type WordAlignment struct {
+	Word    string `json:"word"`
+	StartMS int64  `json:"start_ms"`
+	EndMS   int64  `json:"end_ms"`
+}
+
+// Segment is one timestamped span of a long recording's ground truth.
+// Offsets are milliseconds from the start of the audio.
+type Segment struct {
+	StartMS int64  `json:"start_ms"`
+	EndMS   int64  `json:"end_ms"`
+	Text    string `json:"text"`
+}
+
+// Entity is one annotated named entity in a test case's ground truth:
+// a person, address, product name and so on. Entities drive the entity
+// recall metric, which scores whether vendors got the spans that matter.
+type Entity struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SpeakerInfo carries optional demographic and recording attributes of a
+// test case's speaker. Job summaries break metrics down per attribute value,
+// so systematic performance gaps (e.g. one accent scoring far worse) show up
+// in reports instead of hiding inside the overall means.
+type SpeakerInfo struct {
+	Gender   string `json:"gender,omitempty"`
+	AgeRange string `json:"age_range,omitempty"` // e.g. "18-29", "60+"
+	Accent   string `json:"accent,omitempty"`    // accent or dialect label
+	Device   string `json:"device,omitempty"`    // recording device/channel, e.g. "headset", "telephony"
+}
+
+// Domains is the controlled vocabulary for ASRTestCase.Domain. Unlike
+// free-form tags, the fixed set keeps domain-weighted scores comparable
+// across projects.
+var Domains = []string{"medical", "finance", "navigation", "chit-chat"}
+
+// ValidDomain reports whether s is empty or one of Domains.
+func ValidDomain(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, d := range Domains {
+		if s == d {
+			return true
+		}
+	}
+	return false
+}
+
+// SpeakerTagPrefix marks the test case tag carrying a speaker ID (e.g.
+// "speaker:spk042"), as written by the Kaldi importer and read back by the
+// dataset exporters.
+const SpeakerTagPrefix = "speaker:"
+
+// ASRTestCase is one audio clip with its ground-truth transcript. The audio
+// itself lives in object storage under AudioObjectKey. Long recordings may
+// additionally carry timestamped Segments, enabling per-segment scoring.
+type ASRTestCase struct {
+	ID             int64  `json:"id"`
+	ProjectID      int64  `json:"project_id"`
+	Name           string `json:"name"`
+	Language       string `json:"language"`
+	AudioObjectKey string `json:"audio_object_key"`
+	AudioFormat    string `json:"audio_format"`
+	// DurationMS is the clip's playback duration extracted from the audio
+	// header at upload time; 0 means the format could not be parsed.
+	DurationMS int64 `json:"duration_ms,omitempty"`
+	// Split is the named dataset partition (e.g. "train", "test") the case
+	// belongs to; empty means unassigned.
+	Split string `json:"split,omitempty"`
+	// Domain is the clip's content domain, one of Domains; empty means
+	// unclassified.
+	Domain string `json:"domain,omitempty"`
+	// Speaker carries the clip's speaker attributes; nil when none were
+	// recorded.
+	Speaker        *SpeakerInfo    `json:"speaker,omitempty"`
+	GroundTruth    string          `json:"ground_truth"`
+	Segments       []Segment       `json:"segments,omitempty"`
+	WordAlignments []WordAlignment `json:"word_alignments,omitempty"`
+	Entities       []Entity        `json:"entities,omitempty"`
+	Tags           []string        `json:"tags,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// EvaluationJob is one batch evaluation run: a set of test cases fanned out
+// to a set of vendor configs with shared parameters.
+type EvaluationJob struct {
+	ID          int64   `json:"id"`
+	ProjectID   int64   `json:"project_id"`
+	Name        string  `json:"name"`
+	APIType     string  `json:"api_type"`
+	Status      string  `json:"status"`
+	Language    string  `json:"language,omitempty"`
+	TestCaseIDs []int64 `json:"test_case_ids"`
+	// DatasetSnapshotID is set when the job targets a frozen dataset
+	// version; the engine then scores against the snapshot's pinned ground
+	// truths rather than the live ones.
+	DatasetSnapshotID *int64          `json:"dataset_snapshot_id,omitempty"`
+	VendorConfigIDs   []int64         `json:"vendor_config_ids"`
+	DependsOnJobIDs   []int64         `json:"depends_on_job_ids,omitempty"`
+	Parameters        json.RawMessage `json:"parameters,omitempty"`
+	Report            json.RawMessage `json:"report,omitempty"`
+	// ProgressTotal is how many test case × vendor pairs the job will run,
+	// recorded by the engine once the plan is known; 0 means unknown.
+	ProgressTotal   int64      `json:"progress_total,omitempty"`
+	ErrorMessage    string     `json:"error_message,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+	LastHeartbeatAt *time.Time `json:"last_heartbeat_at,omitempty"`
+}
+
+// SegmentScore is the per-segment outcome of scoring a hypothesis against a
+// segmented test case. Offsets are copied from the test case segment so
+// reviewers can seek straight to the audio span.
+type SegmentScore struct {
+	Index      int     `json:"index"`
+	StartMS    int64   `json:"start_ms"`
+	EndMS      int64   `json:"end_ms"`
+	Reference  string  `json:"reference"`
+	Hypothesis string  `json:"hypothesis"`
+	WER        float64 `json:"wer"`
+}
+
+// ASRResult is the outcome of running one test case against one vendor
+// config inside a job. Either the recognition fields or the error fields are
+// populated, never both. SegmentScores is present only for segmented test
+// cases.
+type ASRResult struct {
+	ID                int64              `json:"id"`
+	JobID             int64              `json:"job_id"`
+	ASRTestCaseID     int64              `json:"asr_test_case_id"`
+	VendorConfigID    int64              `json:"vendor_config_id"`
+	RecognizedText    string             `json:"recognized_text"`
+	PostProcessedText string             `json:"post_processed_text,omitempty"`
+	WER               float64            `json:"wer"`
+	CER               float64            `json:"cer"`
+	Confidence        float64            `json:"confidence,omitempty"`
+	LatencyMS         int64              `json:"latency_ms"`
+	VendorLatencyMS   int64              `json:"vendor_latency_ms,omitempty"`
+	AudioBytes        int64              `json:"audio_bytes,omitempty"`
+	ResponseBytes     int64              `json:"response_bytes,omitempty"`
+	Region            string             `json:"region,omitempty"`
+	Revision          int64              `json:"revision,omitempty"`
+	SegmentScores     []SegmentScore     `json:"segment_scores,omitempty"`
+	ExtraMetrics      map[string]float64 `json:"extra_metrics,omitempty"`
+	RawVendorResponse json.RawMessage    `json:"raw_vendor_response,omitempty"`
+	ErrorCode         string             `json:"error_code,omitempty"`
+	ErrorMessage      string             `json:"error_message,omitempty"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// GroundTruthRevision records one edit to a test case's ground truth, made
+// when a reviewer promotes a (possibly corrected) vendor hypothesis. The
+// previous text is kept so a bad promotion can be reverted by hand.
+type GroundTruthRevision struct {
+	ID             int64     `json:"id"`
+	ASRTestCaseID  int64     `json:"asr_test_case_id"`
+	PreviousText   string    `json:"previous_text"`
+	NewText        string    `json:"new_text"`
+	SourceResultID *int64    `json:"source_result_id,omitempty"`
+	Editor         string    `json:"editor"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// DatasetSnapshotCase pins one test case inside a snapshot: the case ID plus
+// its ground truth as it stood at freeze time.
+type DatasetSnapshotCase struct {
+	TestCaseID  int64  `json:"test_case_id"`
+	GroundTruth string `json:"ground_truth"`
+}
+
+// DatasetSnapshot is an immutable named version of a project's dataset.
+// Jobs referencing a snapshot always evaluate the frozen case list against
+// the frozen ground truths, so "Benchmark v3" keeps meaning the same thing
+// after clips are added or transcripts corrected.
+type DatasetSnapshot struct {
+	ID        int64                 `json:"id"`
+	ProjectID int64                 `json:"project_id"`
+	Name      string                `json:"name"`
+	Cases     []DatasetSnapshotCase `json:"cases"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+// Budget caps monthly spend for a project, optionally narrowed to a single
+// vendor config. A nil VendorConfigID means the budget covers the whole
+// project.
+type Budget struct {
+	ID              int64     `json:"id"`
+	ProjectID       int64     `json:"project_id"`
+	VendorConfigID  *int64    `json:"vendor_config_id,omitempty"`
+	MonthlyLimitUSD float64   `json:"monthly_limit_usd"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// SpendRecord is one ledger entry of estimated vendor spend, written by the
+// engine as results complete. Month is YYYY-MM in UTC for cheap grouping.
+type SpendRecord struct {
+	ID             int64     `json:"id"`
+	ProjectID      int64     `json:"project_id"`
+	VendorConfigID int64     `json:"vendor_config_id"`
+	JobID          int64     `json:"job_id"`
+	AmountUSD      float64   `json:"amount_usd"`
+	Month          string    `json:"month"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Runner task lifecycle states.
+const (
+	RunnerTaskPending = "PENDING"
+	RunnerTaskLeased  = "LEASED"
+	RunnerTaskDone    = "DONE"
+)
+
+// RunnerAgent is a remote runner that executes evaluation tasks for vendors
+// reachable only from its network. Token authenticates the agent API and is
+// returned exactly once, at registration.
+type RunnerAgent struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Pool       string     `json:"pool"`
+	Token      string     `json:"token,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+// RunnerTask is one test case × vendor pair assigned to a runner pool
+// instead of the in-process engine.
+type RunnerTask struct {
+	ID             int64      `json:"id"`
+	JobID          int64      `json:"job_id"`
+	ASRTestCaseID  int64      `json:"asr_test_case_id"`
+	VendorConfigID int64      `json:"vendor_config_id"`
+	Pool           string     `json:"pool"`
+	Status         string     `json:"status"`
+	LeasedBy       *int64     `json:"leased_by,omitempty"`
+	LeasedAt       *time.Time `json:"leased_at,omitempty"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Export lifecycle states.
+const (
+	ExportStatusPending   = "PENDING"
+	ExportStatusCompleted = "COMPLETED"
+	ExportStatusFailed    = "FAILED"
+)
+
+// Export is one asynchronous file export of a job's results. The file is
+// written to object storage; clients poll the export and receive a presigned
+// download URL once it completes.
+type Export struct {
+	ID int64 `json:"id"`
+	// Exactly one of JobID (result exports) and ProjectID (dataset exports)
+	// is set.
+	JobID        int64      `json:"job_id,omitempty"`
+	ProjectID    int64      `json:"project_id,omitempty"`
+	Format       string     `json:"format"`
+	Status       string     `json:"status"`
+	ObjectKey    string     `json:"object_key,omitempty"`
+	ErrorMessage string     `json:"error_message,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+// ReportSnapshot freezes a job's aggregate numbers at a point in time.
+// Summaries recompute from live results and therefore drift when results
+// are re-run; published reports reference a snapshot ID instead, so the
+// numbers stay reproducible.
+type ReportSnapshot struct {
+	ID        int64           `json:"id"`
+	JobID     int64           `json:"job_id"`
+	Summary   json.RawMessage `json:"summary"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// JobSchedule submits a job from a stored template on a recurring cadence.
+// Spec is a small cron subset ("daily HH:MM" or "weekly <dow> HH:MM")
+// interpreted in the project's time zone; NextRunAt is the precomputed next
+// firing in UTC.
+type JobSchedule struct {
+	ID          int64           `json:"id"`
+	ProjectID   int64           `json:"project_id"`
+	Name        string          `json:"name"`
+	Spec        string          `json:"spec"`
+	JobTemplate json.RawMessage `json:"job_template"`
+	Enabled     bool            `json:"enabled"`
+	NextRunAt   time.Time       `json:"next_run_at"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// TestSuite is a named, stable grouping of test cases. Jobs can reference a
+// suite instead of listing individual case IDs, so recurring benchmarks keep
+// scoring the same dataset while the corpus grows around it.
+type TestSuite struct {
+	ID          int64     `json:"id"`
+	ProjectID   int64     `json:"project_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	TestCaseIDs []int64   `json:"test_case_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ScoreComponent is one weighted term of a composite score formula. Metric
+// names a summary metric ("wer", "cer", "latency_ms") or any extra metric
+// results carry ("keyword_recall", "entity_recall", ...).
+type ScoreComponent struct {
+	Metric string  `json:"metric"`
+	Weight float64 `json:"weight"`
+	// NormalizeMax maps raw values onto [0,1] by dividing by it and
+	// clamping; 0 means the metric already lives in [0,1].
+	NormalizeMax float64 `json:"normalize_max,omitempty"`
+	// Invert scores 1−x after normalization, turning error rates and
+	// latencies into higher-is-better terms.
+	Invert bool `json:"invert,omitempty"`
+}
+
+// ScoreFormula is a project's composite score definition: the weighted sum
+// of its components, computed per vendor over a job's results. Storing the
+// formula centrally replaces the per-team spreadsheet variants that kept
+// drifting apart.
+type ScoreFormula struct {
+	ID         int64            `json:"id"`
+	ProjectID  int64            `json:"project_id"`
+	Name       string           `json:"name"`
+	Components []ScoreComponent `json:"components"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// AlertMetrics is the set of aggregates alert rules can watch.
+var AlertMetrics = []string{"mean_wer", "mean_cer", "mean_latency_ms", "error_rate"}
+
+// ValidAlertMetric reports whether s is one of AlertMetrics.
+func ValidAlertMetric(s string) bool {
+	for _, m := range AlertMetrics {
+		if s == m {
+			return true
+		}
+	}
+	return false
+}
+
+// AlertRule watches one metric over a project's latest completed jobs and
+// fires a notification when the comparison holds. Firing tracks the rule's
+// current state so repeated evaluations do not re-notify and a passing
+// evaluation emits a resolve event.
+type AlertRule struct {
+	ID        int64  `json:"id"`
+	ProjectID int64  `json:"project_id"`
+	Name      string `json:"name"`
+	Metric    string `json:"metric"`
+	// VendorConfigID narrows the rule to one vendor; nil covers all.
+	VendorConfigID *int64 `json:"vendor_config_id,omitempty"`
+	// Tag narrows the rule to results whose test case carries the tag,
+	// e.g. a dataset label; empty covers all.
+	Tag        string  `json:"tag,omitempty"`
+	Comparison string  `json:"comparison"` // ">" or "<"
+	Threshold  float64 `json:"threshold"`
+	// WindowJobs is how many of the project's most recent completed jobs
+	// the metric aggregates over; 1 means the latest run only.
+	WindowJobs int       `json:"window_jobs"`
+	Enabled    bool      `json:"enabled"`
+	Firing     bool      `json:"firing"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// QA review lifecycle states.
+const (
+	QAReviewPending   = "PENDING"
+	QAReviewCompleted = "COMPLETED"
+)
+
+// QAReview is one sampled result assigned to a human reviewer. Reviewers
+// score perceived transcript quality in [0,1]; comparing those scores with
+// the automatic metrics validates that WER tracks perceived quality.
+type QAReview struct {
+	ID             int64      `json:"id"`
+	JobID          int64      `json:"job_id"`
+	ResultID       int64      `json:"result_id"`
+	VendorConfigID int64      `json:"vendor_config_id"`
+	Reviewer       string     `json:"reviewer"`
+	Status         string     `json:"status"`
+	Score          *float64   `json:"score,omitempty"`
+	Notes          string     `json:"notes,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	CompletedAt    *time.Time `json:"completed_at,omitempty"`
+}
+
+// Job log levels.
+const (
+	LogLevelInfo  = "INFO"
+	LogLevelWarn  = "WARN"
+	LogLevelError = "ERROR"
+)
+
+// JobLog is one line of a job's execution log, captured by the engine so
+// progress and warnings are inspectable through the API after the fact
+// instead of living only in server stdout.
+type JobLog struct {
+	ID        int64     `json:"id"`
+	JobID     int64     `json:"job_id"`
+	Level     string    `json:"level"`
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Well-known feature flag names. Flags not listed here are free-form and
+// interpreted by whatever reads them.
+const (
+	// FlagMaintenanceMode puts the admin API into read-only mode: every
+	// mutating request except flag management is refused with 503.
+	FlagMaintenanceMode = "maintenance_mode"
+	// FlagJobSubmission gates new job submissions; disable it during
+	// vendor incidents to stop the queue from growing.
+	FlagJobSubmission = "job_submission"
+)
+
+// FeatureFlag toggles platform behavior at runtime without a redeploy.
+// ProjectID 0 is a global flag; a project-scoped flag with the same name
+// overrides it for that project.
+type FeatureFlag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	ProjectID int64     `json:"project_id"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Notification is a platform-generated message for the administrator, e.g. a
+// budget nearing its limit.
+type Notification struct {
+	ID        int64     `json:"id"`
+	ProjectID int64     `json:"project_id"`
+	Kind      string    `json:"kind"`
+	Message   string    `json:"message"`
+	Read      bool      `json:"read"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,107 @@
+// Package objectstore wraps MinIO (or any S3-compatible service) for storing
+// unstructured evaluation artifacts: uploaded ASR audio, synthesized TTS
+// output and archived payloads.
+package objectstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// Client is a thin wrapper around the MinIO SDK bound to one bucket.
+type Client struct {
+	mc     *minio.Client
+	bucket string
+}
+
+// Options configures the object store connection.
+type Options struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	UseSSL    bool
+}
+
+// New connects to the object store and creates the bucket if needed.
+func New(ctx context.Context, opts Options) (*Client, error) {
+	mc, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("connect object store: %w", err)
+	}
+	exists, err := mc.BucketExists(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("check bucket %q: %w", opts.Bucket, err)
+	}
+	if !exists {
+		if err := mc.MakeBucket(ctx, opts.Bucket, minio.MakeBucketOptions{}); err != nil {
+			return nil, fmt.Errorf("create bucket %q: %w", opts.Bucket, err)
+		}
+	}
+	return &Client{mc: mc, bucket: opts.Bucket}, nil
+}
+
+// Put uploads an object under the given key.
+func (c *Client) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := c.mc.PutObject(ctx, c.bucket, key, r, size,
+		minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("put object %q: %w", key, err)
+	}
+	return nil
+}
+
+// Get downloads an object fully into memory. Evaluation audio clips are
+// short, so buffering is acceptable here.
+func (c *Client) Get(ctx context.Context, key string) ([]byte, error) {
+	obj, err := c.mc.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get object %q: %w", key, err)
+	}
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		return nil, fmt.Errorf("read object %q: %w", key, err)
+	}
+	return data, nil
+}
+
+// Delete removes an object.
+func (c *Client) Delete(ctx context.Context, key string) error {
+	if err := c.mc.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("delete object %q: %w", key, err)
+	}
+	return nil
+}
+
+// PresignedGetURL returns a time-limited URL for direct download.
+func (c *Client) PresignedGetURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	u, err := c.mc.PresignedGetObject(ctx, c.bucket, key, expiry, nil)
+	if err != nil {
+		return "", fmt.Errorf("presign object %q: %w", key, err)
+	}
+	return u.String(), nil
+}
+
+// NewObjectKey generates a random object key under the given prefix,
+// preserving the file extension so content types stay guessable.
+func NewObjectKey(prefix, ext string) string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+	if ext != "" && ext[0] != '.' {
+		ext = "." + ext
+	}
+	return fmt.Sprintf("%s/%s%s", prefix, hex.EncodeToString(buf), ext)
+}
@@ -0,0 +1,48 @@
+// Package pricing provides rough per-call cost estimates for vendor API
+// usage. The numbers are deliberately conservative list-price approximations;
+// they exist to drive budget tracking, not billing reconciliation. A config
+// can override its rate via the "price_per_call_usd" key in OtherConfigs.
+package pricing
+
+import (
+	"encoding/json"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// defaultPerCallUSD maps adapter keys to an approximate cost of one short
+// recognition call (roughly one utterance up to 60 seconds).
+var defaultPerCallUSD = map[string]float64{
+	"GoogleCloudASR":  0.024,
+	"AzureSpeechASR":  0.017,
+	"DeepgramASR":     0.0059,
+	"TencentCloudASR": 0.003,
+	"AliCloudASR":     0.003,
+	"VolcengineASR":   0.003,
+}
+
+// EstimatePerCall returns the estimated USD cost of one call against the
+// given vendor config. Unknown vendors (including the mock) cost nothing.
+func EstimatePerCall(vc *models.VendorConfig) float64 {
+	if len(vc.OtherConfigs) > 0 {
+		var override struct {
+			PricePerCallUSD *float64 `json:"price_per_call_usd"`
+		}
+		if err := json.Unmarshal(vc.OtherConfigs, &override); err == nil && override.PricePerCallUSD != nil {
+			return *override.PricePerCallUSD
+		}
+	}
+	// Same fallback as vendoradapter.AdapterKey: configs predating the
+	// adapter_key field selected their provider by name.
+	key := vc.AdapterKey
+	if key == "" {
+		key = vc.Name
+	}
+	return defaultPerCallUSD[key]
+}
+
+// EstimateJobSpend returns the estimated USD cost of running numCases test
+// cases against the vendor config.
+func EstimateJobSpend(vc *models.VendorConfig, numCases int) float64 {
+	return EstimatePerCall(vc) * float64(numCases)
+}
@@ -0,0 +1,144 @@
+package subtitle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// CueOptions control how word timestamps are grouped into subtitle cues.
+type CueOptions struct {
+	MaxChars int   // characters per cue before a break is forced
+	MaxGapMS int64 // silence between words that forces a break
+	MaxDurMS int64 // cue duration before a break is forced
+}
+
+// DefaultCueOptions follows common captioning guidelines: two 21-character
+// lines, breaks at ~0.8 s pauses, cues no longer than 5 s.
+func DefaultCueOptions() CueOptions {
+	return CueOptions{MaxChars: 42, MaxGapMS: 800, MaxDurMS: 5000}
+}
+
+// FromWords groups per-word timestamps into subtitle cues.
+func FromWords(words []models.WordAlignment, opts CueOptions) []models.Segment {
+	var cues []models.Segment
+	var current []string
+	var start, end int64
+	flush := func() {
+		if len(current) > 0 {
+			cues = append(cues, models.Segment{
+				StartMS: start, EndMS: end, Text: strings.Join(current, " "),
+			})
+			current = nil
+		}
+	}
+	chars := 0
+	for _, w := range words {
+		gap := w.StartMS - end
+		if len(current) > 0 &&
+			(chars+len(w.Word)+1 > opts.MaxChars || gap > opts.MaxGapMS ||
+				w.EndMS-start > opts.MaxDurMS) {
+			flush()
+		}
+		if len(current) == 0 {
+			start = w.StartMS
+			chars = 0
+		}
+		current = append(current, w.Word)
+		chars += len(w.Word) + 1
+		end = w.EndMS
+	}
+	flush()
+	return cues
+}
+
+// maxReadingCPS is the reading-speed guideline cues are checked against:
+// 17 characters per second, the common broadcast captioning limit.
+const maxReadingCPS = 17.0
+
+// boundaryToleranceMS is how close a hypothesis cue boundary must land to a
+// reference boundary to count as matched.
+const boundaryToleranceMS = 500
+
+// Quality summarizes generated cues against reference cues.
+type Quality struct {
+	Cues                   int     `json:"cues"`
+	MeanTimingDriftMS      float64 `json:"mean_timing_drift_ms"`
+	SegmentationF1         float64 `json:"segmentation_f1"`
+	ReadingSpeedViolations int     `json:"reading_speed_violations"`
+}
+
+// Evaluate scores hypothesis cues against reference cues: timing drift is
+// the mean distance of hypothesis cue starts to the nearest reference start,
+// segmentation is boundary F1 within the tolerance, and reading speed counts
+// cues exceeding the characters-per-second guideline.
+func Evaluate(reference, hypothesis []models.Segment) Quality {
+	q := Quality{Cues: len(hypothesis)}
+	if len(hypothesis) == 0 || len(reference) == 0 {
+		return q
+	}
+
+	var driftSum float64
+	matched := 0
+	for _, cue := range hypothesis {
+		nearest := int64(-1)
+		for _, ref := range reference {
+			d := cue.StartMS - ref.StartMS
+			if d < 0 {
+				d = -d
+			}
+			if nearest < 0 || d < nearest {
+				nearest = d
+			}
+		}
+		driftSum += float64(nearest)
+		if nearest <= boundaryToleranceMS {
+			matched++
+		}
+	}
+	q.MeanTimingDriftMS = driftSum / float64(len(hypothesis))
+	q.SegmentationF1 = 2 * float64(matched) / float64(len(reference)+len(hypothesis))
+
+	for _, cue := range hypothesis {
+		durS := float64(cue.EndMS-cue.StartMS) / 1000
+		if durS <= 0 {
+			continue
+		}
+		if float64(len([]rune(cue.Text)))/durS > maxReadingCPS {
+			q.ReadingSpeedViolations++
+		}
+	}
+	return q
+}
+
+// FormatSRT renders cues as a SubRip file.
+func FormatSRT(cues []models.Segment) string {
+	var b strings.Builder
+	for i, cue := range cues {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n",
+			i+1, srtTimecode(cue.StartMS), srtTimecode(cue.EndMS), cue.Text)
+	}
+	return b.String()
+}
+
+// FormatVTT renders cues as a WebVTT file.
+func FormatVTT(cues []models.Segment) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, cue := range cues {
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n",
+			vttTimecode(cue.StartMS), vttTimecode(cue.EndMS), cue.Text)
+	}
+	return b.String()
+}
+
+func srtTimecode(ms int64) string {
+	return fmt.Sprintf("%02d:%02d:%02d,%03d",
+		ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
+
+func vttTimecode(ms int64) string {
+	return fmt.Sprintf("%02d:%02d:%02d.%03d",
+		ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
@@ -0,0 +1,174 @@
+// Package subtitle parses time-aligned ground-truth reference formats into
+// the platform's segment model. Supported formats are SRT, WebVTT, Praat
+// TextGrid and NIST STM — the formats annotation tools and caption teams
+// actually exchange.
+package subtitle
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// Parse selects a parser from the file extension. The returned segments are
+// in file order with millisecond offsets.
+func Parse(filename string, data []byte) ([]models.Segment, error) {
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(filename), "."))
+	switch ext {
+	case "srt":
+		return ParseSRT(data)
+	case "vtt":
+		return ParseVTT(data)
+	case "textgrid":
+		return ParseTextGrid(data)
+	case "stm":
+		return ParseSTM(data)
+	default:
+		return nil, fmt.Errorf("unsupported ground-truth format %q (want srt, vtt, textgrid or stm)", ext)
+	}
+}
+
+// timecodeRe matches SRT/VTT cue timings; SRT uses a comma before the
+// milliseconds, VTT a dot, and VTT may omit the hours.
+var timecodeRe = regexp.MustCompile(`(?:(\d+):)?(\d{2}):(\d{2})[.,](\d{3})`)
+
+func parseTimecode(s string) (int64, bool) {
+	m := timecodeRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+	hours := int64(0)
+	if m[1] != "" {
+		hours, _ = strconv.ParseInt(m[1], 10, 64)
+	}
+	minutes, _ := strconv.ParseInt(m[2], 10, 64)
+	seconds, _ := strconv.ParseInt(m[3], 10, 64)
+	millis, _ := strconv.ParseInt(m[4], 10, 64)
+	return ((hours*60+minutes)*60+seconds)*1000 + millis, true
+}
+
+// parseCues handles the shared SRT/VTT shape: blank-line-separated blocks
+// with a "start --> end" timing line followed by the cue text.
+func parseCues(data []byte) ([]models.Segment, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	var segments []models.Segment
+	for _, block := range strings.Split(text, "\n\n") {
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+		timingIdx := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timingIdx = i
+				break
+			}
+		}
+		if timingIdx < 0 {
+			continue // header, cue number on its own, or noise
+		}
+		startStr, endStr, _ := strings.Cut(lines[timingIdx], "-->")
+		start, okStart := parseTimecode(startStr)
+		end, okEnd := parseTimecode(endStr)
+		if !okStart || !okEnd {
+			return nil, fmt.Errorf("bad cue timing %q", lines[timingIdx])
+		}
+		cueText := strings.TrimSpace(strings.Join(lines[timingIdx+1:], " "))
+		if cueText == "" {
+			continue
+		}
+		segments = append(segments, models.Segment{StartMS: start, EndMS: end, Text: cueText})
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no cues found")
+	}
+	return segments, nil
+}
+
+// ParseSRT parses a SubRip subtitle file.
+func ParseSRT(data []byte) ([]models.Segment, error) {
+	return parseCues(data)
+}
+
+// ParseVTT parses a WebVTT subtitle file.
+func ParseVTT(data []byte) ([]models.Segment, error) {
+	text := string(data)
+	if !strings.HasPrefix(strings.TrimSpace(text), "WEBVTT") {
+		return nil, fmt.Errorf("missing WEBVTT header")
+	}
+	return parseCues(data)
+}
+
+var textGridFieldRe = regexp.MustCompile(`(xmin|xmax|text)\s*=\s*(?:"((?:[^"]|"")*)"|([\d.]+))`)
+
+// ParseTextGrid parses a Praat TextGrid, collecting the non-empty intervals
+// of all interval tiers in order. Both the long and short text formats store
+// intervals as xmin/xmax/text triples, which is all we read.
+func ParseTextGrid(data []byte) ([]models.Segment, error) {
+	var segments []models.Segment
+	var xmin, xmax float64
+	var haveMin, haveMax bool
+	for _, m := range textGridFieldRe.FindAllStringSubmatch(string(data), -1) {
+		switch m[1] {
+		case "xmin":
+			xmin, _ = strconv.ParseFloat(m[3], 64)
+			haveMin = true
+		case "xmax":
+			xmax, _ = strconv.ParseFloat(m[3], 64)
+			haveMax = true
+		case "text":
+			text := strings.TrimSpace(strings.ReplaceAll(m[2], `""`, `"`))
+			if text != "" && haveMin && haveMax {
+				segments = append(segments, models.Segment{
+					StartMS: int64(xmin * 1000),
+					EndMS:   int64(xmax * 1000),
+					Text:    text,
+				})
+			}
+			haveMin, haveMax = false, false
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no labelled intervals found")
+	}
+	return segments, nil
+}
+
+// ParseSTM parses a NIST STM file: one segment per line as
+// "file channel speaker begin end [<label>] transcript", times in seconds.
+func ParseSTM(data []byte) ([]models.Segment, error) {
+	var segments []models.Segment
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, ";;") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("bad stm line %q", line)
+		}
+		begin, err1 := strconv.ParseFloat(fields[3], 64)
+		end, err2 := strconv.ParseFloat(fields[4], 64)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("bad stm times in %q", line)
+		}
+		rest := fields[5:]
+		if strings.HasPrefix(rest[0], "<") {
+			rest = rest[1:] // optional category label
+		}
+		text := strings.Join(rest, " ")
+		if text == "" || text == "ignore_time_segment_in_scoring" {
+			continue
+		}
+		segments = append(segments, models.Segment{
+			StartMS: int64(begin * 1000),
+			EndMS:   int64(end * 1000),
+			Text:    text,
+		})
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no segments found")
+	}
+	return segments, nil
+}
@@ -0,0 +1,49 @@
+package synthdata
+
+import (
+	"strings"
+	"unicode"
+)
+
+// CodeSwitchedTag marks test cases whose ground truth mixes scripts (e.g.
+// Mandarin with embedded English terms). It is applied automatically on
+// upload and generation so summaries can break performance down on exactly
+// these cases.
+const CodeSwitchedTag = "code-switched"
+
+// IsCodeSwitched reports whether text contains both Han characters and Latin
+// letters — the dominant code-switching pattern in this platform's datasets.
+func IsCodeSwitched(text string) bool {
+	var hasHan, hasLatin bool
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			hasHan = true
+		case unicode.Is(unicode.Latin, r):
+			hasLatin = true
+		}
+		if hasHan && hasLatin {
+			return true
+		}
+	}
+	return false
+}
+
+// SpliceTerm embeds term into sentence at a deterministic position, producing
+// a code-switched variant. Space-delimited sentences get the term inserted
+// after the middle word; unspaced (Han) sentences get it inserted halfway
+// through the runes, space-padded so tokenization keeps it as one unit.
+func SpliceTerm(sentence, term string) string {
+	words := strings.Fields(sentence)
+	if len(words) > 1 {
+		mid := len(words) / 2
+		spliced := make([]string, 0, len(words)+1)
+		spliced = append(spliced, words[:mid]...)
+		spliced = append(spliced, term)
+		spliced = append(spliced, words[mid:]...)
+		return strings.Join(spliced, " ")
+	}
+	runes := []rune(sentence)
+	mid := len(runes) / 2
+	return string(runes[:mid]) + " " + term + " " + string(runes[mid:])
+}
@@ -0,0 +1,137 @@
+package testharness
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/cache"
+	"github.com/Jcateye/AITestPlatform/internal/coreengine"
+	"github.com/Jcateye/AITestPlatform/internal/datastore"
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// TestFullPipelineWithStubVendors runs a complete evaluation job through the
+// real engine and datastore against stubbed vendor servers and an in-memory
+// object store. It requires a scratch PostgreSQL database and is skipped
+// when AITP_TEST_DATABASE_URL is unset.
+func TestFullPipelineWithStubVendors(t *testing.T) {
+	dbURL := os.Getenv("AITP_TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("AITP_TEST_DATABASE_URL not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	store, err := datastore.Open(ctx, dbURL)
+	if err != nil {
+		t.Fatalf("open datastore: %v", err)
+	}
+	defer store.Close()
+	if err := store.EnsureSchema(ctx); err != nil {
+		t.Fatalf("ensure schema: %v", err)
+	}
+
+	deepgram := NewDeepgramStub(StubOptions{Transcript: "hello world"})
+	defer deepgram.Close()
+	azure := NewAzureStub(StubOptions{Transcript: "hello word", Latency: 50 * time.Millisecond})
+	defer azure.Close()
+	broken := NewTencentStub(StubOptions{FailStatus: 401})
+	defer broken.Close()
+
+	objects := NewMemObjectStore()
+	const audioKey = "asr-audio/e2e-test.wav"
+	if err := objects.Put(ctx, audioKey, bytes.NewReader([]byte("RIFF-fake-wav")), 0, "audio/wav"); err != nil {
+		t.Fatalf("seed audio: %v", err)
+	}
+
+	project := &models.Project{Name: "e2e-" + time.Now().Format("150405.000000")}
+	if err := store.CreateProject(ctx, project); err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	newVendor := func(name, endpoint string) *models.VendorConfig {
+		vc := &models.VendorConfig{
+			ProjectID:   project.ID,
+			Name:        name,
+			APIType:     models.APITypeASR,
+			APIKey:      "stub-key",
+			APIEndpoint: endpoint,
+			IsActive:    true,
+		}
+		if name == "TencentCloudASR" {
+			vc.APISecret = "stub-secret"
+		}
+		if err := store.CreateVendorConfig(ctx, vc); err != nil {
+			t.Fatalf("create vendor %s: %v", name, err)
+		}
+		return vc
+	}
+	dg := newVendor("DeepgramASR", deepgram.URL)
+	az := newVendor("AzureSpeechASR", azure.URL)
+	tc := newVendor("TencentCloudASR", broken.URL)
+
+	testCase := &models.ASRTestCase{
+		ProjectID:      project.ID,
+		Name:           "e2e clip",
+		Language:       "en-US",
+		AudioObjectKey: audioKey,
+		AudioFormat:    "wav",
+		GroundTruth:    "hello world",
+	}
+	if err := store.CreateASRTestCase(ctx, testCase); err != nil {
+		t.Fatalf("create test case: %v", err)
+	}
+
+	job := &models.EvaluationJob{
+		ProjectID:       project.ID,
+		Name:            "e2e job",
+		APIType:         models.APITypeASR,
+		Language:        "en-US",
+		TestCaseIDs:     []int64{testCase.ID},
+		VendorConfigIDs: []int64{dg.ID, az.ID, tc.ID},
+	}
+	if err := store.CreateEvaluationJob(ctx, job); err != nil {
+		t.Fatalf("create job: %v", err)
+	}
+
+	engine := coreengine.New(store, objects, cache.New())
+	if err := engine.RunASREvaluation(ctx, job.ID); err != nil {
+		t.Fatalf("run evaluation: %v", err)
+	}
+
+	done, err := store.GetEvaluationJob(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("reload job: %v", err)
+	}
+	if done.Status != models.JobStatusCompleted {
+		t.Fatalf("job status = %s, want %s", done.Status, models.JobStatusCompleted)
+	}
+
+	results, err := store.ListASRResultsByJob(ctx, job.ID)
+	if err != nil {
+		t.Fatalf("list results: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	byVendor := map[int64]*models.ASRResult{}
+	for _, r := range results {
+		byVendor[r.VendorConfigID] = r
+	}
+	if r := byVendor[dg.ID]; r == nil || r.WER != 0 {
+		t.Errorf("deepgram result: want exact match with WER 0, got %+v", r)
+	}
+	if r := byVendor[az.ID]; r == nil || r.WER <= 0 {
+		t.Errorf("azure result: want nonzero WER for wrong transcript, got %+v", r)
+	}
+	if r := byVendor[tc.ID]; r == nil || r.ErrorCode == "" {
+		t.Errorf("tencent result: want stored error for 401 stub, got %+v", r)
+	}
+	if deepgram.Calls() != 1 || azure.Calls() != 1 {
+		t.Errorf("stub call counts: deepgram=%d azure=%d, want 1 each",
+			deepgram.Calls(), azure.Calls())
+	}
+}
@@ -0,0 +1,125 @@
+package testharness
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+)
+
+// StubOptions configures a fake vendor server's behavior.
+type StubOptions struct {
+	// Transcript is returned on successful recognition calls.
+	Transcript string
+	// Latency is injected before every response.
+	Latency time.Duration
+	// FailStatus, when non-zero, makes the server answer every request
+	// with this HTTP status and an empty body.
+	FailStatus int
+	// FailFirstN makes only the first N requests fail with FailStatus
+	// (or 500 if unset), after which the server recovers. Useful for
+	// retry and error-classification tests.
+	FailFirstN int
+}
+
+func (o *StubOptions) failStatus() int {
+	if o.FailStatus != 0 {
+		return o.FailStatus
+	}
+	return http.StatusInternalServerError
+}
+
+// VendorStub is a running fake vendor server.
+type VendorStub struct {
+	*httptest.Server
+	calls atomic.Int64
+	opts  StubOptions
+}
+
+// Calls reports how many requests the stub has served.
+func (v *VendorStub) Calls() int64 { return v.calls.Load() }
+
+// gate applies latency injection and error modes. It reports whether the
+// handler should continue with a success response.
+func (v *VendorStub) gate(w http.ResponseWriter) bool {
+	n := v.calls.Add(1)
+	if v.opts.Latency > 0 {
+		time.Sleep(v.opts.Latency)
+	}
+	if v.opts.FailStatus != 0 && v.opts.FailFirstN == 0 {
+		w.WriteHeader(v.opts.failStatus())
+		return false
+	}
+	if v.opts.FailFirstN > 0 && n <= int64(v.opts.FailFirstN) {
+		w.WriteHeader(v.opts.failStatus())
+		return false
+	}
+	return true
+}
+
+func newStub(opts StubOptions, handler func(v *VendorStub, w http.ResponseWriter, r *http.Request)) *VendorStub {
+	v := &VendorStub{opts: opts}
+	v.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(v, w, r)
+	}))
+	return v
+}
+
+func writeJSON(w http.ResponseWriter, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+// NewDeepgramStub emulates the Deepgram pre-recorded transcription response
+// shape. Point a DeepgramASR vendor config's APIEndpoint at its URL.
+func NewDeepgramStub(opts StubOptions) *VendorStub {
+	return newStub(opts, func(v *VendorStub, w http.ResponseWriter, r *http.Request) {
+		if !v.gate(w) {
+			return
+		}
+		writeJSON(w, map[string]any{
+			"metadata": map[string]any{"duration": 3.2, "channels": 1},
+			"results": map[string]any{
+				"channels": []map[string]any{{
+					"alternatives": []map[string]any{{
+						"transcript": v.opts.Transcript,
+						"confidence": 0.98,
+					}},
+				}},
+			},
+		})
+	})
+}
+
+// NewAzureStub emulates the Azure Speech short-audio REST response shape.
+// Point an AzureSpeechASR vendor config's APIEndpoint at its URL.
+func NewAzureStub(opts StubOptions) *VendorStub {
+	return newStub(opts, func(v *VendorStub, w http.ResponseWriter, r *http.Request) {
+		if !v.gate(w) {
+			return
+		}
+		writeJSON(w, map[string]any{
+			"RecognitionStatus": "Success",
+			"DisplayText":       v.opts.Transcript,
+			"Offset":            0,
+			"Duration":          32000000,
+		})
+	})
+}
+
+// NewTencentStub emulates the Tencent Cloud SentenceRecognition response
+// shape. Point a TencentCloudASR vendor config's APIEndpoint at its URL.
+func NewTencentStub(opts StubOptions) *VendorStub {
+	return newStub(opts, func(v *VendorStub, w http.ResponseWriter, r *http.Request) {
+		if !v.gate(w) {
+			return
+		}
+		writeJSON(w, map[string]any{
+			"Response": map[string]any{
+				"Result":    v.opts.Transcript,
+				"RequestId": "stub-request",
+			},
+		})
+	})
+}
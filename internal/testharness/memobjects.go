@@ -0,0 +1,71 @@
+// Package testharness provides the pieces needed to run full-pipeline tests
+// of the evaluation engine without real vendor credentials or external
+// services: HTTP stub servers that speak each vendor's response dialect
+// (with configurable error modes and latency) and an in-memory object store.
+package testharness
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// MemObjectStore is an in-memory object store satisfying the engine's
+// ObjectStore interface plus the Put/Delete operations tests need to seed
+// and inspect it.
+type MemObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+// NewMemObjectStore builds an empty in-memory object store.
+func NewMemObjectStore() *MemObjectStore {
+	return &MemObjectStore{objects: make(map[string][]byte)}
+}
+
+// Put stores an object. The size and contentType parameters mirror the real
+// client's signature and are ignored.
+func (m *MemObjectStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = data
+	return nil
+}
+
+// Get returns a stored object's bytes.
+func (m *MemObjectStore) Get(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("object %q not found", key)
+	}
+	return data, nil
+}
+
+// Delete removes an object; deleting a missing key is not an error.
+func (m *MemObjectStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
+	return nil
+}
+
+// PresignedGetURL returns a fake URL; in-memory objects are not reachable
+// over HTTP.
+func (m *MemObjectStore) PresignedGetURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return "memory://" + key, nil
+}
+
+// Len reports how many objects are stored, for test assertions.
+func (m *MemObjectStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.objects)
+}
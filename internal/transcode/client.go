@@ -0,0 +1,58 @@
+package transcode
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Client calls the external transcoding service for formats the platform
+// cannot handle natively.
+type Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewClient builds a Client for the transcoder at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{baseURL: baseURL, client: &http.Client{Timeout: 2 * time.Minute}}
+}
+
+// Extract asks the transcoder for the [startMS, endMS) span of the given
+// audio, returned as PCM WAV.
+func (c *Client) Extract(ctx context.Context, audio []byte, format string, startMS, endMS int64) ([]byte, error) {
+	body, err := json.Marshal(map[string]any{
+		"audio":    base64.StdEncoding.EncodeToString(audio),
+		"format":   format,
+		"start_ms": startMS,
+		"end_ms":   endMS,
+		"output":   "wav",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal extract request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/extract", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("call transcoder: %w", err)
+	}
+	defer resp.Body.Close()
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read transcoder response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("transcoder returned %d: %.200s", resp.StatusCode, out)
+	}
+	return out, nil
+}
@@ -0,0 +1,106 @@
+// Package transcode provides audio format and span manipulation. PCM WAV is
+// handled natively; everything else is delegated to an optional external
+// transcoding service (ffmpeg behind a thin HTTP wrapper) configured via
+// AITP_TRANSCODER_URL.
+package transcode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// wavFormat is the decoded fmt chunk of a PCM WAV file plus the location of
+// its sample data.
+type wavFormat struct {
+	channels      int
+	sampleRate    int
+	bitsPerSample int
+	dataOffset    int
+	dataLen       int
+}
+
+// SliceWAV extracts the [startMS, endMS) span of a PCM WAV file, returning a
+// standalone WAV. Offsets are clamped to the audio's length; an empty span is
+// an error.
+func SliceWAV(wav []byte, startMS, endMS int64) ([]byte, error) {
+	f, err := parseWAV(wav)
+	if err != nil {
+		return nil, err
+	}
+	bytesPerSample := f.channels * f.bitsPerSample / 8
+	bytesPerMS := float64(f.sampleRate*bytesPerSample) / 1000
+
+	start := int(float64(startMS) * bytesPerMS)
+	end := int(float64(endMS) * bytesPerMS)
+	// Snap to sample boundaries so channels stay interleaved correctly.
+	start -= start % bytesPerSample
+	end -= end % bytesPerSample
+	if start < 0 {
+		start = 0
+	}
+	if end > f.dataLen {
+		end = f.dataLen
+	}
+	if start >= end {
+		return nil, fmt.Errorf("empty span %d-%dms", startMS, endMS)
+	}
+
+	data := wav[f.dataOffset+start : f.dataOffset+end]
+	var buf bytes.Buffer
+	buf.Grow(44 + len(data))
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+len(data)))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))
+	binary.Write(&buf, binary.LittleEndian, uint16(1))
+	binary.Write(&buf, binary.LittleEndian, uint16(f.channels))
+	binary.Write(&buf, binary.LittleEndian, uint32(f.sampleRate))
+	binary.Write(&buf, binary.LittleEndian, uint32(f.sampleRate*bytesPerSample))
+	binary.Write(&buf, binary.LittleEndian, uint16(bytesPerSample))
+	binary.Write(&buf, binary.LittleEndian, uint16(f.bitsPerSample))
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(len(data)))
+	buf.Write(data)
+	return buf.Bytes(), nil
+}
+
+// parseWAV walks the RIFF chunks of a PCM WAV file.
+func parseWAV(wav []byte) (*wavFormat, error) {
+	if len(wav) < 44 || string(wav[0:4]) != "RIFF" || string(wav[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("not a RIFF/WAVE file")
+	}
+	f := &wavFormat{}
+	for pos := 12; pos+8 <= len(wav); {
+		id := string(wav[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(wav[pos+4 : pos+8]))
+		body := pos + 8
+		if body+size > len(wav) {
+			size = len(wav) - body
+		}
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("truncated fmt chunk")
+			}
+			if audioFormat := binary.LittleEndian.Uint16(wav[body : body+2]); audioFormat != 1 {
+				return nil, fmt.Errorf("unsupported WAV encoding %d (PCM only)", audioFormat)
+			}
+			f.channels = int(binary.LittleEndian.Uint16(wav[body+2 : body+4]))
+			f.sampleRate = int(binary.LittleEndian.Uint32(wav[body+4 : body+8]))
+			f.bitsPerSample = int(binary.LittleEndian.Uint16(wav[body+14 : body+16]))
+		case "data":
+			f.dataOffset = body
+			f.dataLen = size
+		}
+		// Chunks are word-aligned.
+		pos = body + size + size%2
+	}
+	if f.channels == 0 || f.sampleRate == 0 || f.bitsPerSample == 0 {
+		return nil, fmt.Errorf("missing fmt chunk")
+	}
+	if f.dataOffset == 0 {
+		return nil, fmt.Errorf("missing data chunk")
+	}
+	return f, nil
+}
@@ -0,0 +1,265 @@
+// Package vendoradapter encapsulates the calling conventions of each
+// integrated third-party AI service behind small uniform interfaces. Adding a
+// vendor means adding one adapter here; the engine and API layers never talk
+// to vendor endpoints directly.
+package vendoradapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// ASRRequest carries one recognition call: the raw audio plus the settings
+// the adapter needs to map onto vendor-specific parameters.
+type ASRRequest struct {
+	Audio      []byte
+	Format     string // wav, mp3, pcm, ...
+	SampleRate int    // Hz; 0 means the adapter's default (16000)
+	Language   string // BCP-47 style tag, e.g. zh-CN, en-US
+	Params     map[string]any
+
+	// GroundTruth is the test case's reference transcript. Real vendor
+	// adapters must never send it anywhere; it exists so simulation
+	// adapters (mock) can generate transcripts with a controlled error
+	// rate for load and scheduling tests.
+	GroundTruth string
+}
+
+// ASRResponse is the normalized result of a recognition call. RawResponse
+// preserves the vendor's unmodified reply for archival and debugging.
+// VendorProcessingMS is the processing duration the vendor reports about
+// itself (0 when the vendor exposes none); comparing it with the wall-clock
+// latency separates network overhead from vendor processing time.
+type ASRResponse struct {
+	Transcript         string
+	RawResponse        json.RawMessage
+	VendorProcessingMS int64
+
+	// Words carries per-word timestamps when the vendor provides them;
+	// subtitle evaluation needs them and plain WER jobs ignore them.
+	Words []models.WordAlignment
+
+	// Confidence is the vendor's utterance-level confidence in [0,1], or 0
+	// when the vendor exposes none.
+	Confidence float64
+}
+
+// ASRAdapter is implemented once per speech-recognition vendor.
+type ASRAdapter interface {
+	// Name identifies the adapter implementation, not the user-given
+	// config name.
+	Name() string
+	// Recognize performs one synchronous recognition call.
+	Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error)
+}
+
+// RawReplayer is implemented by adapters that can re-parse a stored raw
+// vendor response without any network call. Replay-mode jobs use it to
+// validate parsing and metric changes against historical data.
+type RawReplayer interface {
+	ParseRaw(raw json.RawMessage) (*ASRResponse, error)
+}
+
+// AdapterKey returns the provider key a config resolves under: the explicit
+// adapter_key field, falling back to the config's name for configs that
+// predate the field and used Name as the provider selector.
+func AdapterKey(cfg *models.VendorConfig) string {
+	if cfg.AdapterKey != "" {
+		return cfg.AdapterKey
+	}
+	return cfg.Name
+}
+
+// asrFactories maps adapter keys to constructors. The built-in providers are
+// registered here; deployments embedding the platform add their own through
+// RegisterAdapter at startup, mirroring RegisterSecretProvider.
+var (
+	asrFactoryMu sync.Mutex
+	asrFactories = map[string]func(*models.VendorConfig) ASRAdapter{
+		"GoogleCloudASR":  func(cfg *models.VendorConfig) ASRAdapter { return newGoogleASRAdapter(cfg) },
+		"AzureSpeechASR":  func(cfg *models.VendorConfig) ASRAdapter { return newAzureASRAdapter(cfg) },
+		"DeepgramASR":     func(cfg *models.VendorConfig) ASRAdapter { return newDeepgramASRAdapter(cfg) },
+		"TencentCloudASR": func(cfg *models.VendorConfig) ASRAdapter { return newTencentASRAdapter(cfg) },
+		"AliCloudASR":     func(cfg *models.VendorConfig) ASRAdapter { return newAliCloudASRAdapter(cfg) },
+		"VolcengineASR":   func(cfg *models.VendorConfig) ASRAdapter { return newVolcengineASRAdapter(cfg) },
+		"BaiduCloudASR":   func(cfg *models.VendorConfig) ASRAdapter { return newBaiduASRAdapter(cfg) },
+		"IFlytekASR":      func(cfg *models.VendorConfig) ASRAdapter { return newIflytekASRAdapter(cfg) },
+		"WhisperCppASR":   func(cfg *models.VendorConfig) ASRAdapter { return newWhisperASRAdapter(cfg) },
+		"SelfHostedHTTP":  func(cfg *models.VendorConfig) ASRAdapter { return newSelfHostedASRAdapter(cfg) },
+		"GenericHTTPASR":  func(cfg *models.VendorConfig) ASRAdapter { return newGenericASRAdapter(cfg) },
+		"MockASR":         func(cfg *models.VendorConfig) ASRAdapter { return NewMockASRAdapter(cfg) },
+	}
+
+	// allowUnknownAdapters restores the legacy behavior of resolving
+	// unrecognized adapter keys to the mock adapter. Off by default: a typo'd
+	// key silently producing simulated transcripts looks like a real run.
+	allowUnknownAdapters bool
+)
+
+// RegisterAdapter installs an ASR adapter factory under a key, replacing any
+// previous registration. Call it before any configs are resolved.
+func RegisterAdapter(key string, factory func(*models.VendorConfig) ASRAdapter) {
+	asrFactoryMu.Lock()
+	defer asrFactoryMu.Unlock()
+	asrFactories[key] = factory
+}
+
+// SetAllowUnknownAdapters controls what an unrecognized adapter key resolves
+// to: the mock adapter when allowed (demo setups without real credentials),
+// or an adapter that fails every call with a stored BAD_REQUEST error so the
+// misconfiguration is visible in results. Call it once at startup.
+func SetAllowUnknownAdapters(allow bool) {
+	asrFactoryMu.Lock()
+	defer asrFactoryMu.Unlock()
+	allowUnknownAdapters = allow
+}
+
+// GetASRAdapter resolves the adapter for a vendor config by its adapter key.
+// Unknown keys resolve per SetAllowUnknownAdapters. Configs whose
+// credentials are secret references are wrapped so the reference resolves on
+// each call, never at construction.
+func GetASRAdapter(cfg *models.VendorConfig) ASRAdapter {
+	if hasSecretRefs(cfg) {
+		return &secretResolvingAdapter{cfg: cfg}
+	}
+	return newASRAdapter(cfg)
+}
+
+func newASRAdapter(cfg *models.VendorConfig) ASRAdapter {
+	key := AdapterKey(cfg)
+	asrFactoryMu.Lock()
+	factory, ok := asrFactories[key]
+	allowUnknown := allowUnknownAdapters
+	asrFactoryMu.Unlock()
+	if !ok {
+		if allowUnknown {
+			return NewMockASRAdapter(cfg)
+		}
+		return &unknownASRAdapter{key: key}
+	}
+	return factory(cfg)
+}
+
+// unknownASRAdapter fails every call with a stored error naming the
+// unresolvable key, so a typo'd adapter_key surfaces as failed test cases
+// instead of silently scoring mock transcripts.
+type unknownASRAdapter struct {
+	key string
+}
+
+func (a *unknownASRAdapter) Name() string { return "Unknown" }
+
+func (a *unknownASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	return nil, &AdapterError{
+		Code:    ErrCodeBadRequest,
+		Message: fmt.Sprintf("no adapter registered for key %q; fix the config's adapter_key or enable AITP_ALLOW_UNKNOWN_ADAPTERS for mock fallback", a.key),
+	}
+}
+
+// RegionalEndpoints returns the vendor config's declared regional endpoints
+// (OtherConfigs key "regional_endpoints", a region-name to URL map), or nil.
+// Region benchmarking jobs fan the same audio to each of them.
+func RegionalEndpoints(cfg *models.VendorConfig) map[string]string {
+	var other struct {
+		Regions map[string]string `json:"regional_endpoints"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Regions
+}
+
+// Credential is one API key/secret pair in a vendor config's credential
+// pool. Configs facing QPS limits can declare several under the OtherConfigs
+// key "credential_pool"; the engine rotates across them.
+type Credential struct {
+	Label     string `json:"label,omitempty"`
+	APIKey    string `json:"api_key"`
+	APISecret string `json:"api_secret,omitempty"`
+}
+
+// CredentialPool returns the vendor config's declared credential pool, or
+// nil when the config uses its single top-level key pair.
+func CredentialPool(cfg *models.VendorConfig) []Credential {
+	var other struct {
+		Pool []Credential `json:"credential_pool"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Pool
+}
+
+// HostTag returns the vendor config's declared host label (OtherConfigs key
+// "host_tag", e.g. "gpu-a100" or "cpu-only"). Self-hosted configs use it to
+// distinguish identical models on different hardware in reports.
+func HostTag(cfg *models.VendorConfig) string {
+	var other struct {
+		Tag string `json:"host_tag"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Tag
+}
+
+// RunnerPool returns the runner pool a vendor config is assigned to
+// (OtherConfigs key "runner_pool"). An empty pool means the config runs in
+// the in-process engine as usual.
+func RunnerPool(cfg *models.VendorConfig) string {
+	var other struct {
+		Pool string `json:"runner_pool"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Pool
+}
+
+// MaxConcurrency returns the vendor config's cap on concurrent in-flight
+// calls (OtherConfigs key "max_concurrency"). Zero means no per-vendor cap;
+// the job-level concurrency parameter still bounds the total.
+func MaxConcurrency(cfg *models.VendorConfig) int {
+	var other struct {
+		Max int `json:"max_concurrency"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Max
+}
+
+// SizeLimits returns the vendor config's payload limits (OtherConfigs keys
+// "max_audio_bytes" and "max_audio_duration_ms"). Zero means unlimited. The
+// engine skips over-limit pairs with UNSUPPORTED_SIZE instead of paying for
+// a call the vendor is guaranteed to reject.
+func SizeLimits(cfg *models.VendorConfig) (maxBytes, maxDurationMS int64) {
+	var other struct {
+		MaxBytes      int64 `json:"max_audio_bytes"`
+		MaxDurationMS int64 `json:"max_audio_duration_ms"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.MaxBytes, other.MaxDurationMS
+}
+
+// MaxQPS returns the vendor config's cap on calls per second (OtherConfigs
+// key "max_qps"). Zero means unthrottled. The engine enforces it with a
+// token bucket shared across all workers calling the vendor.
+func MaxQPS(cfg *models.VendorConfig) float64 {
+	var other struct {
+		QPS float64 `json:"max_qps"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.QPS
+}
+
+// decodeOtherConfigs unmarshals a vendor config's OtherConfigs blob into the
+// adapter's own settings struct. Absent or empty blobs leave dst untouched.
+func decodeOtherConfigs(cfg *models.VendorConfig, dst any) error {
+	if len(cfg.OtherConfigs) == 0 {
+		return nil
+	}
+	return json.Unmarshal(cfg.OtherConfigs, dst)
+}
+
+// sampleRateOrDefault applies the platform-wide 16 kHz default.
+func sampleRateOrDefault(req *ASRRequest) int {
+	if req.SampleRate > 0 {
+		return req.SampleRate
+	}
+	return 16000
+}
@@ -0,0 +1,95 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// aliCloudASRAdapter calls the Alibaba Cloud Intelligent Speech Interaction
+// one-sentence recognition REST gateway. APIKey holds the NLS token and
+// OtherConfigs the appkey.
+type aliCloudASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		AppKey string `json:"ali_appkey"`
+	}
+}
+
+func newAliCloudASRAdapter(cfg *models.VendorConfig) *aliCloudASRAdapter {
+	a := &aliCloudASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *aliCloudASRAdapter) Name() string { return "AliCloudASR" }
+
+func (a *aliCloudASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	if a.other.AppKey == "" {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: "ali_appkey missing from OtherConfigs"}
+	}
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "https://nls-gateway-cn-shanghai.aliyuncs.com/stream/v1/asr"
+	}
+
+	q := url.Values{}
+	q.Set("appkey", a.other.AppKey)
+	q.Set("format", strings.ToLower(req.Format))
+	q.Set("sample_rate", strconv.Itoa(sampleRateOrDefault(req)))
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		endpoint+"?"+q.Encode(), bytes.NewReader(req.Audio))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("X-NLS-Token", a.cfg.APIKey)
+	httpReq.Header.Set("Content-Type", "application/octet-stream")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return parseAliCloudResponse(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *aliCloudASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	return parseAliCloudResponse(raw)
+}
+
+func parseAliCloudResponse(raw []byte) (*ASRResponse, error) {
+	var parsed struct {
+		Status  int    `json:"status"`
+		Message string `json:"message"`
+		Result  string `json:"result"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable alicloud response: %v", err)}
+	}
+	// 20000000 is the NLS gateway success status.
+	if parsed.Status != 20000000 {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("alicloud status %d: %s", parsed.Status, parsed.Message)}
+	}
+	return &ASRResponse{Transcript: parsed.Result, RawResponse: raw}, nil
+}
@@ -0,0 +1,103 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// azureASRAdapter calls the Azure Speech Service short-audio REST API.
+// OtherConfigs must provide azure_region unless APIEndpoint overrides the
+// full URL.
+type azureASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		Region          string `json:"azure_region"`
+		ProfanityOption string `json:"profanity_option"` // masked, removed, raw
+	}
+}
+
+func newAzureASRAdapter(cfg *models.VendorConfig) *azureASRAdapter {
+	a := &azureASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *azureASRAdapter) Name() string { return "AzureSpeechASR" }
+
+func (a *azureASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		if a.other.Region == "" {
+			return nil, &AdapterError{Code: ErrCodeBadRequest,
+				Message: "azure_region missing from OtherConfigs and no APIEndpoint set"}
+		}
+		endpoint = fmt.Sprintf(
+			"https://%s.stt.speech.microsoft.com/speech/recognition/conversation/cognitiveservices/v1",
+			a.other.Region)
+	}
+
+	q := url.Values{}
+	q.Set("language", req.Language)
+	q.Set("format", "detailed")
+	if a.other.ProfanityOption != "" {
+		q.Set("profanity", a.other.ProfanityOption)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		endpoint+"?"+q.Encode(), bytes.NewReader(req.Audio))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Ocp-Apim-Subscription-Key", a.cfg.APIKey)
+	httpReq.Header.Set("Content-Type",
+		fmt.Sprintf("audio/wav; codecs=audio/pcm; samplerate=%d", sampleRateOrDefault(req)))
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return parseAzureResponse(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *azureASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	return parseAzureResponse(raw)
+}
+
+func parseAzureResponse(raw []byte) (*ASRResponse, error) {
+	var parsed struct {
+		RecognitionStatus string `json:"RecognitionStatus"`
+		DisplayText       string `json:"DisplayText"`
+		Duration          int64  `json:"Duration"` // 100ns ticks
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable azure response: %v", err)}
+	}
+	if parsed.RecognitionStatus != "Success" {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: "azure recognition status: " + parsed.RecognitionStatus}
+	}
+	return &ASRResponse{
+		Transcript:         parsed.DisplayText,
+		RawResponse:        raw,
+		VendorProcessingMS: parsed.Duration / 10000,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package vendoradapter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// ConfigField describes one OtherConfigs key a provider understands. The
+// schema is what the API validates against at save time, so configuration
+// mistakes surface immediately instead of hours later inside a job.
+type ConfigField struct {
+	Key      string `json:"key"`
+	Type     string `json:"type"` // string, bool or number
+	Required bool   `json:"required"`
+}
+
+// configSchemas maps adapter keys (as resolved by GetASRAdapter) to the
+// OtherConfigs fields their adapter reads. Keys absent from the schema are
+// allowed — shared settings like regional_endpoints and runner_pool apply to
+// every provider — but present keys must have the declared type, and
+// required keys must be present.
+var configSchemas = map[string][]ConfigField{
+	"GoogleCloudASR": {
+		{Key: "google_model", Type: "string"},
+		{Key: "enable_punctuation", Type: "bool"},
+		{Key: "use_enhanced", Type: "bool"},
+		{Key: "speech_context_phrases", Type: "string"},
+	},
+	"AzureSpeechASR": {
+		{Key: "azure_region", Type: "string", Required: true},
+		{Key: "profanity_option", Type: "string"},
+	},
+	"DeepgramASR": {
+		{Key: "deepgram_model", Type: "string"},
+		{Key: "deepgram_tier", Type: "string"},
+		{Key: "punctuate", Type: "bool"},
+		{Key: "smart_format", Type: "bool"},
+	},
+	"TencentCloudASR": {
+		{Key: "tencent_region", Type: "string", Required: true},
+	},
+	"AliCloudASR": {
+		{Key: "ali_appkey", Type: "string", Required: true},
+	},
+	"VolcengineASR": {
+		{Key: "volcengine_app_id", Type: "string", Required: true},
+		{Key: "volcengine_cluster", Type: "string"},
+	},
+	"BaiduCloudASR": {
+		{Key: "baidu_dev_pid", Type: "number"},
+	},
+	"IFlytekASR": {
+		{Key: "iflytek_app_id", Type: "string", Required: true},
+		{Key: "iflytek_domain", Type: "string"},
+	},
+	"GenericHTTPASR": {
+		{Key: "auth", Type: "string"},
+		{Key: "language_param", Type: "string"},
+		{Key: "transcript_path", Type: "string"},
+	},
+	"WhisperCppASR": {
+		{Key: "whisper_model", Type: "string"},
+		{Key: "whisper_temperature", Type: "number"},
+		{Key: "host_tag", Type: "string"},
+	},
+	"SelfHostedHTTP": {
+		{Key: "file_field", Type: "string"},
+		{Key: "language_field", Type: "string"},
+		{Key: "transcript_path", Type: "string"},
+		{Key: "host_tag", Type: "string"},
+	},
+	"ElevenLabsTTS": {
+		{Key: "elevenlabs_voice_id", Type: "string", Required: true},
+		{Key: "elevenlabs_model", Type: "string"},
+		{Key: "stability", Type: "number"},
+		{Key: "similarity_boost", Type: "number"},
+	},
+	"PlayHTTTS": {
+		{Key: "playht_user_id", Type: "string", Required: true},
+		{Key: "playht_voice", Type: "string", Required: true},
+		{Key: "playht_quality", Type: "string"},
+	},
+	"MockASR": {
+		{Key: "mock_mean_latency_ms", Type: "number"},
+		{Key: "mock_latency_jitter_ms", Type: "number"},
+		{Key: "mock_error_rate", Type: "number"},
+		{Key: "mock_target_wer", Type: "number"},
+		{Key: "mock_wer_jitter", Type: "number"},
+	},
+}
+
+// ConfigSchema returns the declared OtherConfigs fields for an adapter key,
+// or nil for keys without a schema.
+func ConfigSchema(key string) []ConfigField {
+	return configSchemas[key]
+}
+
+// ValidateOtherConfigs checks a vendor config's OtherConfigs blob against
+// its provider's schema and returns one message per violation, empty when
+// the config is acceptable. Keys without a schema only get the
+// well-formedness check.
+func ValidateOtherConfigs(cfg *models.VendorConfig) []string {
+	values := map[string]any{}
+	if len(cfg.OtherConfigs) > 0 {
+		if err := json.Unmarshal(cfg.OtherConfigs, &values); err != nil {
+			return []string{"other_configs must be a JSON object"}
+		}
+	}
+	key := AdapterKey(cfg)
+	var violations []string
+	for _, field := range configSchemas[key] {
+		value, present := values[field.Key]
+		if !present {
+			if field.Required {
+				violations = append(violations,
+					fmt.Sprintf("other_configs.%s is required for %s", field.Key, key))
+			}
+			continue
+		}
+		if !typeMatches(field.Type, value) {
+			violations = append(violations,
+				fmt.Sprintf("other_configs.%s must be a %s", field.Key, field.Type))
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func typeMatches(want string, value any) bool {
+	switch want {
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "bool":
+		_, ok := value.(bool)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return true
+	}
+}
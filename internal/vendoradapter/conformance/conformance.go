@@ -0,0 +1,117 @@
+// Package conformance defines the behavioural contract every ASRAdapter
+// implementation must satisfy, as a reusable test suite. Adapter authors run
+// it from a regular Go test against their adapter — backed by the mock, by
+// recorded fixtures or by a live sandbox — and get the platform's
+// expectations checked uniformly: cancellation is honored, failures are
+// classified, raw responses are valid JSON.
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// Options tunes a conformance run for one adapter.
+type Options struct {
+	// Request is the recognition request used by the checks. Nil gets a
+	// minimal English request with a placeholder WAV payload.
+	Request *vendoradapter.ASRRequest
+
+	// ExpectSuccess additionally runs the success-path checks, which call
+	// Recognize for real. Enable it only when the adapter is backed by the
+	// mock, replay fixtures or a reachable sandbox.
+	ExpectSuccess bool
+}
+
+// Run executes the conformance suite against one adapter as subtests.
+func Run(t *testing.T, adapter vendoradapter.ASRAdapter, opts Options) {
+	t.Helper()
+	req := opts.Request
+	if req == nil {
+		req = &vendoradapter.ASRRequest{
+			Audio:       []byte("RIFF\x00\x00\x00\x00WAVE"),
+			Format:      "wav",
+			Language:    "en-US",
+			GroundTruth: "conformance check utterance",
+		}
+	}
+
+	t.Run("Name", func(t *testing.T) {
+		if adapter.Name() == "" {
+			t.Fatal("Name() must identify the adapter implementation")
+		}
+	})
+
+	t.Run("ContextCancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		start := time.Now()
+		_, err := adapter.Recognize(ctx, req)
+		if err == nil {
+			t.Fatal("Recognize must fail when the context is already cancelled")
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Fatalf("Recognize took %v to notice a cancelled context", elapsed)
+		}
+		assertClassified(t, err)
+	})
+
+	if !opts.ExpectSuccess {
+		return
+	}
+
+	t.Run("SuccessShape", func(t *testing.T) {
+		resp, err := adapter.Recognize(context.Background(), req)
+		if err != nil {
+			assertClassified(t, err)
+			t.Fatalf("Recognize failed: %v", err)
+		}
+		if resp == nil {
+			t.Fatal("Recognize returned a nil response without an error")
+		}
+		if len(resp.RawResponse) > 0 && !json.Valid(resp.RawResponse) {
+			t.Fatalf("RawResponse is not valid JSON: %.100s", resp.RawResponse)
+		}
+		if resp.VendorProcessingMS < 0 {
+			t.Fatalf("VendorProcessingMS must be non-negative, got %d", resp.VendorProcessingMS)
+		}
+	})
+
+	t.Run("ReplayMatchesLive", func(t *testing.T) {
+		replayer, ok := adapter.(vendoradapter.RawReplayer)
+		if !ok {
+			t.Skip("adapter does not implement RawReplayer")
+		}
+		resp, err := adapter.Recognize(context.Background(), req)
+		if err != nil || len(resp.RawResponse) == 0 {
+			t.Skip("no raw response to replay")
+		}
+		replayed, err := replayer.ParseRaw(resp.RawResponse)
+		if err != nil {
+			t.Fatalf("ParseRaw failed on the adapter's own raw response: %v", err)
+		}
+		if replayed.Transcript != resp.Transcript {
+			t.Fatalf("replayed transcript %q differs from live transcript %q",
+				replayed.Transcript, resp.Transcript)
+		}
+	})
+}
+
+// assertClassified fails the test when an adapter error is not an
+// *AdapterError with a stable code; the engine depends on that to persist
+// filterable error codes.
+func assertClassified(t *testing.T, err error) {
+	t.Helper()
+	var adapterErr *vendoradapter.AdapterError
+	if !errors.As(err, &adapterErr) {
+		t.Fatalf("error is not an *AdapterError: %v", err)
+	}
+	if adapterErr.Code == "" {
+		t.Fatalf("AdapterError has no code: %v", err)
+	}
+}
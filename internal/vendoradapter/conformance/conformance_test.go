@@ -0,0 +1,40 @@
+package conformance
+
+import (
+	"testing"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+	"github.com/Jcateye/AITestPlatform/internal/vendoradapter"
+)
+
+// TestMockAdapterConformance runs the full suite, success path included,
+// against the mock adapter. It doubles as the suite's own regression test.
+func TestMockAdapterConformance(t *testing.T) {
+	cfg := &models.VendorConfig{Name: "MockASR", APIType: models.APITypeASR}
+	Run(t, vendoradapter.NewMockASRAdapter(cfg), Options{ExpectSuccess: true})
+}
+
+// TestHTTPAdaptersConformance runs the offline checks against every real
+// adapter. The success path needs credentials or fixtures, so it stays off
+// here; adapter authors enable it locally with recorded fixtures.
+func TestHTTPAdaptersConformance(t *testing.T) {
+	names := []string{
+		"GoogleCloudASR",
+		"AzureSpeechASR",
+		"DeepgramASR",
+		"TencentCloudASR",
+		"AliCloudASR",
+		"VolcengineASR",
+	}
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			cfg := &models.VendorConfig{
+				Name:      name,
+				APIType:   models.APITypeASR,
+				APIKey:    "conformance-key",
+				APISecret: "conformance-secret",
+			}
+			Run(t, vendoradapter.GetASRAdapter(cfg), Options{})
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// deepgramASRAdapter calls the Deepgram pre-recorded transcription API.
+type deepgramASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		Model       string `json:"deepgram_model"` // e.g. nova-2
+		Tier        string `json:"deepgram_tier"`
+		Punctuate   bool   `json:"punctuate"`
+		SmartFormat bool   `json:"smart_format"`
+	}
+}
+
+func newDeepgramASRAdapter(cfg *models.VendorConfig) *deepgramASRAdapter {
+	a := &deepgramASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *deepgramASRAdapter) Name() string { return "DeepgramASR" }
+
+func (a *deepgramASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "https://api.deepgram.com/v1/listen"
+	}
+
+	q := url.Values{}
+	// Deepgram expects a bare language code (en, zh) or a regional tag.
+	q.Set("language", strings.ToLower(req.Language))
+	if a.other.Model != "" {
+		q.Set("model", a.other.Model)
+	}
+	if a.other.Tier != "" {
+		q.Set("tier", a.other.Tier)
+	}
+	if a.other.Punctuate {
+		q.Set("punctuate", "true")
+	}
+	if a.other.SmartFormat {
+		q.Set("smart_format", "true")
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		endpoint+"?"+q.Encode(), bytes.NewReader(req.Audio))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Token "+a.cfg.APIKey)
+	httpReq.Header.Set("Content-Type", "audio/"+req.Format)
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return parseDeepgramResponse(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *deepgramASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	return parseDeepgramResponse(raw)
+}
+
+func parseDeepgramResponse(raw []byte) (*ASRResponse, error) {
+	var parsed struct {
+		Metadata struct {
+			Duration float64 `json:"duration"` // processing seconds
+		} `json:"metadata"`
+		Results struct {
+			Channels []struct {
+				Alternatives []struct {
+					Transcript string `json:"transcript"`
+				} `json:"alternatives"`
+			} `json:"channels"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable deepgram response: %v", err)}
+	}
+	transcript := ""
+	if len(parsed.Results.Channels) > 0 && len(parsed.Results.Channels[0].Alternatives) > 0 {
+		transcript = parsed.Results.Channels[0].Alternatives[0].Transcript
+	}
+	return &ASRResponse{
+		Transcript:         transcript,
+		RawResponse:        raw,
+		VendorProcessingMS: int64(parsed.Metadata.Duration * 1000),
+	}, nil
+}
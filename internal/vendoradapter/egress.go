@@ -0,0 +1,65 @@
+package vendoradapter
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// ErrCodeEgressBlocked marks calls refused by the egress policy before any
+// network traffic was sent.
+const ErrCodeEgressBlocked = "EGRESS_BLOCKED"
+
+// AllowedHosts returns the vendor config's egress allowlist (OtherConfigs
+// key "allowed_hosts"): hostnames, optionally with a leading "*." wildcard
+// matching subdomains. Nil means the config declares no policy.
+func AllowedHosts(cfg *models.VendorConfig) []string {
+	var other struct {
+		Hosts []string `json:"allowed_hosts"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Hosts
+}
+
+// CheckEgress verifies a config's custom endpoint against its allowlist
+// before the adapter dials it, catching endpoints accidentally (or
+// maliciously) pointed at hosts the vendor does not own. Configs without a
+// custom endpoint use the adapter's built-in default and always pass, as do
+// configs without an allowlist.
+func CheckEgress(cfg *models.VendorConfig) *AdapterError {
+	if cfg.APIEndpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(cfg.APIEndpoint)
+	if err != nil || u.Hostname() == "" {
+		return &AdapterError{Code: ErrCodeEgressBlocked,
+			Message: fmt.Sprintf("unparseable endpoint %q", cfg.APIEndpoint)}
+	}
+	if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "ws" && u.Scheme != "wss" {
+		return &AdapterError{Code: ErrCodeEgressBlocked,
+			Message: fmt.Sprintf("endpoint scheme %q is not allowed", u.Scheme)}
+	}
+	allowed := AllowedHosts(cfg)
+	if len(allowed) == 0 {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, pattern := range allowed {
+		if hostMatches(host, strings.ToLower(pattern)) {
+			return nil
+		}
+	}
+	return &AdapterError{Code: ErrCodeEgressBlocked,
+		Message: fmt.Sprintf("endpoint host %q is not in the vendor's allowed_hosts", host)}
+}
+
+// hostMatches supports exact hostnames and "*.example.com" wildcards, which
+// match any subdomain but not the apex.
+func hostMatches(host, pattern string) bool {
+	if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+		return strings.HasSuffix(host, "."+suffix)
+	}
+	return host == pattern
+}
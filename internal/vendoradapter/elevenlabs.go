@@ -0,0 +1,84 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// elevenLabsTTSAdapter calls the ElevenLabs text-to-speech API. APIKey holds
+// the xi-api-key; the voice and its stability/similarity tuning come from
+// OtherConfigs.
+type elevenLabsTTSAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		VoiceID         string  `json:"elevenlabs_voice_id"`
+		Model           string  `json:"elevenlabs_model"` // e.g. eleven_multilingual_v2
+		Stability       float64 `json:"stability"`
+		SimilarityBoost float64 `json:"similarity_boost"`
+	}
+}
+
+func newElevenLabsTTSAdapter(cfg *models.VendorConfig) *elevenLabsTTSAdapter {
+	a := &elevenLabsTTSAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *elevenLabsTTSAdapter) Name() string { return "ElevenLabsTTS" }
+
+func (a *elevenLabsTTSAdapter) Synthesize(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+	if a.other.VoiceID == "" {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: "elevenlabs_voice_id missing from OtherConfigs"}
+	}
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "https://api.elevenlabs.io"
+	}
+
+	payload := map[string]any{"text": req.Text}
+	if a.other.Model != "" {
+		payload["model_id"] = a.other.Model
+	}
+	if a.other.Stability > 0 || a.other.SimilarityBoost > 0 {
+		payload["voice_settings"] = map[string]float64{
+			"stability":        a.other.Stability,
+			"similarity_boost": a.other.SimilarityBoost,
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/v1/text-to-speech/%s", endpoint, a.other.VoiceID),
+		bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("xi-api-key", a.cfg.APIKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "audio/mpeg")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, audio)
+	}
+	return &TTSResponse{Audio: audio, Format: "mp3"}, nil
+}
@@ -0,0 +1,71 @@
+package vendoradapter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Stable error codes stored on results so failures can be filtered and
+// aggregated without string matching vendor messages.
+const (
+	ErrCodeAuthFailed  = "AUTH_FAILED"
+	ErrCodeRateLimited = "RATE_LIMITED"
+	ErrCodeTimeout     = "TIMEOUT"
+	ErrCodeBadRequest  = "BAD_REQUEST"
+	ErrCodeVendorError = "VENDOR_ERROR"
+	ErrCodeNetwork     = "NETWORK_ERROR"
+
+	// ErrCodeUnsupportedSize marks pairs skipped before the vendor call
+	// because the audio exceeds the config's declared payload limits.
+	ErrCodeUnsupportedSize = "UNSUPPORTED_SIZE"
+)
+
+// AdapterError classifies a failed vendor call. Adapters return it from
+// Recognize so the engine can persist a stable code alongside the message.
+type AdapterError struct {
+	Code    string
+	Message string
+}
+
+func (e *AdapterError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// classifyHTTPStatus maps a vendor HTTP status to an AdapterError.
+func classifyHTTPStatus(status int, body []byte) *AdapterError {
+	msg := fmt.Sprintf("vendor returned HTTP %d: %s", status, truncate(string(body), 500))
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return &AdapterError{Code: ErrCodeAuthFailed, Message: msg}
+	case status == http.StatusTooManyRequests:
+		return &AdapterError{Code: ErrCodeRateLimited, Message: msg}
+	case status >= 400 && status < 500:
+		return &AdapterError{Code: ErrCodeBadRequest, Message: msg}
+	default:
+		return &AdapterError{Code: ErrCodeVendorError, Message: msg}
+	}
+}
+
+// classifyTransportError maps transport-level failures (timeouts, DNS,
+// connection resets) to an AdapterError.
+func classifyTransportError(err error) *AdapterError {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return &AdapterError{Code: ErrCodeTimeout, Message: err.Error()}
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return &AdapterError{Code: ErrCodeTimeout, Message: err.Error()}
+	default:
+		return &AdapterError{Code: ErrCodeNetwork, Message: err.Error()}
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
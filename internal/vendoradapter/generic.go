@@ -0,0 +1,282 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// genericASRAdapter onboards niche HTTP ASR providers purely via
+// configuration: the audio is POSTed to the config's endpoint and the
+// transcript is picked out of the JSON reply by path. Authentication is
+// composable from OtherConfigs — static headers, query-string credentials,
+// an HMAC signing template or OAuth2 client-credentials — so providers that
+// don't warrant a dedicated adapter still work.
+type genericASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		// Headers are sent verbatim on every request; values may use the
+		// {api_key}/{api_secret} placeholders.
+		Headers map[string]string `json:"headers"`
+		// QueryAuth adds credential query parameters, e.g. {"key": "{api_key}"}.
+		QueryAuth map[string]string `json:"query_auth"`
+		// Auth selects a built-in scheme: "bearer", "basic" or "" (none).
+		Auth string `json:"auth"`
+		// HMACSigning signs each request: the template is rendered with
+		// {method}, {path}, {date} and {body_sha256}, HMAC-SHA256'd with
+		// the API secret, and the hex digest is sent in Header.
+		HMACSigning *struct {
+			Header   string `json:"header"`
+			Template string `json:"template"`
+		} `json:"hmac_signing"`
+		// OAuth2 fetches a client-credentials token from TokenURL with the
+		// config's key/secret and sends it as a bearer token. Tokens are
+		// cached until shortly before expiry.
+		OAuth2 *struct {
+			TokenURL string `json:"token_url"`
+			Scope    string `json:"scope"`
+		} `json:"oauth2"`
+		// LanguageParam names the query parameter carrying the language
+		// tag; empty omits it.
+		LanguageParam string `json:"language_param"`
+		// TranscriptPath locates the transcript in the JSON reply, dot
+		// separated with numeric array indices, e.g. "results.0.text".
+		// Default "transcript".
+		TranscriptPath string `json:"transcript_path"`
+	}
+}
+
+func newGenericASRAdapter(cfg *models.VendorConfig) *genericASRAdapter {
+	a := &genericASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *genericASRAdapter) Name() string { return "GenericHTTPASR" }
+
+func (a *genericASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	if a.cfg.APIEndpoint == "" {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: "GenericHTTPASR requires api_endpoint"}
+	}
+	endpoint, err := url.Parse(a.cfg.APIEndpoint)
+	if err != nil {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: fmt.Sprintf("bad api_endpoint: %v", err)}
+	}
+	q := endpoint.Query()
+	if a.other.LanguageParam != "" && req.Language != "" {
+		q.Set(a.other.LanguageParam, req.Language)
+	}
+	for name, tpl := range a.other.QueryAuth {
+		q.Set(name, a.expand(tpl))
+	}
+	endpoint.RawQuery = q.Encode()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		endpoint.String(), bytes.NewReader(req.Audio))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "audio/"+req.Format)
+	for name, tpl := range a.other.Headers {
+		httpReq.Header.Set(name, a.expand(tpl))
+	}
+	if err := a.applyAuth(ctx, httpReq, req.Audio); err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return a.ParseRaw(raw)
+}
+
+// applyAuth layers the configured authentication onto a request.
+func (a *genericASRAdapter) applyAuth(ctx context.Context, httpReq *http.Request, body []byte) error {
+	switch a.other.Auth {
+	case "bearer":
+		httpReq.Header.Set("Authorization", "Bearer "+a.cfg.APIKey)
+	case "basic":
+		httpReq.SetBasicAuth(a.cfg.APIKey, a.cfg.APISecret)
+	case "":
+	default:
+		return &AdapterError{Code: ErrCodeBadRequest,
+			Message: fmt.Sprintf("unknown auth scheme %q", a.other.Auth)}
+	}
+	if h := a.other.HMACSigning; h != nil && h.Header != "" {
+		date := time.Now().UTC().Format(http.TimeFormat)
+		bodySum := sha256.Sum256(body)
+		signed := strings.NewReplacer(
+			"{method}", httpReq.Method,
+			"{path}", httpReq.URL.Path,
+			"{date}", date,
+			"{body_sha256}", hex.EncodeToString(bodySum[:]),
+			"{api_key}", a.cfg.APIKey,
+		).Replace(h.Template)
+		mac := hmac.New(sha256.New, []byte(a.cfg.APISecret))
+		mac.Write([]byte(signed))
+		httpReq.Header.Set(h.Header, hex.EncodeToString(mac.Sum(nil)))
+		if strings.Contains(h.Template, "{date}") {
+			httpReq.Header.Set("Date", date)
+		}
+	}
+	if a.other.OAuth2 != nil && a.other.OAuth2.TokenURL != "" {
+		token, err := a.oauth2Token(ctx)
+		if err != nil {
+			return err
+		}
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+	return nil
+}
+
+// expand substitutes credential placeholders in a configured value.
+func (a *genericASRAdapter) expand(tpl string) string {
+	return strings.NewReplacer(
+		"{api_key}", a.cfg.APIKey,
+		"{api_secret}", a.cfg.APISecret,
+	).Replace(tpl)
+}
+
+// oauth2Tokens caches client-credentials tokens per credential (see
+// tokenCacheKey) so each call doesn't pay a token round trip. Tokens are
+// dropped one minute before their reported expiry.
+var oauth2Tokens = struct {
+	mu     sync.Mutex
+	tokens map[string]oauth2Token
+}{tokens: make(map[string]oauth2Token)}
+
+type oauth2Token struct {
+	value   string
+	expires time.Time
+}
+
+// tokenCacheKey identifies the credential a cached token was exchanged for.
+// The config ID alone is not enough: credential pools and secret resolution
+// clone a config with the same ID but different key material, and each
+// credential must exchange and cache its own token — otherwise rotating or
+// disabling a pool credential silently keeps serving the first one's token.
+// The key material is fingerprinted rather than embedded.
+func tokenCacheKey(cfg *models.VendorConfig) string {
+	sum := sha256.Sum256([]byte(cfg.APIKey + "\x00" + cfg.APISecret))
+	return fmt.Sprintf("%d:%s", cfg.ID, hex.EncodeToString(sum[:8]))
+}
+
+func (a *genericASRAdapter) oauth2Token(ctx context.Context) (string, error) {
+	cacheKey := tokenCacheKey(a.cfg)
+	oauth2Tokens.mu.Lock()
+	cached, ok := oauth2Tokens.tokens[cacheKey]
+	oauth2Tokens.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.value, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if a.other.OAuth2.Scope != "" {
+		form.Set("scope", a.other.OAuth2.Scope)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		a.other.OAuth2.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.SetBasicAuth(a.cfg.APIKey, a.cfg.APISecret)
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return "", classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", &AdapterError{Code: ErrCodeAuthFailed,
+			Message: fmt.Sprintf("token endpoint returned HTTP %d: %s",
+				resp.StatusCode, truncate(string(raw), 200))}
+	}
+	var parsed struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil || parsed.AccessToken == "" {
+		return "", &AdapterError{Code: ErrCodeAuthFailed,
+			Message: "token endpoint returned no access_token"}
+	}
+	ttl := time.Duration(parsed.ExpiresIn)*time.Second - time.Minute
+	if ttl < time.Minute {
+		ttl = time.Minute
+	}
+	oauth2Tokens.mu.Lock()
+	oauth2Tokens.tokens[cacheKey] = oauth2Token{value: parsed.AccessToken, expires: time.Now().Add(ttl)}
+	oauth2Tokens.mu.Unlock()
+	return parsed.AccessToken, nil
+}
+
+// ParseRaw implements RawReplayer by walking transcript_path through the
+// reply.
+func (a *genericASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	path := a.other.TranscriptPath
+	if path == "" {
+		path = "transcript"
+	}
+	return transcriptAtPath(raw, path)
+}
+
+// transcriptAtPath picks the transcript out of an arbitrary JSON reply by a
+// dot-separated path with numeric array indices, e.g. "results.0.text".
+// Shared by the configuration-driven adapters.
+func transcriptAtPath(raw json.RawMessage, path string) (*ASRResponse, error) {
+	var parsed any
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable response: %v", err)}
+	}
+	node := parsed
+	for _, part := range strings.Split(path, ".") {
+		switch v := node.(type) {
+		case map[string]any:
+			node = v[part]
+		case []any:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(v) {
+				node = nil
+			} else {
+				node = v[idx]
+			}
+		default:
+			node = nil
+		}
+	}
+	transcript, _ := node.(string)
+	if transcript == "" {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("no transcript at %q in response", path)}
+	}
+	return &ASRResponse{Transcript: transcript, RawResponse: raw}, nil
+}
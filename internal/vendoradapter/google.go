@@ -0,0 +1,128 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// googleASRAdapter calls the Google Cloud Speech-to-Text v1 REST API using
+// API-key authentication.
+type googleASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		Model                 string `json:"google_model"`           // e.g. latest_long
+		EnablePunctuation     bool   `json:"enable_punctuation"`     // automatic punctuation
+		UseEnhanced           bool   `json:"use_enhanced"`           // enhanced models
+		SpeechContextsPhrases string `json:"speech_context_phrases"` // comma-separated phrase hints
+	}
+}
+
+func newGoogleASRAdapter(cfg *models.VendorConfig) *googleASRAdapter {
+	a := &googleASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *googleASRAdapter) Name() string { return "GoogleCloudASR" }
+
+func (a *googleASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "https://speech.googleapis.com/v1/speech:recognize"
+	}
+
+	config := map[string]any{
+		"languageCode":               req.Language,
+		"encoding":                   googleEncoding(req.Format),
+		"sampleRateHertz":            sampleRateOrDefault(req),
+		"enableAutomaticPunctuation": a.other.EnablePunctuation,
+	}
+	if a.other.Model != "" {
+		config["model"] = a.other.Model
+	}
+	if a.other.UseEnhanced {
+		config["useEnhanced"] = true
+	}
+	if a.other.SpeechContextsPhrases != "" {
+		config["speechContexts"] = []map[string]any{
+			{"phrases": strings.Split(a.other.SpeechContextsPhrases, ",")},
+		}
+	}
+	body, err := json.Marshal(map[string]any{
+		"config": config,
+		"audio":  map[string]string{"content": base64.StdEncoding.EncodeToString(req.Audio)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal google request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		endpoint+"?key="+a.cfg.APIKey, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return parseGoogleResponse(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *googleASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	return parseGoogleResponse(raw)
+}
+
+func parseGoogleResponse(raw []byte) (*ASRResponse, error) {
+	var parsed struct {
+		Results []struct {
+			Alternatives []struct {
+				Transcript string `json:"transcript"`
+			} `json:"alternatives"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable google response: %v", err)}
+	}
+	var sb strings.Builder
+	for _, r := range parsed.Results {
+		if len(r.Alternatives) > 0 {
+			sb.WriteString(r.Alternatives[0].Transcript)
+		}
+	}
+	return &ASRResponse{Transcript: sb.String(), RawResponse: raw}, nil
+}
+
+// googleEncoding maps our format names onto Google's encoding enum.
+func googleEncoding(format string) string {
+	switch strings.ToLower(format) {
+	case "mp3":
+		return "MP3"
+	case "flac":
+		return "FLAC"
+	case "ogg", "opus":
+		return "OGG_OPUS"
+	default: // wav, pcm
+		return "LINEAR16"
+	}
+}
@@ -0,0 +1,216 @@
+package vendoradapter
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// All HTTP-based adapters share one tuned client so connections are pooled
+// and reused across calls and jobs instead of each adapter instance paying
+// TLS handshakes on a default transport. Large runs against a single vendor
+// host benefit most from the per-host idle limit.
+
+// TransportOptions tunes the shared adapter transport. Zero values take the
+// defaults noted per field.
+type TransportOptions struct {
+	MaxIdleConns        int           // total idle connections kept (default 100)
+	MaxIdleConnsPerHost int           // idle connections per vendor host (default 16)
+	Timeout             time.Duration // per-call timeout (default 60s)
+
+	// RecordFixturesDir, when set, records every vendor HTTP exchange as a
+	// sanitized fixture file in the directory. Development use only.
+	RecordFixturesDir string
+
+	// BlockPrivateAddrs refuses vendor connections that resolve to
+	// private, loopback or link-local addresses. The check runs at dial
+	// time and pins the vetted IP, so DNS rebinding cannot bypass it.
+	// Vendor configs opt out individually with allow_private_egress.
+	BlockPrivateAddrs bool
+
+	// ProxyURL routes all vendor calls through an outbound HTTP(S) proxy.
+	// Empty falls back to the standard proxy environment variables.
+	ProxyURL string
+
+	// CACertFile is a PEM bundle of additional CAs to trust, for networks
+	// that intercept TLS. Empty uses the system roots alone.
+	CACertFile string
+}
+
+var (
+	transportMu    sync.Mutex
+	sharedOpts     TransportOptions
+	sharedClient   *http.Client
+	perVendorCache map[string]*http.Client
+)
+
+// ConfigureTransport applies deployment-level transport tuning. Call it once
+// at startup, before any adapters are constructed; adapters built earlier
+// keep the previous client. It fails when the proxy URL or CA bundle cannot
+// be loaded, so misconfigured deployments stop at boot instead of failing
+// every vendor call.
+func ConfigureTransport(opts TransportOptions) error {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	client, err := newSharedClient(opts, "", nil)
+	if err != nil {
+		return err
+	}
+	sharedOpts = opts
+	sharedClient = client
+	perVendorCache = nil
+	return nil
+}
+
+// sharedHTTPClient returns the shared client, building one with defaults if
+// ConfigureTransport was never called (tests, tools).
+func sharedHTTPClient() *http.Client {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	if sharedClient == nil {
+		sharedClient, _ = newSharedClient(TransportOptions{}, "", nil)
+	}
+	return sharedClient
+}
+
+// SharedHTTPClient exposes the shared client for callers outside the
+// adapters — e.g. server-side fetches of user-supplied URLs — so they get
+// the same egress policy (private-address guard, proxy, CAs) as vendor
+// calls. The dial-time guard applies to every hop, so redirects cannot
+// escape it either.
+func SharedHTTPClient() *http.Client {
+	return sharedHTTPClient()
+}
+
+// HTTPClientFor exposes httpClientFor for callers outside the adapters that
+// talk to a vendor-config endpoint directly, such as the LLM judge. Calls to
+// user-editable endpoints must never use a plain client: this one applies
+// the config's proxy, CA and allow_private_egress overrides on top of the
+// dial-time private-address guard.
+func HTTPClientFor(cfg *models.VendorConfig) *http.Client {
+	return httpClientFor(cfg)
+}
+
+// httpClientFor returns the HTTP client an adapter should call its vendor
+// with: the shared client, or a dedicated one when the vendor config
+// declares its own proxy_url or ca_cert_pem in OtherConfigs. Dedicated
+// clients are cached by their override so connection pools are still reused.
+func httpClientFor(cfg *models.VendorConfig) *http.Client {
+	var other struct {
+		ProxyURL  string `json:"proxy_url"`
+		CACertPEM string `json:"ca_cert_pem"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	allowPrivate := AllowPrivateEgress(cfg)
+	if other.ProxyURL == "" && other.CACertPEM == "" && !allowPrivate {
+		return sharedHTTPClient()
+	}
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	key := fmt.Sprintf("%s\n%s\n%t", other.ProxyURL, other.CACertPEM, allowPrivate)
+	if client, ok := perVendorCache[key]; ok {
+		return client
+	}
+	opts := sharedOpts
+	if other.ProxyURL != "" {
+		opts.ProxyURL = other.ProxyURL
+	}
+	if allowPrivate {
+		opts.BlockPrivateAddrs = false
+	}
+	client, err := newSharedClient(opts, other.CACertPEM, nil)
+	if err != nil {
+		// A broken override is a config mistake; fall back to the shared
+		// client so the call fails with the vendor's error, not a panic.
+		if sharedClient == nil {
+			sharedClient, _ = newSharedClient(TransportOptions{}, "", nil)
+		}
+		return sharedClient
+	}
+	if perVendorCache == nil {
+		perVendorCache = make(map[string]*http.Client)
+	}
+	perVendorCache[key] = client
+	return client
+}
+
+// wsTransportPolicy exposes the parts of the shared transport policy that
+// websocket adapters, which cannot go through the pooled HTTP client, must
+// apply themselves at dial time.
+func wsTransportPolicy() (blockPrivate bool, timeout time.Duration) {
+	transportMu.Lock()
+	defer transportMu.Unlock()
+	blockPrivate = sharedOpts.BlockPrivateAddrs
+	timeout = sharedOpts.Timeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	return blockPrivate, timeout
+}
+
+// newSharedClient builds a tuned client. extraCAPEM adds an inline PEM
+// bundle on top of opts.CACertFile; rootCAs short-circuits both (tests).
+func newSharedClient(opts TransportOptions, extraCAPEM string, rootCAs *x509.CertPool) (*http.Client, error) {
+	if opts.MaxIdleConns <= 0 {
+		opts.MaxIdleConns = 100
+	}
+	if opts.MaxIdleConnsPerHost <= 0 {
+		opts.MaxIdleConnsPerHost = 16
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 60 * time.Second
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = opts.MaxIdleConns
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	transport.ForceAttemptHTTP2 = true
+	if opts.BlockPrivateAddrs {
+		transport.DialContext = guardedDialContext(transport.DialContext)
+	}
+	if opts.ProxyURL != "" {
+		proxy, err := url.Parse(opts.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if rootCAs == nil && (opts.CACertFile != "" || extraCAPEM != "") {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if opts.CACertFile != "" {
+			pem, err := os.ReadFile(opts.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("read CA bundle: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificates parsed from %s", opts.CACertFile)
+			}
+		}
+		if extraCAPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(extraCAPEM)) {
+				return nil, fmt.Errorf("no certificates parsed from ca_cert_pem")
+			}
+		}
+		rootCAs = pool
+	}
+	if rootCAs != nil {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+	var rt http.RoundTripper = transport
+	if opts.RecordFixturesDir != "" {
+		rt = &recordingTransport{dir: opts.RecordFixturesDir, next: transport}
+	}
+	return &http.Client{Transport: rt, Timeout: opts.Timeout}, nil
+}
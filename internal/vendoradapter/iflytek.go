@@ -0,0 +1,252 @@
+package vendoradapter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// iflytekASRAdapter calls the iFlytek (Xunfei) IAT streaming recognition API.
+// iFlytek exposes recognition only over websocket, authenticated by an
+// HMAC-SHA256 signature over the host, date and request line carried in the
+// connection URL. APIKey holds the APIKey and APISecret the APISecret from
+// the iFlytek console; the console's AppId goes in OtherConfigs.
+type iflytekASRAdapter struct {
+	cfg   *models.VendorConfig
+	other struct {
+		AppID string `json:"iflytek_app_id"`
+		// Domain selects the recognition domain; default "iat".
+		Domain string `json:"iflytek_domain"`
+	}
+}
+
+const iflytekDefaultEndpoint = "wss://iat-api.xfyun.cn/v2/iat"
+
+// iflytekFrameBytes is how much audio each websocket frame carries. iFlytek
+// recommends small frames paced like live audio but accepts batch uploads;
+// 10 KB keeps frame counts low without tripping their size limit.
+const iflytekFrameBytes = 10240
+
+func newIflytekASRAdapter(cfg *models.VendorConfig) *iflytekASRAdapter {
+	a := &iflytekASRAdapter{cfg: cfg}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *iflytekASRAdapter) Name() string { return "IFlytekASR" }
+
+func (a *iflytekASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	if a.other.AppID == "" {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: "IFlytekASR requires other_configs.iflytek_app_id"}
+	}
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = iflytekDefaultEndpoint
+	}
+	signed, err := a.signURL(endpoint, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	blockPrivate, timeout := wsTransportPolicy()
+	if AllowPrivateEgress(a.cfg) {
+		blockPrivate = false
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	conn, err := dialWebSocket(ctx, signed, blockPrivate)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := a.sendAudio(conn, req); err != nil {
+		return nil, err
+	}
+
+	// Collect every server message: the transcript arrives in pieces, and
+	// the concatenation of all messages is the archival raw response.
+	var messages []json.RawMessage
+	var transcript strings.Builder
+	for {
+		msg, err := conn.ReadText()
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, json.RawMessage(append([]byte(nil), msg...)))
+		text, status, err := parseIflytekMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		transcript.WriteString(text)
+		if status == 2 {
+			break
+		}
+	}
+	raw, err := json.Marshal(messages)
+	if err != nil {
+		return nil, err
+	}
+	return &ASRResponse{Transcript: transcript.String(), RawResponse: raw}, nil
+}
+
+// sendAudio streams the clip: a first frame carrying the session settings,
+// middle frames with audio only, and an empty end-of-stream frame.
+func (a *iflytekASRAdapter) sendAudio(conn *wsConn, req *ASRRequest) error {
+	language, accent := iflytekLanguage(req.Language)
+	domain := a.other.Domain
+	if domain == "" {
+		domain = "iat"
+	}
+	encoding := "raw"
+	if strings.EqualFold(req.Format, "mp3") {
+		encoding = "lame"
+	}
+	format := fmt.Sprintf("audio/L16;rate=%d", sampleRateOrDefault(req))
+
+	audio := req.Audio
+	first := true
+	for {
+		chunk := audio
+		if len(chunk) > iflytekFrameBytes {
+			chunk = chunk[:iflytekFrameBytes]
+		}
+		audio = audio[len(chunk):]
+
+		status := 1
+		if first {
+			status = 0
+		}
+		if len(audio) == 0 {
+			status = 2
+		}
+		frame := map[string]any{
+			"data": map[string]any{
+				"status":   status,
+				"format":   format,
+				"encoding": encoding,
+				"audio":    base64.StdEncoding.EncodeToString(chunk),
+			},
+		}
+		if first {
+			frame["common"] = map[string]any{"app_id": a.other.AppID}
+			business := map[string]any{"language": language, "domain": domain}
+			if accent != "" {
+				business["accent"] = accent
+			}
+			frame["business"] = business
+			first = false
+		}
+		payload, err := json.Marshal(frame)
+		if err != nil {
+			return err
+		}
+		if err := conn.WriteText(payload); err != nil {
+			return err
+		}
+		if status == 2 {
+			return nil
+		}
+	}
+}
+
+// signURL builds the HMAC-signed connection URL: the signature covers the
+// host, the RFC 1123 date and the websocket request line, and travels
+// base64-encoded in the authorization query parameter.
+func (a *iflytekASRAdapter) signURL(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", &AdapterError{Code: ErrCodeBadRequest,
+			Message: fmt.Sprintf("bad api_endpoint: %v", err)}
+	}
+	date := now.Format(http.TimeFormat)
+	origin := "host: " + u.Host + "\ndate: " + date + "\nGET " + u.Path + " HTTP/1.1"
+	signature := base64.StdEncoding.EncodeToString(
+		hmacSHA256([]byte(a.cfg.APISecret), origin))
+	auth := fmt.Sprintf(
+		`api_key="%s", algorithm="hmac-sha256", headers="host date request-line", signature="%s"`,
+		a.cfg.APIKey, signature)
+
+	q := u.Query()
+	q.Set("authorization", base64.StdEncoding.EncodeToString([]byte(auth)))
+	q.Set("date", date)
+	q.Set("host", u.Host)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// ParseRaw implements RawReplayer over the stored message array.
+func (a *iflytekASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	var messages []json.RawMessage
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable iflytek response: %v", err)}
+	}
+	var transcript strings.Builder
+	for _, msg := range messages {
+		text, _, err := parseIflytekMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		transcript.WriteString(text)
+	}
+	return &ASRResponse{Transcript: transcript.String(), RawResponse: raw}, nil
+}
+
+// parseIflytekMessage extracts the text of one server message and the stream
+// status (2 means final).
+func parseIflytekMessage(raw []byte) (text string, status int, err error) {
+	var parsed struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    struct {
+			Status int `json:"status"`
+			Result struct {
+				WS []struct {
+					CW []struct {
+						W string `json:"w"`
+					} `json:"cw"`
+				} `json:"ws"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return "", 0, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable iflytek message: %v", err)}
+	}
+	if parsed.Code != 0 {
+		code := ErrCodeVendorError
+		// 10105: illegal access (bad signature); 10313: invalid appid.
+		if parsed.Code == 10105 || parsed.Code == 10313 {
+			code = ErrCodeAuthFailed
+		}
+		return "", 0, &AdapterError{Code: code,
+			Message: fmt.Sprintf("iflytek error %d: %s", parsed.Code, parsed.Message)}
+	}
+	var b strings.Builder
+	for _, ws := range parsed.Data.Result.WS {
+		for _, cw := range ws.CW {
+			b.WriteString(cw.W)
+		}
+	}
+	return b.String(), parsed.Data.Status, nil
+}
+
+// iflytekLanguage maps a language tag to iFlytek's language/accent pair.
+func iflytekLanguage(language string) (lang, accent string) {
+	switch strings.ToLower(language) {
+	case "en-us", "en":
+		return "en_us", ""
+	default:
+		return "zh_cn", "mandarin"
+	}
+}
@@ -0,0 +1,168 @@
+package vendoradapter
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// MockASRAdapter is a stand-in vendor used for demos, local development and
+// load testing. Its behavior is configurable through OtherConfigs so
+// realistic latency distributions, error rates and accuracy levels can be
+// simulated against the real queue and engine:
+//
+//	mock_mean_latency_ms   mean simulated latency (default 100)
+//	mock_latency_jitter_ms uniform +/- jitter around the mean
+//	mock_error_rate        probability [0,1] a call fails with VENDOR_ERROR
+//	mock_target_wer        approximate WER of generated transcripts [0,1]
+//	mock_wer_jitter        uniform +/- jitter around the target WER
+//
+// When a target WER is set the transcript is generated by corrupting the
+// test case's ground truth; otherwise the ground truth (or the
+// mock_transcript job parameter) is returned verbatim.
+type MockASRAdapter struct {
+	cfg   *models.VendorConfig
+	other struct {
+		MeanLatencyMS   float64 `json:"mock_mean_latency_ms"`
+		LatencyJitterMS float64 `json:"mock_latency_jitter_ms"`
+		ErrorRate       float64 `json:"mock_error_rate"`
+		TargetWER       float64 `json:"mock_target_wer"`
+		WERJitter       float64 `json:"mock_wer_jitter"`
+	}
+}
+
+// NewMockASRAdapter builds a mock adapter for the given config.
+func NewMockASRAdapter(cfg *models.VendorConfig) *MockASRAdapter {
+	a := &MockASRAdapter{cfg: cfg}
+	a.other.MeanLatencyMS = 100
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+// Name implements ASRAdapter.
+func (a *MockASRAdapter) Name() string { return "MockASR" }
+
+// Recognize implements ASRAdapter.
+func (a *MockASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	select {
+	case <-time.After(a.simulatedLatency()):
+	case <-ctx.Done():
+		return nil, classifyTransportError(ctx.Err())
+	}
+
+	if a.other.ErrorRate > 0 && rand.Float64() < a.other.ErrorRate {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: "simulated vendor failure (mock_error_rate)"}
+	}
+
+	transcript := a.transcriptFor(req)
+	raw, _ := json.Marshal(map[string]any{
+		"mock":        true,
+		"transcript":  transcript,
+		"language":    req.Language,
+		"audio_bytes": len(req.Audio),
+	})
+	return &ASRResponse{
+		Transcript:  transcript,
+		RawResponse: raw,
+		Words:       a.simulatedWords(transcript, len(req.Audio)),
+		Confidence:  a.simulatedConfidence(),
+	}, nil
+}
+
+// simulatedConfidence tracks the configured accuracy: a high target WER
+// yields low confidence, with some jitter, so confidence-threshold analysis
+// behaves plausibly against the mock.
+func (a *MockASRAdapter) simulatedConfidence() float64 {
+	conf := 0.97 - a.other.TargetWER + (rand.Float64()-0.5)*0.1
+	if conf < 0 {
+		conf = 0
+	}
+	if conf > 1 {
+		conf = 1
+	}
+	return conf
+}
+
+// simulatedWords spreads the transcript's words evenly over the clip so
+// subtitle evaluation can run against the mock. The duration is estimated
+// from the audio size assuming 16 kHz 16-bit mono PCM.
+func (a *MockASRAdapter) simulatedWords(transcript string, audioBytes int) []models.WordAlignment {
+	words := strings.Fields(transcript)
+	if len(words) == 0 {
+		return nil
+	}
+	durationMS := int64(audioBytes) * 1000 / 32000
+	if durationMS <= 0 {
+		durationMS = int64(len(words)) * 300
+	}
+	per := durationMS / int64(len(words))
+	out := make([]models.WordAlignment, len(words))
+	for i, w := range words {
+		out[i] = models.WordAlignment{
+			Word:    w,
+			StartMS: int64(i) * per,
+			EndMS:   int64(i+1) * per,
+		}
+	}
+	return out
+}
+
+// ParseRaw implements RawReplayer.
+func (a *MockASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	var parsed struct {
+		Transcript string `json:"transcript"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: "unparseable mock response: " + err.Error()}
+	}
+	return &ASRResponse{Transcript: parsed.Transcript, RawResponse: raw}, nil
+}
+
+func (a *MockASRAdapter) simulatedLatency() time.Duration {
+	latency := a.other.MeanLatencyMS
+	if a.other.LatencyJitterMS > 0 {
+		latency += (rand.Float64()*2 - 1) * a.other.LatencyJitterMS
+	}
+	if latency < 0 {
+		latency = 0
+	}
+	return time.Duration(latency * float64(time.Millisecond))
+}
+
+func (a *MockASRAdapter) transcriptFor(req *ASRRequest) string {
+	if v, ok := req.Params["mock_transcript"].(string); ok && v != "" {
+		return v
+	}
+	if req.GroundTruth == "" {
+		return "this is a mock transcript"
+	}
+	if a.other.TargetWER <= 0 {
+		return req.GroundTruth
+	}
+	targetWER := a.other.TargetWER
+	if a.other.WERJitter > 0 {
+		targetWER += (rand.Float64()*2 - 1) * a.other.WERJitter
+	}
+	return corruptTranscript(req.GroundTruth, targetWER)
+}
+
+// corruptTranscript substitutes roughly wer of the ground-truth tokens so
+// downstream metrics land near the configured error rate.
+func corruptTranscript(groundTruth string, wer float64) string {
+	if wer <= 0 {
+		return groundTruth
+	}
+	words := strings.Fields(groundTruth)
+	for i := range words {
+		if rand.Float64() < wer {
+			words[i] = "[sub]"
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,87 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// playHTTTSAdapter calls the Play.ht v2 streaming synthesis API. APIKey
+// holds the secret key and OtherConfigs the user ID and voice selection.
+type playHTTTSAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		UserID  string `json:"playht_user_id"`
+		Voice   string `json:"playht_voice"`
+		Quality string `json:"playht_quality"` // draft, low, medium, high, premium
+	}
+}
+
+func newPlayHTTTSAdapter(cfg *models.VendorConfig) *playHTTTSAdapter {
+	a := &playHTTTSAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *playHTTTSAdapter) Name() string { return "PlayHTTTS" }
+
+func (a *playHTTTSAdapter) Synthesize(ctx context.Context, req *TTSRequest) (*TTSResponse, error) {
+	if a.other.UserID == "" || a.other.Voice == "" {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: "playht_user_id and playht_voice missing from OtherConfigs"}
+	}
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "https://api.play.ht/api/v2/tts/stream"
+	}
+
+	format := strings.ToLower(req.Format)
+	if format == "" {
+		format = "mp3"
+	}
+	payload := map[string]any{
+		"text":          req.Text,
+		"voice":         a.other.Voice,
+		"output_format": format,
+	}
+	if a.other.Quality != "" {
+		payload["quality"] = a.other.Quality
+	}
+	if req.SampleRate > 0 {
+		payload["sample_rate"] = req.SampleRate
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+a.cfg.APIKey)
+	httpReq.Header.Set("X-User-Id", a.other.UserID)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "audio/mpeg")
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, audio)
+	}
+	return &TTSResponse{Audio: audio, Format: format}, nil
+}
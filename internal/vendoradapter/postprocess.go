@@ -0,0 +1,47 @@
+package vendoradapter
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// PostProcessRule is one step of a vendor's transcript clean-up chain: a
+// regular expression and its replacement, applied in order. Configs declare
+// them under the OtherConfigs key "post_process" to strip vendor-specific
+// artifacts ("<unk>", speaker tags and the like) before scoring.
+type PostProcessRule struct {
+	Pattern string `json:"pattern"`
+	Replace string `json:"replace"`
+}
+
+// PostProcessRules returns a vendor config's transcript post-processing
+// chain, or nil when the config declares none.
+func PostProcessRules(cfg *models.VendorConfig) []PostProcessRule {
+	var other struct {
+		Rules []PostProcessRule `json:"post_process"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Rules
+}
+
+// ApplyPostProcess runs the rule chain over a transcript. Rules that fail to
+// compile are skipped with a log line rather than failing the result; the
+// output is whitespace-collapsed since deletions often leave double spaces.
+func ApplyPostProcess(rules []PostProcessRule, text string) string {
+	if len(rules) == 0 {
+		return text
+	}
+	out := text
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			log.Printf("post-process: skip invalid pattern %q: %v", rule.Pattern, err)
+			continue
+		}
+		out = re.ReplaceAllString(out, rule.Replace)
+	}
+	return strings.Join(strings.Fields(out), " ")
+}
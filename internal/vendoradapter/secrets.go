@@ -0,0 +1,197 @@
+package vendoradapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// Vendor configs may store a secret reference instead of a raw key, so real
+// credentials never live in the platform database. A reference has the form
+// "<scheme>://<path>", e.g. "vault://secret/data/asr#api_key" or
+// "kms://arn:aws:kms:us-east-1:123:key/abc". The scheme selects a registered
+// SecretProvider, which resolves the reference at call time; values without a
+// registered scheme are used verbatim as before.
+
+// SecretProvider resolves one secret reference into its raw value. Providers
+// are registered per scheme at startup; deployments with bespoke secret
+// stores register their own.
+type SecretProvider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+var (
+	secretMu        sync.RWMutex
+	secretProviders = map[string]SecretProvider{
+		"env": envSecretProvider{},
+	}
+)
+
+// RegisterSecretProvider installs a provider for a reference scheme,
+// replacing any previous provider for the same scheme.
+func RegisterSecretProvider(scheme string, p SecretProvider) {
+	secretMu.Lock()
+	defer secretMu.Unlock()
+	secretProviders[scheme] = p
+}
+
+// secretRefScheme returns the registered scheme a value references, or ""
+// when the value is a literal credential.
+func secretRefScheme(v string) string {
+	i := strings.Index(v, "://")
+	if i <= 0 {
+		return ""
+	}
+	scheme := v[:i]
+	secretMu.RLock()
+	defer secretMu.RUnlock()
+	if _, ok := secretProviders[scheme]; !ok {
+		return ""
+	}
+	return scheme
+}
+
+// hasSecretRefs reports whether a vendor config's credentials need call-time
+// resolution.
+func hasSecretRefs(cfg *models.VendorConfig) bool {
+	return secretRefScheme(cfg.APIKey) != "" || secretRefScheme(cfg.APISecret) != ""
+}
+
+// ResolveSecretRefs returns a shallow copy of the config with every secret
+// reference replaced by its resolved value. Configs without references are
+// returned unchanged.
+func ResolveSecretRefs(ctx context.Context, cfg *models.VendorConfig) (*models.VendorConfig, error) {
+	if !hasSecretRefs(cfg) {
+		return cfg, nil
+	}
+	clone := *cfg
+	var err error
+	if clone.APIKey, err = resolveSecret(ctx, cfg.APIKey); err != nil {
+		return nil, fmt.Errorf("api_key: %w", err)
+	}
+	if clone.APISecret, err = resolveSecret(ctx, cfg.APISecret); err != nil {
+		return nil, fmt.Errorf("api_secret: %w", err)
+	}
+	return &clone, nil
+}
+
+func resolveSecret(ctx context.Context, v string) (string, error) {
+	scheme := secretRefScheme(v)
+	if scheme == "" {
+		return v, nil
+	}
+	secretMu.RLock()
+	p := secretProviders[scheme]
+	secretMu.RUnlock()
+	value, err := p.Resolve(ctx, v[len(scheme)+len("://"):])
+	if err != nil {
+		return "", fmt.Errorf("resolve %s secret: %w", scheme, err)
+	}
+	return value, nil
+}
+
+// secretResolvingAdapter defers adapter construction until Recognize so
+// secret references resolve per call and rotated secrets take effect without
+// a restart. Resolution failures surface as AUTH_FAILED results.
+type secretResolvingAdapter struct {
+	cfg *models.VendorConfig
+}
+
+func (a *secretResolvingAdapter) Name() string {
+	return newASRAdapter(a.cfg).Name()
+}
+
+func (a *secretResolvingAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	resolved, err := ResolveSecretRefs(ctx, a.cfg)
+	if err != nil {
+		return nil, &AdapterError{Code: ErrCodeAuthFailed, Message: err.Error()}
+	}
+	return newASRAdapter(resolved).Recognize(ctx, req)
+}
+
+// ParseRaw needs no credentials, so replay works even when the secret store
+// is unreachable.
+func (a *secretResolvingAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	replayer, ok := newASRAdapter(a.cfg).(RawReplayer)
+	if !ok {
+		return nil, fmt.Errorf("adapter %s cannot replay raw responses", a.Name())
+	}
+	return replayer.ParseRaw(raw)
+}
+
+// envSecretProvider resolves "env://VAR" references from the process
+// environment. It exists mainly for development and tests, but also covers
+// deployments that inject secrets via the environment.
+type envSecretProvider struct{}
+
+func (envSecretProvider) Resolve(_ context.Context, ref string) (string, error) {
+	v, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return v, nil
+}
+
+// VaultSecretProvider resolves "vault://<api-path>#<field>" references from a
+// HashiCorp Vault KV v2 store, e.g. "vault://secret/data/asr#api_key". The
+// path is the Vault API path under /v1/; the fragment names the field inside
+// the secret's data.
+type VaultSecretProvider struct {
+	// Addr is the Vault base URL, e.g. https://vault.internal:8200.
+	Addr string
+	// Token authenticates the platform against Vault.
+	Token string
+}
+
+func (p *VaultSecretProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok || field == "" {
+		return "", fmt.Errorf("vault reference %q must name a field after #", ref)
+	}
+	url := strings.TrimSuffix(p.Addr, "/") + "/v1/" + path
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	httpReq.Header.Set("X-Vault-Token", p.Token)
+	resp, err := sharedHTTPClient().Do(httpReq)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned HTTP %d: %s", resp.StatusCode, truncate(string(body), 200))
+	}
+	// KV v2 nests the fields under data.data; KV v1 puts them under data.
+	var parsed struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return "", fmt.Errorf("parse vault response: %w", err)
+	}
+	if v, ok := parsed.Data.Data[field]; ok {
+		return v, nil
+	}
+	var v1 struct {
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(body, &v1); err == nil {
+		if v, ok := v1.Data[field]; ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("vault secret %s has no field %q", path, field)
+}
@@ -0,0 +1,111 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// selfHostedASRAdapter benchmarks on-prem models behind an arbitrary HTTP
+// inference server described entirely by the vendor config. Unlike
+// GenericHTTPASR, which posts the raw audio as the request body, this adapter
+// uploads a multipart form — the convention of whisper.cpp, faster-whisper
+// and most OpenAI-compatible self-hosted servers — with the file field name,
+// extra form fields and transcript location all taken from OtherConfigs.
+// WhisperCppASR remains the zero-config path for stock whisper.cpp; this
+// adapter covers everything with a different form shape.
+type selfHostedASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		// FileField names the multipart field carrying the audio; default
+		// "file".
+		FileField string `json:"file_field"`
+		// FormFields are sent verbatim as additional form fields, e.g.
+		// {"response_format": "json", "beam_size": "5"}.
+		FormFields map[string]string `json:"form_fields"`
+		// LanguageField names the form field carrying the request language;
+		// empty omits it.
+		LanguageField string `json:"language_field"`
+		// Headers are sent verbatim on every request.
+		Headers map[string]string `json:"headers"`
+		// TranscriptPath locates the transcript in the JSON reply, dot
+		// separated with numeric array indices. Default "text".
+		TranscriptPath string `json:"transcript_path"`
+	}
+}
+
+func newSelfHostedASRAdapter(cfg *models.VendorConfig) *selfHostedASRAdapter {
+	a := &selfHostedASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *selfHostedASRAdapter) Name() string { return "SelfHostedHTTP" }
+
+func (a *selfHostedASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	if a.cfg.APIEndpoint == "" {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: "SelfHostedHTTP requires api_endpoint"}
+	}
+
+	fileField := a.other.FileField
+	if fileField == "" {
+		fileField = "file"
+	}
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile(fileField, "audio."+req.Format)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(req.Audio); err != nil {
+		return nil, err
+	}
+	for name, value := range a.other.FormFields {
+		_ = form.WriteField(name, value)
+	}
+	if a.other.LanguageField != "" && req.Language != "" {
+		_ = form.WriteField(a.other.LanguageField, req.Language)
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.cfg.APIEndpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", form.FormDataContentType())
+	for name, value := range a.other.Headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return a.ParseRaw(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *selfHostedASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	path := a.other.TranscriptPath
+	if path == "" {
+		path = "text"
+	}
+	return transcriptAtPath(raw, path)
+}
@@ -0,0 +1,73 @@
+package vendoradapter
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// SSRF guard: endpoints and URLs in vendor configs are user-supplied, so a
+// malicious or mistaken config could point an adapter at the metadata
+// service, the datastore or another internal host. The guard validates the
+// destination at dial time — after DNS resolution — and dials the vetted IP
+// directly, so a rebinding DNS record cannot swap in a private address
+// between check and connect.
+
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// guardedDialContext wraps a dialer so every outbound connection resolves
+// the target itself, rejects private, loopback and link-local addresses and
+// connects to the vetted IP.
+func guardedDialContext(next dialFunc) dialFunc {
+	if next == nil {
+		d := &net.Dialer{}
+		next = d.DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		var lastErr error
+		for _, ip := range addrs {
+			if privateAddr(ip.IP) {
+				lastErr = fmt.Errorf("dial %s: resolved to blocked address %s", host, ip.IP)
+				continue
+			}
+			conn, err := next(ctx, network, net.JoinHostPort(ip.IP.String(), port))
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+		if lastErr == nil {
+			lastErr = fmt.Errorf("dial %s: no addresses resolved", host)
+		}
+		return nil, lastErr
+	}
+}
+
+// privateAddr reports whether an IP must not be reached from a vendor call:
+// RFC1918 and ULA ranges, loopback, link-local (including the cloud
+// metadata range) and unspecified addresses.
+func privateAddr(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
+
+// AllowPrivateEgress reports whether a vendor config explicitly opts out of
+// the private-address guard (OtherConfigs key "allow_private_egress"), e.g.
+// for a self-hosted engine on the internal network.
+func AllowPrivateEgress(cfg *models.VendorConfig) bool {
+	var other struct {
+		Allow bool `json:"allow_private_egress"`
+	}
+	_ = decodeOtherConfigs(cfg, &other)
+	return other.Allow
+}
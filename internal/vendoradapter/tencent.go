@@ -0,0 +1,181 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// tencentASRAdapter calls the Tencent Cloud SentenceRecognition API using
+// TC3-HMAC-SHA256 request signing. APIKey holds the SecretId and APISecret
+// the SecretKey.
+type tencentASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		Region string `json:"tencent_region"` // e.g. ap-guangzhou
+	}
+}
+
+const (
+	tencentHost    = "asr.tencentcloudapi.com"
+	tencentService = "asr"
+	tencentAction  = "SentenceRecognition"
+	tencentVersion = "2019-06-14"
+)
+
+func newTencentASRAdapter(cfg *models.VendorConfig) *tencentASRAdapter {
+	a := &tencentASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *tencentASRAdapter) Name() string { return "TencentCloudASR" }
+
+func (a *tencentASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	payload, err := json.Marshal(map[string]any{
+		"EngSerViceType": tencentEngineType(req.Language),
+		"SourceType":     1,
+		"VoiceFormat":    strings.ToLower(req.Format),
+		"Data":           base64.StdEncoding.EncodeToString(req.Audio),
+		"DataLen":        len(req.Audio),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal tencent request: %w", err)
+	}
+
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "https://" + tencentHost
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Host", tencentHost)
+	httpReq.Header.Set("X-TC-Action", tencentAction)
+	httpReq.Header.Set("X-TC-Version", tencentVersion)
+	httpReq.Header.Set("X-TC-Timestamp", fmt.Sprintf("%d", now.Unix()))
+	if a.other.Region != "" {
+		httpReq.Header.Set("X-TC-Region", a.other.Region)
+	}
+	httpReq.Header.Set("Authorization",
+		a.signTC3(payload, now))
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return parseTencentResponse(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *tencentASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	return parseTencentResponse(raw)
+}
+
+func parseTencentResponse(raw []byte) (*ASRResponse, error) {
+	var parsed struct {
+		Response struct {
+			Result string `json:"Result"`
+			Error  *struct {
+				Code    string `json:"Code"`
+				Message string `json:"Message"`
+			} `json:"Error"`
+		} `json:"Response"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable tencent response: %v", err)}
+	}
+	if parsed.Response.Error != nil {
+		code := ErrCodeVendorError
+		if strings.HasPrefix(parsed.Response.Error.Code, "AuthFailure") {
+			code = ErrCodeAuthFailed
+		}
+		return nil, &AdapterError{Code: code,
+			Message: parsed.Response.Error.Code + ": " + parsed.Response.Error.Message}
+	}
+	return &ASRResponse{Transcript: parsed.Response.Result, RawResponse: raw}, nil
+}
+
+// signTC3 implements the TC3-HMAC-SHA256 signing scheme for a JSON POST.
+func (a *tencentASRAdapter) signTC3(payload []byte, now time.Time) string {
+	date := now.UTC().Format("2006-01-02")
+
+	canonicalRequest := strings.Join([]string{
+		"POST",
+		"/",
+		"",
+		"content-type:application/json\nhost:" + tencentHost + "\n",
+		"content-type;host",
+		sha256Hex(payload),
+	}, "\n")
+
+	credentialScope := date + "/" + tencentService + "/tc3_request"
+	stringToSign := strings.Join([]string{
+		"TC3-HMAC-SHA256",
+		fmt.Sprintf("%d", now.Unix()),
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	secretDate := hmacSHA256([]byte("TC3"+a.cfg.APISecret), date)
+	secretService := hmacSHA256(secretDate, tencentService)
+	secretSigning := hmacSHA256(secretService, "tc3_request")
+	signature := hex.EncodeToString(hmacSHA256(secretSigning, stringToSign))
+
+	return fmt.Sprintf(
+		"TC3-HMAC-SHA256 Credential=%s/%s, SignedHeaders=content-type;host, Signature=%s",
+		a.cfg.APIKey, credentialScope, signature)
+}
+
+// tencentEngineType maps a language tag to Tencent's engine model type for
+// sentence recognition.
+func tencentEngineType(language string) string {
+	switch strings.ToLower(language) {
+	case "zh-cn", "zh":
+		return "16k_zh"
+	case "en-us", "en":
+		return "16k_en"
+	case "yue", "zh-hk":
+		return "16k_yue"
+	case "ja-jp", "ja":
+		return "16k_ja"
+	default:
+		return "16k_zh"
+	}
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, msg string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(msg))
+	return h.Sum(nil)
+}
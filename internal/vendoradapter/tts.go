@@ -0,0 +1,86 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// TTSRequest carries one synthesis call. Voice selection and vendor-specific
+// tuning (stability, quality tiers) come from the vendor config rather than
+// the request, so test cases stay vendor-neutral.
+type TTSRequest struct {
+	Text       string
+	Format     string // mp3, wav, pcm
+	SampleRate int    // Hz; 0 means the adapter's default
+	Language   string // BCP-47 style tag, informative only for most vendors
+	Params     map[string]any
+}
+
+// TTSResponse is the normalized result of a synthesis call: the synthesized
+// audio plus whatever metadata the vendor returned alongside it.
+type TTSResponse struct {
+	Audio              []byte
+	Format             string
+	VendorProcessingMS int64
+}
+
+// TTSAdapter is implemented once per speech-synthesis vendor. The TTS
+// evaluation subsystem dispatches through it exactly like the ASR engine
+// dispatches through ASRAdapter.
+type TTSAdapter interface {
+	Name() string
+	Synthesize(ctx context.Context, req *TTSRequest) (*TTSResponse, error)
+}
+
+// GetTTSAdapter resolves the adapter for a vendor config by its adapter key.
+// Unknown keys fall back to the mock adapter, matching GetASRAdapter.
+func GetTTSAdapter(cfg *models.VendorConfig) TTSAdapter {
+	switch AdapterKey(cfg) {
+	case "ElevenLabsTTS":
+		return newElevenLabsTTSAdapter(cfg)
+	case "PlayHTTTS":
+		return newPlayHTTTSAdapter(cfg)
+	default:
+		return &mockTTSAdapter{}
+	}
+}
+
+// mockTTSAdapter synthesizes silence sized to the text length, so the TTS
+// pipeline can be exercised end to end without vendor credentials.
+type mockTTSAdapter struct{}
+
+func (m *mockTTSAdapter) Name() string { return "MockTTS" }
+
+func (m *mockTTSAdapter) Synthesize(_ context.Context, req *TTSRequest) (*TTSResponse, error) {
+	rate := req.SampleRate
+	if rate <= 0 {
+		rate = 16000
+	}
+	// Roughly 15 characters per second of speech.
+	seconds := len(req.Text)/15 + 1
+	samples := rate * seconds
+	return &TTSResponse{Audio: silentWAV(rate, samples), Format: "wav"}, nil
+}
+
+// silentWAV renders a 16-bit mono PCM WAV file of silence.
+func silentWAV(rate, samples int) []byte {
+	dataLen := samples * 2
+	buf := &bytes.Buffer{}
+	buf.WriteString("RIFF")
+	binary.Write(buf, binary.LittleEndian, uint32(36+dataLen))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(buf, binary.LittleEndian, uint32(16))
+	binary.Write(buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(buf, binary.LittleEndian, uint16(1)) // mono
+	binary.Write(buf, binary.LittleEndian, uint32(rate))
+	binary.Write(buf, binary.LittleEndian, uint32(rate*2))
+	binary.Write(buf, binary.LittleEndian, uint16(2))
+	binary.Write(buf, binary.LittleEndian, uint16(16))
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, uint32(dataLen))
+	buf.Write(make([]byte, dataLen))
+	return buf.Bytes()
+}
@@ -0,0 +1,121 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// volcengineASRAdapter calls the Volcengine (ByteDance) one-shot speech
+// recognition HTTP API. APIKey holds the access token; OtherConfigs must
+// provide the app id and cluster.
+type volcengineASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		AppID   string `json:"volcengine_app_id"`
+		Cluster string `json:"volcengine_cluster"`
+	}
+}
+
+func newVolcengineASRAdapter(cfg *models.VendorConfig) *volcengineASRAdapter {
+	a := &volcengineASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *volcengineASRAdapter) Name() string { return "VolcengineASR" }
+
+func (a *volcengineASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	if a.other.AppID == "" {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: "volcengine_app_id missing from OtherConfigs"}
+	}
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "https://openspeech.bytedance.com/api/v1/asr"
+	}
+	cluster := a.other.Cluster
+	if cluster == "" {
+		cluster = "volcengine_streaming_common"
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"app": map[string]string{
+			"appid":   a.other.AppID,
+			"token":   a.cfg.APIKey,
+			"cluster": cluster,
+		},
+		"user": map[string]string{"uid": "aitestplatform"},
+		"request": map[string]any{
+			"reqid":    fmt.Sprintf("aitp-%d", time.Now().UnixNano()),
+			"language": strings.ToLower(req.Language),
+			"nbest":    1,
+		},
+		"audio": map[string]any{
+			"format": strings.ToLower(req.Format),
+			"rate":   sampleRateOrDefault(req),
+			"data":   base64.StdEncoding.EncodeToString(req.Audio),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal volcengine request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer; "+a.cfg.APIKey)
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return parseVolcengineResponse(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *volcengineASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	return parseVolcengineResponse(raw)
+}
+
+func parseVolcengineResponse(raw []byte) (*ASRResponse, error) {
+	var parsed struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Result  []struct {
+			Text string `json:"text"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable volcengine response: %v", err)}
+	}
+	if parsed.Code != 1000 && parsed.Code != 0 {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("volcengine code %d: %s", parsed.Code, parsed.Message)}
+	}
+	transcript := ""
+	if len(parsed.Result) > 0 {
+		transcript = parsed.Result[0].Text
+	}
+	return &ASRResponse{Transcript: transcript, RawResponse: raw}, nil
+}
@@ -0,0 +1,230 @@
+package vendoradapter
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// Minimal RFC 6455 websocket client for the adapters of vendors that only
+// expose streaming endpoints. It deliberately covers just what those
+// protocols need — text frames, ping/pong and clean closes — so the platform
+// stays free of a websocket dependency. Connections are per-call, matching
+// how the streaming vendors meter sessions, so there is no pooling to share
+// with the HTTP transport; the private-address guard is applied at dial time
+// instead.
+
+const wsKeyMagic = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+type wsConn struct {
+	conn net.Conn
+	br   *bufio.Reader
+}
+
+// dialWebSocket connects and upgrades to a websocket. A non-101 handshake
+// reply is surfaced through classifyHTTPStatus so auth failures carry the
+// same error codes as the HTTP adapters.
+func dialWebSocket(ctx context.Context, rawURL string, blockPrivate bool) (*wsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, &AdapterError{Code: ErrCodeBadRequest,
+			Message: fmt.Sprintf("bad websocket endpoint: %v", err)}
+	}
+	port := u.Port()
+	if port == "" {
+		if u.Scheme == "wss" {
+			port = "443"
+		} else {
+			port = "80"
+		}
+	}
+
+	dial := (&net.Dialer{}).DialContext
+	if blockPrivate {
+		dial = guardedDialContext(dial)
+	}
+	conn, err := dial(ctx, "tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if u.Scheme == "wss" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, classifyTransportError(err)
+		}
+		conn = tlsConn
+	}
+
+	keyBytes := make([]byte, 16)
+	if _, err := rand.Read(keyBytes); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(keyBytes)
+
+	req := fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\n"+
+		"Connection: Upgrade\r\nSec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\n\r\n",
+		u.RequestURI(), u.Host, key)
+	if _, err := io.WriteString(conn, req); err != nil {
+		conn.Close()
+		return nil, classifyTransportError(err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	if err != nil {
+		conn.Close()
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		resp.Body.Close()
+		conn.Close()
+		return nil, classifyHTTPStatus(resp.StatusCode, body)
+	}
+	sum := sha1.Sum([]byte(key + wsKeyMagic))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		conn.Close()
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: "websocket handshake returned a bad accept key"}
+	}
+	return &wsConn{conn: conn, br: br}, nil
+}
+
+// WriteText sends one masked text frame, as clients must.
+func (c *wsConn) WriteText(payload []byte) error {
+	return c.writeFrame(0x1, payload)
+}
+
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	header := []byte{0x80 | opcode}
+	switch {
+	case len(payload) <= 125:
+		header = append(header, 0x80|byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		header = append(header, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(len(payload)))
+	default:
+		header = append(header, 0x80|127, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(len(payload)))
+	}
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return err
+	}
+	header = append(header, mask...)
+	masked := make([]byte, len(payload))
+	for i, b := range payload {
+		masked[i] = b ^ mask[i%4]
+	}
+	if _, err := c.conn.Write(append(header, masked...)); err != nil {
+		return classifyTransportError(err)
+	}
+	return nil
+}
+
+// wsMaxMessageBytes caps a single frame and a reassembled message. IAT
+// replies are JSON transcript fragments, at most a few KB; the declared
+// frame length comes from the (config-overridable) server, so without a cap
+// a hostile endpoint could declare a multi-GB frame and OOM the worker.
+const wsMaxMessageBytes = 8 << 20
+
+// ReadText returns the next complete text or binary message, transparently
+// answering pings and reassembling fragmented messages. A close frame from
+// the server surfaces as io.EOF.
+func (c *wsConn) ReadText() ([]byte, error) {
+	var message []byte
+	for {
+		opcode, fin, payload, err := c.readFrame()
+		if err != nil {
+			return nil, err
+		}
+		switch opcode {
+		case 0x1, 0x2, 0x0:
+			if len(message)+len(payload) > wsMaxMessageBytes {
+				return nil, &AdapterError{Code: ErrCodeVendorError,
+					Message: fmt.Sprintf("websocket message exceeds %d bytes", wsMaxMessageBytes)}
+			}
+			message = append(message, payload...)
+			if fin {
+				return message, nil
+			}
+		case 0x8: // close
+			return nil, io.EOF
+		case 0x9: // ping
+			if err := c.writeFrame(0xA, payload); err != nil {
+				return nil, err
+			}
+		case 0xA: // unsolicited pong, ignore
+		default:
+			return nil, &AdapterError{Code: ErrCodeVendorError,
+				Message: fmt.Sprintf("unexpected websocket opcode %#x", opcode)}
+		}
+	}
+}
+
+func (c *wsConn) readFrame() (opcode byte, fin bool, payload []byte, err error) {
+	head := make([]byte, 2)
+	if _, err = io.ReadFull(c.br, head); err != nil {
+		return 0, false, nil, classifyTransportError(err)
+	}
+	fin = head[0]&0x80 != 0
+	opcode = head[0] & 0x0F
+	masked := head[1]&0x80 != 0
+	length := uint64(head[1] & 0x7F)
+	switch length {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err = io.ReadFull(c.br, ext); err != nil {
+			return 0, false, nil, classifyTransportError(err)
+		}
+		length = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err = io.ReadFull(c.br, ext); err != nil {
+			return 0, false, nil, classifyTransportError(err)
+		}
+		length = binary.BigEndian.Uint64(ext)
+	}
+	// Validate the server-declared length before allocating for it.
+	if length > wsMaxMessageBytes {
+		return 0, false, nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("websocket frame declares %d bytes, limit is %d", length, wsMaxMessageBytes)}
+	}
+	var mask []byte
+	if masked {
+		mask = make([]byte, 4)
+		if _, err = io.ReadFull(c.br, mask); err != nil {
+			return 0, false, nil, classifyTransportError(err)
+		}
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c.br, payload); err != nil {
+		return 0, false, nil, classifyTransportError(err)
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return opcode, fin, payload, nil
+}
+
+// Close sends a close frame and tears the connection down.
+func (c *wsConn) Close() error {
+	_ = c.writeFrame(0x8, nil)
+	return c.conn.Close()
+}
@@ -0,0 +1,105 @@
+package vendoradapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/Jcateye/AITestPlatform/internal/models"
+)
+
+// whisperASRAdapter calls a self-hosted whisper.cpp (or faster-whisper)
+// inference server, so open-source baselines appear in comparisons without
+// paid API calls. No credentials are involved; the endpoint is typically on
+// a private network, so configs usually pair it with allow_private_egress.
+type whisperASRAdapter struct {
+	cfg    *models.VendorConfig
+	client *http.Client
+	other  struct {
+		// Model selects the loaded model size on servers that serve
+		// several (tiny, base, small, medium, large-v3).
+		Model string `json:"whisper_model"`
+		// Temperature tunes decoding; 0 is whisper's deterministic default.
+		Temperature float64 `json:"whisper_temperature"`
+	}
+}
+
+func newWhisperASRAdapter(cfg *models.VendorConfig) *whisperASRAdapter {
+	a := &whisperASRAdapter{cfg: cfg, client: httpClientFor(cfg)}
+	_ = decodeOtherConfigs(cfg, &a.other)
+	return a
+}
+
+func (a *whisperASRAdapter) Name() string { return "WhisperCppASR" }
+
+func (a *whisperASRAdapter) Recognize(ctx context.Context, req *ASRRequest) (*ASRResponse, error) {
+	endpoint := a.cfg.APIEndpoint
+	if endpoint == "" {
+		endpoint = "http://localhost:8080/inference"
+	}
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile("file", "audio."+req.Format)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(req.Audio); err != nil {
+		return nil, err
+	}
+	_ = form.WriteField("response_format", "json")
+	if req.Language != "" {
+		_ = form.WriteField("language", req.Language)
+	}
+	if a.other.Model != "" {
+		_ = form.WriteField("model", a.other.Model)
+	}
+	if a.other.Temperature > 0 {
+		_ = form.WriteField("temperature",
+			strconv.FormatFloat(a.other.Temperature, 'f', -1, 64))
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", form.FormDataContentType())
+
+	resp, err := a.client.Do(httpReq)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, classifyTransportError(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, classifyHTTPStatus(resp.StatusCode, raw)
+	}
+	return parseWhisperResponse(raw)
+}
+
+// ParseRaw implements RawReplayer.
+func (a *whisperASRAdapter) ParseRaw(raw json.RawMessage) (*ASRResponse, error) {
+	return parseWhisperResponse(raw)
+}
+
+func parseWhisperResponse(raw []byte) (*ASRResponse, error) {
+	var parsed struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return nil, &AdapterError{Code: ErrCodeVendorError,
+			Message: fmt.Sprintf("unparseable whisper response: %v", err)}
+	}
+	return &ASRResponse{Transcript: parsed.Text, RawResponse: raw}, nil
+}